@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event"
+	libhttp "github.com/channel-io/cht-app-github/internal/http"
+	"github.com/channel-io/cht-app-github/internal/queue"
+)
+
+const bearerPrefix = "Bearer "
+
+type Handler struct {
+	eventHandler *event.GithubEventHandler
+	jobQueue     queue.Queue
+	token        string
+}
+
+func NewHandler(
+	conf *config.Config,
+	eventHandler *event.GithubEventHandler,
+	jobQueue queue.Queue,
+) *Handler {
+	return &Handler{
+		eventHandler: eventHandler,
+		jobQueue:     jobQueue,
+		token:        conf.Admin.Token,
+	}
+}
+
+func (h *Handler) Path() string {
+	return "/admin/v1"
+}
+
+func (h *Handler) Register(router libhttp.Router) {
+	router.POST("/deliveries", h.reprocessDelivery)
+}
+
+// Reprocess Delivery godoc
+//
+//	@Summary		Reprocess Delivery
+//	@Description	Reprocess a webhook delivery even if it was already processed. Send the original delivery with its GitHub headers.
+//	@Tags			Admin
+//	@Produce		plain
+//	@Param			Authorization	header	string	true	"Bearer admin token"
+//	@Success		202
+//	@Failure		400
+//	@Failure		401
+//	@Router			/admin/v1/deliveries [post]
+func (h *Handler) reprocessDelivery(ctx *gin.Context) {
+	// NOTE : token 이 설정되지 않은 경우 재처리 요청을 받지 않습니다.
+	token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), bearerPrefix)
+	if h.token == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// NOTE : 관리자 token 이 유출되더라도 임의의 event 를 만들 수 없도록 github 서명도 검증합니다.
+	delivery, err := h.eventHandler.ParseDelivery(ctx.Request)
+	if err != nil {
+		_ = ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	delivery.Force = true
+	delivery.Run = fmt.Sprintf("%s:%d", delivery.ID, time.Now().UnixNano())
+
+	if err := h.jobQueue.Enqueue(ctx, delivery.Job()); err != nil {
+		_ = ctx.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
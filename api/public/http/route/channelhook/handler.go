@@ -0,0 +1,78 @@
+package channelhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	libhttp "github.com/channel-io/cht-app-github/internal/http"
+	"github.com/channel-io/cht-app-github/internal/logger"
+)
+
+type Handler struct {
+	replySvc *svc.ReplySvc
+	logger   logger.Logger
+	token    string
+}
+
+func NewHandler(conf *config.Config, replySvc *svc.ReplySvc, logger logger.Logger) *Handler {
+	return &Handler{
+		replySvc: replySvc,
+		logger:   logger,
+		token:    conf.ChannelTalk.Webhook.Token,
+	}
+}
+
+func (h *Handler) Path() string {
+	return "/hook/channel/v1"
+}
+
+func (h *Handler) Register(router libhttp.Router) {
+	router.POST("", h.processEvent)
+}
+
+// Process Channel Talk Event godoc
+//
+//	@Summary		Process Channel Talk Event
+//	@Description	Mirror manager replies in issue and pull request threads to GitHub comments
+//	@Tags			Hook
+//	@Param			token	query	string	true	"webhook token"
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Router			/hook/channel/v1 [post]
+func (h *Handler) processEvent(ctx *gin.Context) {
+	// NOTE : token 이 설정되지 않은 경우 webhook 을 받지 않습니다.
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(ctx.Query("token")), []byte(h.token)) != 1 {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	var event model.WebhookEvent
+	if err := ctx.ShouldBindJSON(&event); err != nil {
+		_ = ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if event.Type != model.WebhookTypeMessage {
+		ctx.Status(http.StatusOK)
+		return
+	}
+
+	var message model.ChatMessage
+	if err := json.Unmarshal(event.Entity, &message); err != nil {
+		_ = ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.replySvc.MirrorReply(ctx, message); err != nil {
+		h.logger.Errorw("failed to mirror reply", "messageID", message.ID, "error", err)
+		_ = ctx.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
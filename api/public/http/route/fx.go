@@ -3,6 +3,8 @@ package route
 import (
 	"go.uber.org/fx"
 
+	"github.com/channel-io/cht-app-github/api/public/http/route/admin"
+	"github.com/channel-io/cht-app-github/api/public/http/route/channelhook"
 	"github.com/channel-io/cht-app-github/api/public/http/route/function"
 	"github.com/channel-io/cht-app-github/api/public/http/route/hook"
 	"github.com/channel-io/cht-app-github/api/public/http/route/ping"
@@ -20,7 +22,9 @@ func Module() fx.Option {
 			route(swagger.NewHandler),
 			route(version.NewHandler),
 			route(hook.NewHandler),
+			route(channelhook.NewHandler),
 			route(function.NewHandler),
+			route(admin.NewHandler),
 		),
 	)
 }
@@ -7,17 +7,21 @@ import (
 
 	"github.com/channel-io/cht-app-github/internal/event"
 	libhttp "github.com/channel-io/cht-app-github/internal/http"
+	"github.com/channel-io/cht-app-github/internal/queue"
 )
 
 type Handler struct {
 	eventHandler *event.GithubEventHandler
+	jobQueue     queue.Queue
 }
 
 func NewHandler(
 	eventHandler *event.GithubEventHandler,
+	jobQueue queue.Queue,
 ) *Handler {
 	return &Handler{
 		eventHandler: eventHandler,
+		jobQueue:     jobQueue,
 	}
 }
 
@@ -32,15 +36,22 @@ func (h *Handler) Register(router libhttp.Router) {
 // Ping godoc
 //
 //	@Summary		Process Event
-//	@Description	Process webhook event
+//	@Description	Verify webhook event and enqueue it for asynchronous processing
 //	@Tags			Hook
 //	@Produce		plain
-//	@Success		200
+//	@Success		202
+//	@Failure		400
 //	@Router			/hook/v1 [post]
 func (h *Handler) processEvent(ctx *gin.Context) {
-	if err := h.eventHandler.HandleEventRequest(ctx.Request); err != nil {
-		_ = ctx.Error(err)
+	delivery, err := h.eventHandler.ParseDelivery(ctx.Request)
+	if err != nil {
+		_ = ctx.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
-	ctx.Status(http.StatusOK)
+
+	if err := h.jobQueue.Enqueue(ctx, delivery.Job()); err != nil {
+		_ = ctx.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	ctx.Status(http.StatusAccepted)
 }
@@ -16,6 +16,8 @@ import (
 	"github.com/channel-io/cht-app-github/internal/logger"
 	"github.com/channel-io/cht-app-github/internal/loggerfx"
 	"github.com/channel-io/cht-app-github/internal/metricfx"
+	"github.com/channel-io/cht-app-github/internal/schedulerfx"
+	"github.com/channel-io/cht-app-github/internal/storagefx"
 )
 
 const (
@@ -51,9 +53,11 @@ func internalModule() fx.Option {
 		httpfx.Option,
 		loggerfx.Option,
 		eventfx.Option,
+		schedulerfx.Option,
 		metricfx.MetricServerModule(),
 		functionfx.Module(),
 		githubfx.Module(),
+		storagefx.Module(),
 	)
 }
 
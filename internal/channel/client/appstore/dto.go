@@ -66,6 +66,15 @@ type Pagination struct {
 	Limit     int    `json:"limit"`
 }
 
+type SearchTeamsRequest struct {
+	ChannelID  string     `json:"channelId"`
+	Pagination Pagination `json:"pagination"`
+}
+
+func (s *SearchTeamsRequest) Method() string {
+	return "searchTeams"
+}
+
 type GetManagerRequest struct {
 	ChannelID string `json:"channelId"`
 	ManagerID string `json:"managerId"`
@@ -86,6 +95,23 @@ type SearchManagersResponse struct {
 	Next     string       `json:"next"`
 }
 
+type SearchTeamsResponse struct {
+	Teams []TeamDTO `json:"teams"`
+	Next  string    `json:"next"`
+}
+
+type TeamDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (t *TeamDTO) ToModel() model.Team {
+	return model.Team{
+		ID:   t.ID,
+		Name: t.Name,
+	}
+}
+
 type GetManagerResponse struct {
 	Manager ManagerDTO `json:"manager"`
 }
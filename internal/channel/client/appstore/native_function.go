@@ -64,3 +64,22 @@ func (c *Client) GetManager(ctx context.Context, req *GetManagerRequest) (*GetMa
 
 	return &res, nil
 }
+
+func (c *Client) SearchTeams(ctx context.Context, req *SearchTeamsRequest) (*SearchTeamsResponse, error) {
+	token, err := c.getAccessToken(ctx, req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.invokeNativeFunction(ctx, token, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res SearchTeamsResponse
+	if err := json.Unmarshal(r, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -11,4 +11,5 @@ type Client interface {
 	WriteThreadMessage(ctx context.Context, channelId, groupId, rootMessageId string, message *model.Message, broadcast bool) error
 	ListManagers(ctx context.Context, channelID string) ([]model.Manager, error)
 	GetManager(ctx context.Context, channelID, managerID string) (model.Manager, error)
+	ListTeams(ctx context.Context, channelID string) ([]model.Team, error)
 }
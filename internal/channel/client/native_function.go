@@ -100,3 +100,38 @@ func (s *NativeFunction) GetManager(ctx context.Context, channelID, managerID st
 
 	return res.Manager.ToModel(), nil
 }
+
+func (s *NativeFunction) ListTeams(ctx context.Context, channelID string) ([]model.Team, error) {
+	next := ""
+	teams := make([]model.Team, 0, pageSize)
+
+	req := appstore.SearchTeamsRequest{
+		ChannelID: channelID,
+		Pagination: appstore.Pagination{
+			SortOrder: appstore.SortOrderAsc,
+			Limit:     pageSize,
+		},
+	}
+
+	for {
+		if next != "" {
+			req.Pagination.Since = next
+		}
+
+		res, err := s.client.SearchTeams(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range res.Teams {
+			teams = append(teams, t.ToModel())
+		}
+
+		if len(res.Teams) < pageSize {
+			break
+		}
+		next = res.Next
+	}
+
+	return teams, nil
+}
@@ -76,6 +76,32 @@ func (b MessageBlock) MarshalJSON() ([]byte, error) {
 	return marshalJSONWithoutEscapeHTML(m)
 }
 
+// UnmarshalJSON 은 팀챗 message event 의 block 을 읽습니다. 알 수 없는 type 의 block 은 내용 없이 type 만 읽습니다.
+func (b *MessageBlock) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type     BlockType      `json:"type"`
+		Value    string         `json:"value"`
+		Language *string        `json:"language"`
+		Blocks   []MessageBlock `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*b = MessageBlock{Type: raw.Type}
+	switch raw.Type {
+	case BlockTypeText:
+		b.Text.Value = raw.Value
+
+	case BlockTypeCode:
+		b.Code = Code{Language: raw.Language, Value: raw.Value}
+
+	case BlockTypeBullets:
+		b.Bullets.Blocks = raw.Blocks
+	}
+	return nil
+}
+
 // Text := { type: "text", value: ANTLRString }
 type Text struct {
 	Value string
@@ -215,3 +215,22 @@ func TestBlocks_MarshalJSON_By_Unmarshal(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestBlocks_UnmarshalJSON(t *testing.T) {
+	language := "go"
+	expected := []MessageBlock{
+		NewTextBlock("This is " + Bold("bold")),
+		NewCodeBlock("<script>ChannelIO('boot')</script>", &language),
+		NewBulletsBlock([]MessageBlock{NewTextBlock("first"), NewTextBlock("second")}),
+	}
+
+	data, err := marshalJSONWithoutEscapeHTML(expected)
+	assert.NoError(t, err)
+
+	var actual []MessageBlock
+	assert.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, expected, actual)
+
+	assert.NoError(t, json.Unmarshal([]byte(`[{"type":"unknown","value":"x"}]`), &actual))
+	assert.Equal(t, []MessageBlock{{Type: "unknown"}}, actual)
+}
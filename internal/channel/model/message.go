@@ -2,6 +2,8 @@ package model
 
 type Message struct {
 	Blocks []MessageBlock `json:"blocks"`
+	// URL 은 github 에서 전체 내용을 볼 수 있는 url 입니다. message 가 너무 길어 생략한 경우 link 로 남깁니다.
+	URL string `json:"-"`
 }
 
 func NewMessage(blocks ...MessageBlock) *Message {
@@ -9,3 +11,9 @@ func NewMessage(blocks ...MessageBlock) *Message {
 		Blocks: blocks,
 	}
 }
+
+// WithURL 은 message 의 원문 url 을 설정합니다.
+func (m *Message) WithURL(url string) *Message {
+	m.URL = url
+	return m
+}
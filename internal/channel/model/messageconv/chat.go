@@ -0,0 +1,304 @@
+package messageconv
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+var (
+	// tagRegex 는 ANTLRString 의 <b>, <i>, <link> tag 입니다.
+	tagRegex     = regexp.MustCompile(`<(/?)(b|i|link)((?:\s+[a-zA-Z]+="[^"]*")*)\s*>`)
+	tagAttrRegex = regexp.MustCompile(`([a-zA-Z]+)="([^"]*)"`)
+	// verbatimRegex 는 escape 하지 않고 그대로 작성할 code span 과 emoji 입니다.
+	verbatimRegex = regexp.MustCompile("`[^`\n]+`|:[-+_0-9a-zA-Z]+:")
+	// blockMarkerRegex 는 줄의 처음에 있으면 heading, list, quote 등으로 해석되는 문자입니다.
+	blockMarkerRegex = regexp.MustCompile(`^[ \t]*(?:#{1,6}(?:[ \t]|$)|[+-](?:[ \t]|$)|>|\d{1,9}[.)](?:[ \t]|$)|=+[ \t]*$|-+[ \t]*$)`)
+	markdownEscape   = strings.NewReplacer(
+		`\`, `\\`,
+		"*", `\*`,
+		"_", `\_`,
+		"`", "\\`",
+		"~", `\~`,
+		"[", `\[`,
+		"]", `\]`,
+		"<", `\<`,
+	)
+	// nestedItemRegex 는 GithubMarkdownConverter 가 bullet 의 text 로 작성한 하위 list 의 item 입니다. (ex. "  *item")
+	nestedItemRegex = regexp.MustCompile(`^( +)(\\\*|[-+.)])(\S)`)
+	// bulletMarkers 는 연속된 bullets block 이 하나의 list 로 합쳐지지 않도록 번갈아 사용하는 marker 입니다.
+	bulletMarkers = []string{"-", "+"}
+)
+
+// ChatMessageConverter 는 팀챗 message 의 block 을 github markdown 으로 변환합니다.
+// GithubMarkdownConverter 의 역변환입니다.
+type ChatMessageConverter struct {
+	blocks []model.MessageBlock
+	// githubUsernames is map from manager ID to github username
+	githubUsernames map[string]string
+}
+
+// ToGithubMarkdown 은 managerMap(github username → Manager)으로 manager 멘션을 @github-username 으로 변환합니다.
+// github username 을 모르는 manager 는 이름으로 작성합니다.
+func ToGithubMarkdown(blocks []model.MessageBlock, managerMap map[string]model.Manager) *ChatMessageConverter {
+	githubUsernames := make(map[string]string, len(managerMap))
+	for _, manager := range managerMap {
+		if manager.GithubUsername != nil {
+			githubUsernames[manager.ID] = *manager.GithubUsername
+		}
+	}
+	return &ChatMessageConverter{
+		blocks:          blocks,
+		githubUsernames: githubUsernames,
+	}
+}
+
+// chatNode 는 ANTLRString 을 tag 단위로 나눈 tree 입니다. tag 가 비어있으면 text 입니다.
+type chatNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*chatNode
+}
+
+// Convert 는 block 을 빈 줄로 구분된 paragraph 로 작성합니다. 내용이 없는 block 은 건너뜁니다.
+func (c *ChatMessageConverter) Convert() string {
+	paragraphs := make([]string, 0, len(c.blocks))
+	bullets := 0
+	for _, block := range c.blocks {
+		var paragraph string
+		switch block.Type {
+		case model.BlockTypeText:
+			paragraph = c.convertText(block.Text.Value)
+
+		case model.BlockTypeCode:
+			paragraph = convertCode(block.Code)
+
+		case model.BlockTypeBullets:
+			paragraph = c.convertBullets(block.Bullets, bulletMarkers[bullets%len(bulletMarkers)])
+		}
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+
+		if block.Type == model.BlockTypeBullets {
+			bullets++
+		} else {
+			bullets = 0
+		}
+		paragraphs = append(paragraphs, paragraph)
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// convertText 는 ANTLRString 을 변환하고, 줄의 처음에 있는 block 문법을 escape 합니다.
+func (c *ChatMessageConverter) convertText(source string) string {
+	var buf strings.Builder
+	for _, node := range parseChatText(source) {
+		c.writeNode(&buf, node)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	for i, line := range lines {
+		lines[i] = escapeBlockMarker(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *ChatMessageConverter) convertBullets(bullets model.Bullets, marker string) string {
+	items := make([]string, 0, len(bullets.Blocks))
+	for _, block := range bullets.Blocks {
+		text := c.convertText(block.Text.Value)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines := strings.Split(text, "\n")
+		for i := 1; i < len(lines); i++ {
+			lines[i] = convertNestedItem(lines[i])
+		}
+		items = append(items, marker+" "+strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+// convertNestedItem 은 하위 list 의 item 을 markdown list 로 작성하고, 그 외의 줄은 item 에 이어지도록 들여씁니다.
+func convertNestedItem(line string) string {
+	match := nestedItemRegex.FindStringSubmatchIndex(line)
+	if match == nil {
+		return "  " + line
+	}
+	indent, marker := line[:match[3]], line[match[4]:match[5]]
+	switch marker {
+	case `\*`:
+		marker = "*"
+	case ".", ")":
+		marker = "1" + marker
+	}
+	return indent + marker + " " + line[match[6]:]
+}
+
+// convertCode 는 code 에 포함된 것보다 긴 fence 로 감쌉니다.
+func convertCode(code model.Code) string {
+	fence := "```"
+	for strings.Contains(code.Value, fence) {
+		fence += "`"
+	}
+
+	var buf strings.Builder
+	buf.WriteString(fence)
+	if code.Language != nil {
+		buf.WriteString(*code.Language)
+	}
+	buf.WriteString("\n")
+	buf.WriteString(code.Value)
+	if !strings.HasSuffix(code.Value, "\n") {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fence)
+	return buf.String()
+}
+
+func (c *ChatMessageConverter) writeNode(buf *strings.Builder, node *chatNode) {
+	switch node.tag {
+	case "":
+		writeText(buf, html.UnescapeString(node.text))
+
+	case "b":
+		buf.WriteString("**")
+		c.writeChildren(buf, node)
+		buf.WriteString("**")
+
+	case "i":
+		buf.WriteString("_")
+		c.writeChildren(buf, node)
+		buf.WriteString("_")
+
+	case "link":
+		c.writeLink(buf, node)
+
+	default:
+		c.writeChildren(buf, node)
+	}
+}
+
+func (c *ChatMessageConverter) writeLink(buf *strings.Builder, node *chatNode) {
+	value := html.UnescapeString(node.attrs["value"])
+	switch model.MentionType(node.attrs["type"]) {
+	case model.MentionTypeManager:
+		if username, ok := c.githubUsernames[value]; ok {
+			buf.WriteString("@" + username)
+			return
+		}
+		c.writeChildren(buf, node)
+
+	case model.MentionTypeTeam:
+		c.writeChildren(buf, node)
+
+	default:
+		// NOTE : value 가 없는 url link 는 text 가 url 입니다. url 은 escape 하지 않고 autolink 로 작성합니다.
+		text := plainText(node)
+		if value == "" {
+			value = text
+		}
+		if text == "" || text == value {
+			buf.WriteString(value)
+			return
+		}
+		buf.WriteString("[")
+		c.writeChildren(buf, node)
+		buf.WriteString("](" + linkDestination(value) + ")")
+	}
+}
+
+func (c *ChatMessageConverter) writeChildren(buf *strings.Builder, node *chatNode) {
+	for _, child := range node.children {
+		c.writeNode(buf, child)
+	}
+}
+
+// writeText 는 code span 과 emoji 를 제외한 markdown 문법을 escape 합니다.
+func writeText(buf *strings.Builder, text string) {
+	last := 0
+	for _, match := range verbatimRegex.FindAllStringIndex(text, -1) {
+		buf.WriteString(markdownEscape.Replace(text[last:match[0]]))
+		buf.WriteString(text[match[0]:match[1]])
+		last = match[1]
+	}
+	buf.WriteString(markdownEscape.Replace(text[last:]))
+}
+
+// linkDestination 은 공백이나 괄호가 있는 url 을 <> 로 감쌉니다.
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+// escapeBlockMarker 는 줄의 처음에 있는 heading, list, quote 문법을 escape 합니다.
+// ordered list 는 숫자 뒤의 . 또는 ) 를 escape 합니다.
+func escapeBlockMarker(line string) string {
+	loc := blockMarkerRegex.FindStringIndex(line)
+	if loc == nil {
+		return line
+	}
+	at := len(line[:loc[1]]) - len(strings.TrimLeft(line[:loc[1]], " \t"))
+	if i := strings.IndexAny(line[at:loc[1]], ".)"); i > 0 {
+		at += i
+	}
+	return line[:at] + `\` + line[at:]
+}
+
+func plainText(node *chatNode) string {
+	if node.tag == "" {
+		return html.UnescapeString(node.text)
+	}
+	var buf strings.Builder
+	for _, child := range node.children {
+		buf.WriteString(plainText(child))
+	}
+	return buf.String()
+}
+
+// parseChatText 는 ANTLRString 을 tree 로 나눕니다. 짝이 맞지 않는 닫는 tag 는 무시하고, 닫히지 않은 tag 는 끝에서 닫습니다.
+func parseChatText(source string) []*chatNode {
+	root := &chatNode{tag: "root"}
+	stack := []*chatNode{root}
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, &chatNode{text: text})
+	}
+
+	last := 0
+	for _, match := range tagRegex.FindAllStringSubmatchIndex(source, -1) {
+		appendText(source[last:match[0]])
+		last = match[1]
+
+		closing := source[match[2]:match[3]] == "/"
+		tag := source[match[4]:match[5]]
+		if closing {
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == tag {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		node := &chatNode{tag: tag, attrs: make(map[string]string)}
+		for _, attr := range tagAttrRegex.FindAllStringSubmatch(source[match[6]:match[7]], -1) {
+			node.attrs[attr[1]] = attr[2]
+		}
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, node)
+		stack = append(stack, node)
+	}
+	appendText(source[last:])
+	return root.children
+}
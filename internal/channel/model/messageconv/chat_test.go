@@ -0,0 +1,217 @@
+package messageconv
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+func TestToGithubMarkdown_Convert(t *testing.T) {
+	t.Parallel()
+
+	managerMap := map[string]model.Manager{
+		"dylan": {ID: "1", Name: "딜런", GithubUsername: lo.ToPtr("Dylan")},
+		"lento": {ID: "2", Name: "렌토"},
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "emphasis",
+			source:   "<b>bold</b> and <i>italic <b>nested</b></i>",
+			expected: "**bold** and _italic **nested**_",
+		},
+		{
+			name:     "link",
+			source:   `<link type="url" value="https://github.com/channel-io/cht-app-github/pull/1">#1</link> <link type="url" value="https://channel.io">https://channel.io</link>`,
+			expected: "[#1](https://github.com/channel-io/cht-app-github/pull/1) https://channel.io",
+		},
+		{
+			name:     "url without value",
+			source:   `<link type="url">https://example.com/a_b</link>`,
+			expected: "https://example.com/a_b",
+		},
+		{
+			name:     "mention",
+			source:   `cc <link type="manager" value="1">딜런</link> <link type="manager" value="2">렌토</link>`,
+			expected: "cc @Dylan 렌토",
+		},
+		{
+			name:     "escape",
+			source:   "a &lt; b &amp;&amp; *not bold* :smile:",
+			expected: `a \< b && \*not bold\* :smile:`,
+		},
+		{
+			name:     "emoji and code span",
+			source:   ":white_check_mark: run `go test ./...` in snake_case",
+			expected: ":white_check_mark: run `go test ./...` in snake\\_case",
+		},
+		{
+			name:     "block markers",
+			source:   "# not heading\n- not bullet\n1. not ordered\n&gt; not quote",
+			expected: "\\# not heading\n\\- not bullet\n1\\. not ordered\n\\> not quote",
+		},
+		{
+			name:     "unbalanced tags",
+			source:   "</b>text <b>open",
+			expected: "text **open**",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, ToGithubMarkdown([]model.MessageBlock{model.NewTextBlock(tt.source)}, managerMap).Convert())
+		})
+	}
+}
+
+func TestToGithubMarkdown_Convert_Blocks(t *testing.T) {
+	t.Parallel()
+
+	language := "go"
+	blocks := []model.MessageBlock{
+		model.NewTextBlock("<b>title</b>"),
+		model.NewCodeBlock("fmt.Println(\"```\")", &language),
+		model.NewBulletsBlock([]model.MessageBlock{model.NewTextBlock("first"), model.NewTextBlock("second\nline")}),
+		model.NewBulletsBlock([]model.MessageBlock{model.NewTextBlock("other list")}),
+		model.NewTextBlock(""),
+		model.NewTextBlock("end"),
+	}
+	expected := "**title**\n\n" +
+		"````go\nfmt.Println(\"```\")\n````\n\n" +
+		"- first\n- second\n  line\n\n" +
+		"+ other list\n\n" +
+		"end"
+	assert.Equal(t, expected, ToGithubMarkdown(blocks, nil).Convert())
+}
+
+var roundTripManagers = map[string]model.Manager{
+	"dylan":  {ID: "1", Name: "딜런", GithubUsername: lo.ToPtr("Dylan")},
+	"claud":  {ID: "2", Name: "클로드", GithubUsername: lo.ToPtr("claud")},
+	"nabi-x": {ID: "3", Name: "나비", GithubUsername: lo.ToPtr("nabi-x")},
+}
+
+// markdown → block → markdown → block 의 결과가 처음 변환한 block 과 같아야 합니다.
+func TestToGithubMarkdown_RoundTrip_Testdata(t *testing.T) {
+	t.Parallel()
+
+	entries, err := testdata.ReadDir("testdata")
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		entry := entry
+		t.Run(entry.Name(), func(t *testing.T) {
+			t.Parallel()
+			md, err := testdata.ReadFile("testdata/" + entry.Name())
+			assert.NoError(t, err)
+
+			blocks := FromGithubMarkdown(md, roundTripManagers).Convert()
+			converted := ToGithubMarkdown(blocks, roundTripManagers).Convert()
+			assert.Equal(t, blocks, FromGithubMarkdown([]byte(converted), roundTripManagers).Convert(), converted)
+		})
+	}
+}
+
+// 임의로 만든 block 을 markdown 으로 변환한 뒤 다시 block 으로 변환하면 처음 block 과 같아야 합니다.
+func TestToGithubMarkdown_RoundTrip_Random(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(20240601))
+	for i := 0; i < 500; i++ {
+		blocks := randomBlocks(r)
+		converted := ToGithubMarkdown(blocks, roundTripManagers).Convert()
+		if !assert.Equal(t, blocks, FromGithubMarkdown([]byte(converted), roundTripManagers).Convert(), converted) {
+			return
+		}
+	}
+}
+
+// randomBlocks 는 FromGithubMarkdown 이 만드는 형태의 block 을 만듭니다.
+func randomBlocks(r *rand.Rand) []model.MessageBlock {
+	blocks := make([]model.MessageBlock, 0, 4)
+	for i := 0; i < 1+r.Intn(4); i++ {
+		switch r.Intn(4) {
+		case 0:
+			code := strings.Repeat(randomWord(r, false)+" ", 1+r.Intn(3)) + "\n"
+			if r.Intn(2) == 0 {
+				code += "```\n"
+			}
+			blocks = append(blocks, model.NewCodeBlock(code, nil))
+
+		case 1:
+			items := make([]model.MessageBlock, 0, 3)
+			for j := 0; j < 1+r.Intn(3); j++ {
+				items = append(items, model.NewTextBlock(randomLine(r)))
+			}
+			blocks = append(blocks, model.NewBulletsBlock(items))
+
+		default:
+			lines := make([]string, 0, 3)
+			for j := 0; j < 1+r.Intn(3); j++ {
+				lines = append(lines, randomLine(r))
+			}
+			blocks = append(blocks, model.NewTextBlock(strings.Join(lines, "\n")))
+		}
+	}
+	return blocks
+}
+
+func randomLine(r *rand.Rand) string {
+	tokens := make([]string, 0, 6)
+	for i := 0; i < 1+r.Intn(6); i++ {
+		tokens = append(tokens, randomToken(r))
+	}
+	return strings.Join(tokens, " ")
+}
+
+func randomToken(r *rand.Rand) string {
+	switch r.Intn(10) {
+	case 0:
+		return model.Bold(randomWord(r, false))
+	case 1:
+		return model.Italic(randomWord(r, false))
+	case 2:
+		// NOTE : goldmark 은 bullet 의 처음에 있는 [x](url) 을 task list 로 해석하므로 두 글자 이상으로 작성합니다.
+		return model.InlineLink(fmt.Sprintf("https://example.com/docs/%d", r.Intn(100)), "ln"+randomWord(r, false))
+	case 3:
+		url := fmt.Sprintf("https://example.com/page/%d", r.Intn(100))
+		return model.InlineLink(url, url)
+	case 4:
+		number := r.Intn(1000)
+		return model.InlineLink(fmt.Sprintf("https://github.com/channel-io/cht-app-github/pull/%d", number), fmt.Sprintf("#%d", number))
+	case 5:
+		manager := []model.Manager{roundTripManagers["dylan"], roundTripManagers["claud"], roundTripManagers["nabi-x"]}[r.Intn(3)]
+		return model.Mention(model.MentionTypeManager, manager.ID, manager.Name)
+	case 6:
+		return model.Emoji([]string{"smile", "white_check_mark", "+1", "rocket"}[r.Intn(4)])
+	case 7:
+		return "`" + model.EscapedString(randomWord(r, true)) + "`"
+	default:
+		return model.EscapedString(randomWord(r, true))
+	}
+}
+
+// randomWord 는 공백이 없는 단어입니다. punctuation 이 true 이면 markdown 문법 문자를 포함합니다.
+func randomWord(r *rand.Rand, punctuation bool) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789가나다"
+	const symbols = `*_[]<>&\#-+.!~"'()=`
+	alphabet := []rune(letters)
+	if punctuation {
+		alphabet = append(alphabet, []rune(symbols)...)
+	}
+
+	word := make([]rune, 0, 8)
+	for i := 0; i < 1+r.Intn(8); i++ {
+		word = append(word, alphabet[r.Intn(len(alphabet))])
+	}
+	return string(word)
+}
@@ -9,11 +9,28 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/width"
 
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 )
 
+const (
+	checkedTaskEmoji   = "white_check_mark"
+	uncheckedTaskEmoji = "white_large_square"
+	// strikethroughOverlay 는 글자 위에 겹쳐 그리는 가로줄(U+0336)입니다. 채널톡에는 취소선 문법이 없습니다.
+	strikethroughOverlay = '\u0336'
+	quotePrefix          = "> "
+)
+
+var (
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlBreakRegex   = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagRegex     = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+)
+
 type GithubMarkdownConverter struct {
 	source []byte
 	// managerMap is map from `githubID` to Manager
@@ -50,14 +67,16 @@ func (r *GithubMarkdownConverter) Convert() []model.MessageBlock {
 	root := gm.Parser().Parse(rd)
 	blocks := make([]model.MessageBlock, 0, root.ChildCount())
 	for child := root.FirstChild(); child != nil; child = child.NextSibling() {
-		blocks = append(blocks, r.buildMessageBlock(child))
+		if block, ok := r.buildMessageBlock(child); ok {
+			blocks = append(blocks, block)
+		}
 	}
 
 	return blocks
 }
 
-// buildMessageBlock build a single block for a leaf node
-func (r *GithubMarkdownConverter) buildMessageBlock(node ast.Node) model.MessageBlock {
+// buildMessageBlock build a single block for a leaf node. 내용이 없는 node(ex. html 주석)는 false 를 반환한다.
+func (r *GithubMarkdownConverter) buildMessageBlock(node ast.Node) (model.MessageBlock, bool) {
 	switch node.Kind() {
 	case ast.KindList:
 		blocks := make([]model.MessageBlock, 0, node.ChildCount())
@@ -65,23 +84,36 @@ func (r *GithubMarkdownConverter) buildMessageBlock(node ast.Node) model.Message
 			text := r.buildListText(child)
 			blocks = append(blocks, model.NewTextBlock(text))
 		}
-		return model.NewBulletsBlock(blocks)
+		return model.NewBulletsBlock(blocks), true
 
 	case ast.KindCodeBlock:
 		text := r.buildText(node, false)
-		return model.NewCodeBlock(text, nil)
+		return model.NewCodeBlock(text, nil), true
 
 	case ast.KindFencedCodeBlock:
 		// Note: Language는 채널톡 블록 v1.0 문서 상으로 Hidden Spec으로 되어 있어서 사용하지 않기로 결정.
 		// b := node.(*ast.FencedCodeBlock)
 		// lang := string(b.Language(r.source))
 		text := r.buildText(node, false)
-		return model.NewCodeBlock(text, nil)
+		return model.NewCodeBlock(text, nil), true
+
+	case extast.KindTable:
+		// Note: 표는 열을 맞춘 code block 으로 보낸다.
+		return model.NewCodeBlock(r.buildTableText(node), nil), true
+
+	case ast.KindBlockquote:
+		text := r.buildQuoteText(node)
+		return model.NewTextBlock(text), text != ""
+
+	case ast.KindHTMLBlock:
+		text := model.EscapedString(stripHTML(string(r.buildRawTextAux(node))))
+		text = strings.TrimSpace(r.replaceManagerMention(text))
+		return model.NewTextBlock(text), text != ""
 	}
 
 	return model.NewTextBlock(
 		r.buildText(node, true),
-	)
+	), true
 }
 
 func (r *GithubMarkdownConverter) buildText(node ast.Node, escape bool) string {
@@ -119,9 +151,43 @@ func (r *GithubMarkdownConverter) buildTextAux(buf *bytes.Buffer, node ast.Node,
 	case ast.KindLink:
 		b := node.(*ast.Link)
 		url := string(b.Destination)
-		value := string(b.Text(r.source))
+		value := model.EscapedString(string(r.buildRawTextAux(node)))
 		buf.WriteString(model.InlineLink(url, value))
 
+	case ast.KindImage:
+		b := node.(*ast.Image)
+		url := string(b.Destination)
+		alt := model.EscapedString(string(r.buildRawTextAux(node)))
+		if alt == "" {
+			alt = model.EscapedString(url)
+		}
+		buf.WriteString(model.InlineLink(url, alt))
+
+	case extast.KindTaskCheckBox:
+		if node.(*extast.TaskCheckBox).IsChecked {
+			buf.WriteString(model.Emoji(checkedTaskEmoji))
+		} else {
+			buf.WriteString(model.Emoji(uncheckedTaskEmoji))
+		}
+		buf.WriteRune(' ')
+
+	case extast.KindStrikethrough:
+		for _, c := range r.buildRawText(node, escape) {
+			buf.WriteRune(c)
+			if c != ' ' && c != '\n' {
+				buf.WriteRune(strikethroughOverlay)
+			}
+		}
+
+	case ast.KindRawHTML:
+		segments := node.(*ast.RawHTML).Segments
+		var raw bytes.Buffer
+		for i := 0; i < segments.Len(); i++ {
+			segment := segments.At(i)
+			raw.Write(segment.Value(r.source))
+		}
+		buf.WriteString(model.EscapedString(stripHTML(raw.String())))
+
 	case ast.KindCodeSpan:
 		buf.WriteRune('`')
 		buf.WriteString(r.buildRawText(node, escape))
@@ -129,11 +195,10 @@ func (r *GithubMarkdownConverter) buildTextAux(buf *bytes.Buffer, node ast.Node,
 
 	case ast.KindText:
 		b := node.(*ast.Text)
+		buf.WriteString(r.buildRawText(node, escape))
 		if b.SoftLineBreak() || b.HardLineBreak() {
 			buf.Write([]byte("\n"))
-			return
 		}
-		buf.WriteString(r.buildRawText(node, escape))
 
 	default:
 		if node.Type() == ast.TypeBlock && !node.IsRaw() {
@@ -170,7 +235,11 @@ func (r *GithubMarkdownConverter) buildRawText(node ast.Node, escape bool) strin
 }
 
 func (r *GithubMarkdownConverter) buildRawTextAux(node ast.Node) []byte {
-	if node.Type() == ast.TypeInline {
+	if node.Kind() == ast.KindText && node.Parent().Kind() != ast.KindCodeSpan {
+		// Note: \* 처럼 escape 된 문자는 escape 를 제거한다.
+		return util.UnescapePunctuations(node.Text(r.source))
+	}
+	if node.Type() == ast.TypeInline && node.Kind() != ast.KindEmphasis && node.Kind() != ast.KindLink {
 		return node.Text(r.source)
 	}
 
@@ -246,3 +315,107 @@ func (r *GithubMarkdownConverter) buildListTextAux(buf *bytes.Buffer, node ast.N
 		buf.WriteString(r.buildText(node, true))
 	}
 }
+
+// buildQuoteText 는 인용문의 각 줄 앞에 "> " 를 붙인다. 인용문 안의 list 는 "- " 로 시작하는 줄로 작성한다.
+func (r *GithubMarkdownConverter) buildQuoteText(node ast.Node) string {
+	var lines []string
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.Kind() == ast.KindList {
+			for item := child.FirstChild(); item != nil; item = item.NextSibling() {
+				lines = append(lines, "- "+r.buildListText(item))
+			}
+			continue
+		}
+		if block, ok := r.buildMessageBlock(child); ok && block.Type == model.BlockTypeText {
+			lines = append(lines, block.Text.Value)
+		} else if ok && block.Type == model.BlockTypeCode {
+			lines = append(lines, model.EscapedString(strings.TrimSuffix(block.Code.Value, "\n")))
+		}
+	}
+
+	text := strings.Join(lines, "\n")
+	if text == "" {
+		return ""
+	}
+	prefix := model.EscapedString(quotePrefix)
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
+
+// buildTableText 는 표의 열 너비를 맞춘 text 를 만든다. 정렬은 header 아래의 구분선을 따른다.
+func (r *GithubMarkdownConverter) buildTableText(node ast.Node) string {
+	table := node.(*extast.Table)
+	var rows [][]string
+	for row := node.FirstChild(); row != nil; row = row.NextSibling() {
+		cells := make([]string, 0, row.ChildCount())
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, strings.TrimSpace(string(util.UnescapePunctuations(r.buildRawTextAux(cell)))))
+		}
+		rows = append(rows, cells)
+	}
+
+	widths := make([]int, len(table.Alignments))
+	for _, cells := range rows {
+		for i, cell := range cells {
+			if i < len(widths) && displayWidth(cell) > widths[i] {
+				widths[i] = displayWidth(cell)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for n, cells := range rows {
+		line := make([]string, len(widths))
+		for i := range widths {
+			var cell string
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			line[i] = alignCell(cell, widths[i], table.Alignments[i])
+		}
+		buf.WriteString(strings.TrimRight(strings.Join(line, " | "), " "))
+		buf.WriteRune('\n')
+
+		if n == 0 {
+			separators := make([]string, len(widths))
+			for i, w := range widths {
+				separators[i] = strings.Repeat("-", max(w, 1))
+			}
+			buf.WriteString(strings.Join(separators, "-|-"))
+			buf.WriteRune('\n')
+		}
+	}
+	return buf.String()
+}
+
+func alignCell(cell string, w int, alignment extast.Alignment) string {
+	padding := w - displayWidth(cell)
+	switch alignment {
+	case extast.AlignRight:
+		return strings.Repeat(" ", padding) + cell
+	case extast.AlignCenter:
+		return strings.Repeat(" ", padding/2) + cell + strings.Repeat(" ", padding-padding/2)
+	default:
+		return cell + strings.Repeat(" ", padding)
+	}
+}
+
+// displayWidth 는 고정폭 글꼴에서의 너비이다. 한글 등 전각 문자는 두 칸을 차지한다.
+func displayWidth(s string) int {
+	w := 0
+	for _, c := range s {
+		switch width.LookupRune(c).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
+
+// stripHTML 은 html 주석과 tag 를 제거한다. <br> 은 줄바꿈으로 바꾼다.
+func stripHTML(s string) string {
+	s = htmlCommentRegex.ReplaceAllString(s, "")
+	s = htmlBreakRegex.ReplaceAllString(s, "\n")
+	return htmlTagRegex.ReplaceAllString(s, "")
+}
@@ -251,3 +251,140 @@ func TestFromMarkdown_Convert_Regression_Escape(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+func TestFromMarkdown_Convert_Table(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/table.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// table is rendered as code block with aligned columns
+		model.NewCodeBlock(
+			"Name   | Status | Count\n"+
+				"-------|--------|------\n"+
+				"채널톡 |   ok   |     1\n"+
+				"github |  fail  |   120\n",
+			nil,
+		),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_TaskList(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/tasklist.md")
+	assert.NoError(t, err)
+
+	managerMap := map[string]model.Manager{
+		"claud": {
+			Name: "클로드",
+			ID:   "12345",
+		},
+	}
+
+	actual := FromGithubMarkdown(md, managerMap).Convert()
+	expected := []model.MessageBlock{
+		model.NewTextBlock("<b>Checklist</b>"),
+		model.NewBulletsBlock(
+			[]model.MessageBlock{
+				model.NewTextBlock(":white_check_mark: tests added"),
+				model.NewTextBlock(":white_large_square: docs updated by <link type=\"manager\" value=\"12345\">클로드</link>"),
+			},
+		),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_Blockquote(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/blockquote.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		model.NewTextBlock("&gt; <b>Note</b>\n&gt; This is a quote\n&gt; - with a list"),
+		model.NewTextBlock("after quote"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_Image(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/image.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// image is converted to link
+		model.NewTextBlock("<link type=\"url\" value=\"https://example.com/screenshot.png\">screenshot</link>"),
+		model.NewTextBlock("<link type=\"url\" value=\"https://example.com/no-alt.png\">https://example.com/no-alt.png</link> inline"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_HTML(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/html.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// comment is removed, and tags are stripped
+		model.NewTextBlock("Changes"),
+		model.NewTextBlock("more text"),
+		model.NewTextBlock("line\nbreak"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_Strikethrough(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/strikethrough.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		model.NewTextBlock("d̶e̶p̶r̶e̶c̶a̶t̶e̶d̶ use <b>new</b> API"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_SoftLineBreak(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/soft_line_break.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// text before the soft line break is kept
+		model.NewTextBlock("first line\nsecond line\nthird line"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_LinkText(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/link_text.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// link text is escaped, and emphasis markers in link text are removed
+		model.NewTextBlock("<link type=\"url\" value=\"https://channel.io\">a &lt; b &amp; c</link>\n<link type=\"url\" value=\"https://channel.io/docs\">bold link</link>"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFromMarkdown_Convert_Escape(t *testing.T) {
+	t.Parallel()
+	md, err := testdata.ReadFile("testdata/escape.md")
+	assert.NoError(t, err)
+
+	actual := FromGithubMarkdown(md, nil).Convert()
+	expected := []model.MessageBlock{
+		// backslash escapes are removed
+		model.NewTextBlock("*not italic* and 1. not ordered\n<link type=\"url\" value=\"https://channel.io\">[web-1] link</link>"),
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,6 @@
+package model
+
+type Team struct {
+	ID   string
+	Name string
+}
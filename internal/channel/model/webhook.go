@@ -0,0 +1,40 @@
+package model
+
+import "encoding/json"
+
+const (
+	WebhookTypeMessage = "message"
+
+	ChatTypeGroup     = "group"
+	PersonTypeManager = "manager"
+)
+
+// WebhookEvent 는 channel 의 webhook 으로 받는 event 입니다. Entity 는 Type 에 따라 다릅니다.
+type WebhookEvent struct {
+	Event  string          `json:"event"`
+	Type   string          `json:"type"`
+	Entity json.RawMessage `json:"entity"`
+}
+
+// ChatMessage 는 message webhook event 의 entity 입니다.
+type ChatMessage struct {
+	ID         string `json:"id"`
+	ChannelID  string `json:"channelId"`
+	ChatType   string `json:"chatType"`
+	ChatID     string `json:"chatId"`
+	PersonType string `json:"personType"`
+	PersonID   string `json:"personId"`
+	// RootMessageID 는 thread 의 답글인 경우 thread 의 root message id 입니다.
+	RootMessageID string         `json:"rootMessageId"`
+	PlainText     string         `json:"plainText"`
+	Blocks        []MessageBlock `json:"blocks"`
+}
+
+// IsManagerThreadReply 는 manager 가 팀챗 thread 에 작성한 답글인지 확인합니다.
+// bot 이 작성한 message 는 제외되므로 github 에서 동기화한 message 는 포함되지 않습니다.
+func (m ChatMessage) IsManagerThreadReply() bool {
+	return m.ChatType == ChatTypeGroup &&
+		m.PersonType == PersonTypeManager &&
+		m.RootMessageID != "" &&
+		m.RootMessageID != m.ID
+}
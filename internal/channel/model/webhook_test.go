@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatMessage_IsManagerThreadReply(t *testing.T) {
+	payload := `{
+		"event": "push",
+		"type": "message",
+		"entity": {
+			"id": "m2",
+			"channelId": "1",
+			"chatType": "group",
+			"chatId": "g1",
+			"personType": "manager",
+			"personId": "42",
+			"rootMessageId": "m1",
+			"plainText": "LGTM",
+			"blocks": [{"type": "text", "value": "<b>LGTM</b>"}]
+		}
+	}`
+
+	var event WebhookEvent
+	assert.NoError(t, json.Unmarshal([]byte(payload), &event))
+	assert.Equal(t, WebhookTypeMessage, event.Type)
+
+	var message ChatMessage
+	assert.NoError(t, json.Unmarshal(event.Entity, &message))
+	assert.True(t, message.IsManagerThreadReply())
+	assert.Equal(t, []MessageBlock{NewTextBlock("<b>LGTM</b>")}, message.Blocks)
+
+	bot := message
+	bot.PersonType = "bot"
+	assert.False(t, bot.IsManagerThreadReply())
+
+	root := message
+	root.RootMessageID = ""
+	assert.False(t, root.IsManagerThreadReply())
+
+	userChat := message
+	userChat.ChatType = "userChat"
+	assert.False(t, userChat.IsManagerThreadReply())
+}
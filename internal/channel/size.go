@@ -0,0 +1,251 @@
+package channel
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+)
+
+const (
+	// OverflowSplit 은 크기를 넘는 내용을 첫 message 의 thread 에 이어서 작성합니다.
+	OverflowSplit = "split"
+	// OverflowCollapse 는 크기를 넘는 내용을 생략하고 github link 를 남깁니다.
+	OverflowCollapse = "collapse"
+)
+
+// MessagePolicy 는 channel 에 한 번에 작성할 수 있는 message 의 크기입니다.
+// MaxBytes 는 block 을 JSON 으로 작성한 크기입니다. 0 이하인 값은 제한하지 않습니다.
+type MessagePolicy struct {
+	MaxBytes         int
+	MaxBlocks        int
+	Overflow         string
+	MaxContinuations int
+}
+
+func newMessagePolicy(conf *config.Config) MessagePolicy {
+	return MessagePolicy{
+		MaxBytes:         conf.ChannelTalk.Message.MaxBytes,
+		MaxBlocks:        conf.ChannelTalk.Message.MaxBlocks,
+		Overflow:         conf.ChannelTalk.Message.Overflow,
+		MaxContinuations: conf.ChannelTalk.Message.MaxContinuations,
+	}
+}
+
+// maxMessages 는 첫 message 와 continuation 을 합친 message 의 최대 개수입니다.
+func (p MessagePolicy) maxMessages() int {
+	if p.Overflow != OverflowSplit || p.MaxContinuations <= 0 {
+		return 1
+	}
+	return 1 + p.MaxContinuations
+}
+
+// fit 은 blocks 를 policy 의 크기에 맞는 message 들로 나눕니다.
+// 최대 개수를 넘으면 나머지를 생략하고 마지막 message 에 notice 를 붙입니다. notice 는 생략하는 경우에만 호출합니다.
+func (p MessagePolicy) fit(blocks []model.MessageBlock, notice func() (model.MessageBlock, error)) ([][]model.MessageBlock, error) {
+	chunks := splitBlocks(blocks, p.MaxBytes, p.MaxBlocks)
+	limit := p.maxMessages()
+	if len(chunks) <= limit {
+		return chunks, nil
+	}
+
+	noticeBlock, err := notice()
+	if err != nil {
+		return nil, err
+	}
+	// NOTE : 마지막 message 는 notice 를 붙일 자리를 남기고 다시 나눕니다.
+	maxBytes, maxBlocks := p.MaxBytes, p.MaxBlocks
+	if maxBytes > 0 {
+		maxBytes -= blockSize(noticeBlock)
+	}
+	if maxBlocks > 0 {
+		maxBlocks--
+	}
+	var last []model.MessageBlock
+	if (p.MaxBytes <= 0 || maxBytes > 0) && (p.MaxBlocks <= 0 || maxBlocks > 0) {
+		last = splitBlocks(lo.Flatten(chunks[limit-1:]), maxBytes, maxBlocks)[0]
+	}
+	chunks = append(chunks[:limit-1], append(last, noticeBlock))
+	return chunks, nil
+}
+
+// splitBlocks 는 blocks 를 maxBytes, maxBlocks 를 넘지 않도록 순서대로 나눕니다.
+// maxBytes 를 넘는 block 은 줄 단위로 나눕니다.
+func splitBlocks(blocks []model.MessageBlock, maxBytes, maxBlocks int) [][]model.MessageBlock {
+	// NOTE : "[]" 와 block 사이의 "," 입니다.
+	const arrayOverhead, separator = 2, 1
+
+	var chunks [][]model.MessageBlock
+	var chunk []model.MessageBlock
+	size := arrayOverhead
+	for _, block := range blocks {
+		pieces := []model.MessageBlock{block}
+		if maxBytes > 0 && blockSize(block)+arrayOverhead > maxBytes {
+			pieces = splitBlock(block, maxBytes-arrayOverhead)
+		}
+		for _, piece := range pieces {
+			pieceSize := blockSize(piece) + separator
+			if len(chunk) > 0 && ((maxBytes > 0 && size+pieceSize > maxBytes) || (maxBlocks > 0 && len(chunk) >= maxBlocks)) {
+				chunks = append(chunks, chunk)
+				chunk, size = nil, arrayOverhead
+			}
+			chunk = append(chunk, piece)
+			size += pieceSize
+		}
+	}
+	if len(chunk) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// splitBlock 은 maxBytes 를 넘는 block 을 같은 type 의 block 들로 나눕니다.
+// bullets 는 item 단위로 나누고, maxBytes 를 넘는 item 은 다시 나눠서 각각 하나의 item 으로 작성합니다.
+func splitBlock(block model.MessageBlock, maxBytes int) []model.MessageBlock {
+	switch block.Type {
+	case model.BlockTypeText:
+		overhead := blockSize(model.NewTextBlock(""))
+		return lo.Map(splitLines(block.Text.Value, maxBytes-overhead, true), func(piece string, _ int) model.MessageBlock {
+			return model.NewTextBlock(piece)
+		})
+
+	case model.BlockTypeCode:
+		overhead := blockSize(model.NewCodeBlock("", block.Code.Language))
+		return lo.Map(splitLines(block.Code.Value, maxBytes-overhead, false), func(piece string, _ int) model.MessageBlock {
+			return model.NewCodeBlock(piece, block.Code.Language)
+		})
+
+	case model.BlockTypeBullets:
+		const separator = 1
+		overhead := blockSize(model.NewBulletsBlock([]model.MessageBlock{}))
+		var result, items []model.MessageBlock
+		size := overhead
+		flush := func() {
+			if len(items) > 0 {
+				result = append(result, model.NewBulletsBlock(items))
+			}
+			items, size = nil, overhead
+		}
+		for _, item := range block.Bullets.Blocks {
+			itemSize := blockSize(item) + separator
+			if overhead+itemSize > maxBytes {
+				flush()
+				for _, piece := range splitBlock(item, maxBytes-overhead) {
+					result = append(result, model.NewBulletsBlock([]model.MessageBlock{piece}))
+				}
+				continue
+			}
+			if size+itemSize > maxBytes {
+				flush()
+			}
+			items = append(items, item)
+			size += itemSize
+		}
+		flush()
+		return result
+	}
+	return []model.MessageBlock{block}
+}
+
+// splitLines 는 text 를 JSON string 의 크기가 maxBytes 를 넘지 않도록 줄 단위로 나눕니다. 나눈 곳의 줄바꿈은 작성하지 않습니다.
+// maxBytes 를 넘는 줄은 글자 단위로 나눕니다. markup 이면 ANTLRString 의 tag 와 HTML entity 가 나뉘지 않도록 합니다.
+func splitLines(text string, maxBytes int, markup bool) []string {
+	var pieces []string
+	var piece strings.Builder
+	size, started := 0, false
+	flush := func() {
+		pieces = append(pieces, piece.String())
+		piece.Reset()
+		size, started = 0, false
+	}
+
+	newline := stringSize("\n")
+	for _, line := range strings.Split(text, "\n") {
+		lineSize := stringSize(line)
+		if started && size+newline+lineSize > maxBytes {
+			flush()
+		}
+		if lineSize > maxBytes {
+			if started {
+				flush()
+			}
+			parts := splitLine(line, maxBytes, markup)
+			pieces = append(pieces, parts[:len(parts)-1]...)
+			line, lineSize = parts[len(parts)-1], stringSize(parts[len(parts)-1])
+		}
+
+		if started {
+			piece.WriteString("\n")
+			size += newline
+		}
+		piece.WriteString(line)
+		size += lineSize
+		started = true
+	}
+	if started || len(pieces) == 0 {
+		flush()
+	}
+	return pieces
+}
+
+// splitLine 은 한 줄을 글자 단위로 나눕니다. 한 글자가 maxBytes 를 넘더라도 한 글자씩은 작성합니다.
+func splitLine(line string, maxBytes int, markup bool) []string {
+	var parts []string
+	for line != "" {
+		end, size := 0, 0
+		for end < len(line) {
+			r, width := utf8.DecodeRuneInString(line[end:])
+			runeSize := stringSize(string(r))
+			if end > 0 && size+runeSize > maxBytes {
+				break
+			}
+			end += width
+			size += runeSize
+		}
+		if markup && end < len(line) {
+			end = avoidMarkup(line, end)
+		}
+		parts = append(parts, line[:end])
+		line = line[end:]
+	}
+	return parts
+}
+
+// avoidMarkup 은 end 가 tag(<...>) 나 HTML entity(&...;) 의 중간이면 그 시작으로 옮깁니다. 시작이 처음이면 그대로 둡니다.
+func avoidMarkup(line string, end int) int {
+	prefix := line[:end]
+	if open := strings.LastIndexByte(prefix, '<'); open > 0 && open > strings.LastIndexByte(prefix, '>') {
+		return open
+	}
+	if amp := strings.LastIndexByte(prefix, '&'); amp > 0 && amp > strings.LastIndexByte(prefix, ';') && end-amp <= maxEntityLength {
+		return amp
+	}
+	return end
+}
+
+// maxEntityLength 는 &quot; 와 같은 HTML entity 의 최대 길이입니다.
+const maxEntityLength = 10
+
+func blockSize(block model.MessageBlock) int {
+	b, err := block.MarshalJSON()
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// stringSize 는 s 를 JSON string 으로 작성한 크기에서 따옴표를 뺀 크기입니다. escape 는 글자마다 하므로 더할 수 있습니다.
+func stringSize(s string) int {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return len(s)
+	}
+	return len(bytes.TrimRight(buffer.Bytes(), "\n")) - 2
+}
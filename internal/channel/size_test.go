@@ -0,0 +1,168 @@
+package channel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+func TestSplitBlocks(t *testing.T) {
+	language := "go"
+	tests := []struct {
+		name      string
+		blocks    []model.MessageBlock
+		maxBytes  int
+		maxBlocks int
+		expected  [][]model.MessageBlock
+	}{
+		{
+			name:     "unlimited",
+			blocks:   []model.MessageBlock{model.NewTextBlock(strings.Repeat("a", 100))},
+			expected: [][]model.MessageBlock{{model.NewTextBlock(strings.Repeat("a", 100))}},
+		},
+		{
+			name:     "empty",
+			blocks:   nil,
+			maxBytes: 100,
+			expected: [][]model.MessageBlock{nil},
+		},
+		{
+			name:      "max blocks",
+			blocks:    []model.MessageBlock{model.NewTextBlock("a"), model.NewTextBlock("b"), model.NewTextBlock("c")},
+			maxBlocks: 2,
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("a"), model.NewTextBlock("b")},
+				{model.NewTextBlock("c")},
+			},
+		},
+		{
+			name:     "text by lines",
+			blocks:   []model.MessageBlock{model.NewTextBlock("first line\nsecond line\nthird line")},
+			maxBytes: 52,
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("first line\nsecond line")},
+				{model.NewTextBlock("third line")},
+			},
+		},
+		{
+			name:     "long line does not break tag",
+			blocks:   []model.MessageBlock{model.NewTextBlock("aaaaaaaa <b>bold</b>")},
+			maxBytes: 39,
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("aaaaaaaa ")},
+				{model.NewTextBlock("<b>bold</b>")},
+			},
+		},
+		{
+			name:     "code keeps language",
+			blocks:   []model.MessageBlock{model.NewCodeBlock("line 1\nline 2", &language)},
+			maxBytes: 50,
+			expected: [][]model.MessageBlock{
+				{model.NewCodeBlock("line 1", &language)},
+				{model.NewCodeBlock("line 2", &language)},
+			},
+		},
+		{
+			name: "bullets by items",
+			blocks: []model.MessageBlock{model.NewBulletsBlock([]model.MessageBlock{
+				model.NewTextBlock("item 1"), model.NewTextBlock("item 2"), model.NewTextBlock("item 3"),
+			})},
+			maxBytes: 110,
+			expected: [][]model.MessageBlock{
+				{model.NewBulletsBlock([]model.MessageBlock{model.NewTextBlock("item 1"), model.NewTextBlock("item 2")})},
+				{model.NewBulletsBlock([]model.MessageBlock{model.NewTextBlock("item 3")})},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			actual := splitBlocks(tc.blocks, tc.maxBytes, tc.maxBlocks)
+			assert.Equal(t, tc.expected, actual)
+			if tc.maxBytes <= 0 {
+				return
+			}
+			for _, chunk := range actual {
+				size := 1
+				for _, block := range chunk {
+					size += blockSize(block) + 1
+				}
+				assert.LessOrEqual(t, size, tc.maxBytes)
+			}
+		})
+	}
+}
+
+func TestSplitLines_Size(t *testing.T) {
+	t.Parallel()
+
+	text := strings.Repeat("한글 \"quote\" <b>bold</b> &amp; line\n", 50) + strings.Repeat("긴줄", 200)
+	pieces := splitLines(text, 100, true)
+	for _, piece := range pieces {
+		assert.LessOrEqual(t, stringSize(piece), 100)
+		assert.NotContains(t, []string{"<", "&"}, piece[len(piece)-1:])
+	}
+	assert.Equal(t, strings.ReplaceAll(text, "\n", ""), strings.ReplaceAll(strings.Join(pieces, ""), "\n", ""))
+}
+
+func TestMessagePolicy_Fit(t *testing.T) {
+	blocks := []model.MessageBlock{model.NewTextBlock("a"), model.NewTextBlock("b"), model.NewTextBlock("c")}
+	notice := model.NewTextBlock("notice")
+	tests := []struct {
+		name     string
+		policy   MessagePolicy
+		expected [][]model.MessageBlock
+	}{
+		{
+			name:     "fits",
+			policy:   MessagePolicy{MaxBlocks: 3, Overflow: OverflowSplit},
+			expected: [][]model.MessageBlock{blocks},
+		},
+		{
+			name:   "split",
+			policy: MessagePolicy{MaxBlocks: 2, Overflow: OverflowSplit, MaxContinuations: 1},
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("a"), model.NewTextBlock("b")},
+				{model.NewTextBlock("c")},
+			},
+		},
+		{
+			name:   "split beyond continuations",
+			policy: MessagePolicy{MaxBlocks: 1, Overflow: OverflowSplit, MaxContinuations: 1},
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("a")},
+				{notice},
+			},
+		},
+		{
+			name:     "collapse",
+			policy:   MessagePolicy{MaxBlocks: 2, Overflow: OverflowCollapse},
+			expected: [][]model.MessageBlock{{model.NewTextBlock("a"), notice}},
+		},
+		{
+			name:   "split with notice",
+			policy: MessagePolicy{MaxBlocks: 2, Overflow: OverflowSplit, MaxContinuations: 1},
+			expected: [][]model.MessageBlock{
+				{model.NewTextBlock("a"), model.NewTextBlock("b")},
+				{model.NewTextBlock("c")},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := tc.policy.fit(blocks, func() (model.MessageBlock, error) {
+				return notice, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -7,17 +7,21 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 
 	"github.com/channel-io/cht-app-github/internal/channel/client"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/channel/model/messageconv"
 	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/template"
 	"github.com/channel-io/cht-app-github/pkg/cache"
 )
 
 type Service interface {
 	FindManagerByGitHubMentionUsername(ctx context.Context, channelID string, username string) (*model.Manager, error)
 	BuildMessageBlocksFromMarkdown(ctx context.Context, channelID string, markdown []byte) ([]model.MessageBlock, error)
+	BuildGithubMarkdown(ctx context.Context, channelID string, blocks []model.MessageBlock) (string, error)
 	BuildTeamChatURL(group model.Group, rootMessageID string) string
 	WriteMessage(ctx context.Context, group model.Group, message *model.Message) (messageID string, err error)
 	WriteThreadMessage(
@@ -28,21 +32,38 @@ type Service interface {
 		broadcast bool,
 	) error
 	FetchManagerByManagerID(ctx context.Context, channelID, managerID string) (model.Manager, error)
+	FindTeamByGithubTeam(ctx context.Context, channelID, githubTeam string) (*model.Team, error)
+	FindTeam(ctx context.Context, channelID, idOrName string) (*model.Team, error)
 }
 
 type ServiceImpl struct {
+	logger              logger.Logger
 	client              client.Client
 	githubUserNameCache ManagerCache
 	managerIDCache      cache.Cache[model.Manager]
+	teamsCache          cache.Cache[[]model.Team]
+	templateEngine      *template.Engine
+	messagePolicy       MessagePolicy
+	teams               map[teamKey]config.TeamMapping
 
 	deskURL string
 }
 
-func NewServiceImpl(client client.Client, conf *config.Config) *ServiceImpl {
+type teamKey struct {
+	channelID  string
+	githubTeam string
+}
+
+func NewServiceImpl(logger logger.Logger, client client.Client, conf *config.Config, templateEngine *template.Engine) *ServiceImpl {
 	return &ServiceImpl{
+		logger:              logger,
 		client:              client,
 		githubUserNameCache: cache.NewLocalCache[map[string]model.Manager](),
 		managerIDCache:      cache.NewLocalCache[model.Manager](),
+		teamsCache:          cache.NewLocalCache[[]model.Team](),
+		templateEngine:      templateEngine,
+		messagePolicy:       newMessagePolicy(conf),
+		teams:               newTeams(conf.ChannelTalk.Teams),
 		deskURL:             conf.ChannelTalk.DeskUrl,
 	}
 }
@@ -68,6 +89,16 @@ func (s *ServiceImpl) BuildMessageBlocksFromMarkdown(ctx context.Context, channe
 	return messageconv.FromGithubMarkdown(markdown, managerMap).Convert(), nil
 }
 
+// BuildGithubMarkdown 은 팀챗 message 의 text 를 github markdown 으로 변환합니다.
+func (s *ServiceImpl) BuildGithubMarkdown(ctx context.Context, channelID string, blocks []model.MessageBlock) (string, error) {
+	managerMap, err := s.buildChannelManagersMap(ctx, channelID)
+	if err != nil {
+		return "", err
+	}
+
+	return messageconv.ToGithubMarkdown(blocks, managerMap).Convert(), nil
+}
+
 func (s *ServiceImpl) buildChannelManagersMap(ctx context.Context, channelID string) (map[string]model.Manager, error) {
 	// Note: ListManagers에서 내부적으로 paginated API call 하는 경우가 있어서 timeout을 설정해둠.
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -106,10 +137,30 @@ func (s *ServiceImpl) buildChannelManagersMap(ctx context.Context, channelID str
 	return m, nil
 }
 
+// WriteMessage 는 message 를 작성합니다. 크기를 넘는 내용은 작성한 message 의 thread 에 이어서 작성합니다.
+// NOTE: 이어서 작성하는 message 가 실패해도 root message 는 이미 작성되었으므로, 실패를 기록하고 root message 의 id 를 반환합니다.
+// 호출하는 쪽이 root message 를 thread 로 저장하지 못하면 retry 할 때 같은 message 를 다시 작성하게 됩니다.
 func (s *ServiceImpl) WriteMessage(ctx context.Context, group model.Group, message *model.Message) (string, error) {
-	return s.client.WriteGroupMessage(ctx, group.ChannelID, group.ID, message)
+	messages, err := s.fitMessage(group, message)
+	if err != nil {
+		return "", err
+	}
+
+	messageID, err := s.client.WriteGroupMessage(ctx, group.ChannelID, group.ID, messages[0])
+	if err != nil {
+		return "", err
+	}
+	for _, continuation := range messages[1:] {
+		if err := s.client.WriteThreadMessage(ctx, group.ChannelID, group.ID, messageID, continuation, false); err != nil {
+			s.logger.Errorw("failed to write continuation message", "channelID", group.ChannelID, "messageID", messageID, "error", err)
+			break
+		}
+	}
+	return messageID, nil
 }
 
+// WriteThreadMessage 는 thread 에 message 를 작성합니다. broadcast 는 첫 message 에만 적용합니다.
+// NOTE: 첫 message 를 작성한 뒤에는 이어서 작성하는 message 가 실패해도 retry 하지 않도록 실패를 기록만 합니다.
 func (s *ServiceImpl) WriteThreadMessage(
 	ctx context.Context,
 	group model.Group,
@@ -117,7 +168,42 @@ func (s *ServiceImpl) WriteThreadMessage(
 	message *model.Message,
 	broadcast bool,
 ) error {
-	return s.client.WriteThreadMessage(ctx, group.ChannelID, group.ID, rootMessageID, message, broadcast)
+	messages, err := s.fitMessage(group, message)
+	if err != nil {
+		return err
+	}
+
+	for i, m := range messages {
+		if err := s.client.WriteThreadMessage(ctx, group.ChannelID, group.ID, rootMessageID, m, broadcast && i == 0); err != nil {
+			if i == 0 {
+				return err
+			}
+			s.logger.Errorw("failed to write continuation message", "channelID", group.ChannelID, "rootMessageID", rootMessageID, "error", err)
+			break
+		}
+	}
+	return nil
+}
+
+// fitMessage 는 message 를 messagePolicy 의 크기에 맞게 나눕니다. 생략한 내용이 있으면 message 의 URL 을 link 로 남깁니다.
+func (s *ServiceImpl) fitMessage(group model.Group, message *model.Message) ([]*model.Message, error) {
+	chunks, err := s.messagePolicy.fit(message.Blocks, func() (model.MessageBlock, error) {
+		notice, err := s.templateEngine.RenderForChannel(group.ChannelID, template.MessageTruncated, template.MessageData{URL: message.URL})
+		if err != nil {
+			return model.MessageBlock{}, err
+		}
+		return model.NewTextBlock(notice), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 1 && len(chunks[0]) == len(message.Blocks) {
+		return []*model.Message{message}, nil
+	}
+
+	return lo.Map(chunks, func(blocks []model.MessageBlock, _ int) *model.Message {
+		return model.NewMessage(blocks...).WithURL(message.URL)
+	}), nil
 }
 
 func (s *ServiceImpl) BuildTeamChatURL(group model.Group, rootMessageID string) string {
@@ -140,3 +226,57 @@ func (s *ServiceImpl) FetchManagerByManagerID(ctx context.Context, channelID, ma
 	_ = s.managerIDCache.Set(ctx, managerID, manager, 60*time.Minute)
 	return manager, nil
 }
+
+// FindTeamByGithubTeam 은 서버 설정에서 github team("org/team-slug")과 연결한 channel 의 team 을 찾습니다. 연결하지 않은 team 은 nil 을 반환합니다.
+func (s *ServiceImpl) FindTeamByGithubTeam(ctx context.Context, channelID, githubTeam string) (*model.Team, error) {
+	mapping, ok := s.teams[teamKey{channelID: channelID, githubTeam: strings.ToLower(githubTeam)}]
+	if !ok {
+		return nil, nil
+	}
+	if mapping.TeamID != "" && mapping.Name != "" {
+		return &model.Team{ID: mapping.TeamID, Name: mapping.Name}, nil
+	}
+	return s.FindTeam(ctx, channelID, lo.Ternary(mapping.TeamID != "", mapping.TeamID, mapping.Name))
+}
+
+// FindTeam 은 channel 의 team 중 id 혹은 이름(대소문자 무시)이 같은 team 을 찾습니다. 없으면 nil 을 반환합니다.
+func (s *ServiceImpl) FindTeam(ctx context.Context, channelID, idOrName string) (*model.Team, error) {
+	teams, err := s.listTeams(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if team, ok := lo.Find(teams, func(team model.Team) bool { return team.ID == idOrName }); ok {
+		return &team, nil
+	}
+	if team, ok := lo.Find(teams, func(team model.Team) bool { return strings.EqualFold(team.Name, idOrName) }); ok {
+		return &team, nil
+	}
+	return nil, nil
+}
+
+func (s *ServiceImpl) listTeams(ctx context.Context, channelID string) ([]model.Team, error) {
+	cached, err := s.teamsCache.Get(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return *cached, nil
+	}
+
+	teams, err := s.client.ListTeams(ctx, channelID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search teams")
+	}
+	if err := s.teamsCache.Set(ctx, channelID, teams, 10*time.Minute); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func newTeams(mappings []config.TeamMapping) map[teamKey]config.TeamMapping {
+	teams := make(map[teamKey]config.TeamMapping, len(mappings))
+	for _, mapping := range mappings {
+		teams[teamKey{channelID: mapping.ChannelID, githubTeam: strings.ToLower(mapping.GithubTeam)}] = mapping
+	}
+	return teams
+}
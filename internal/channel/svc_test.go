@@ -5,12 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/channel-io/cht-app-github/internal/channel/client"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
 func TestServiceImpl_buildGithubManagersMap(t *testing.T) {
@@ -111,7 +114,7 @@ func TestServiceImpl_buildGithubManagersMap(t *testing.T) {
 			m.On("ListManagers", mock.Anything, mock.Anything).
 				Return(tc.managers, nil)
 
-			s := NewServiceImpl(m, new(config.Config))
+			s := NewServiceImpl(nil, m, new(config.Config), nil)
 
 			ctx := context.TODO()
 			channelID := "1"
@@ -150,7 +153,7 @@ func TestServiceImpl_buildGithubManagersMap_Cache(t *testing.T) {
 	c := new(mockManagerCache)
 	c.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	s := NewServiceImpl(m, new(config.Config))
+	s := NewServiceImpl(nil, m, new(config.Config), nil)
 
 	ctx := context.TODO()
 	channelID := "1"
@@ -198,7 +201,7 @@ func TestServiceImpl_buildGithubManagersMap_ClientError(t *testing.T) {
 	m.On("ListManagers", mock.Anything, mock.Anything).
 		Return(([]model.Manager)(nil), assert.AnError)
 
-	s := NewServiceImpl(m, new(config.Config))
+	s := NewServiceImpl(nil, m, new(config.Config), nil)
 
 	ctx := context.TODO()
 	channelID := "1"
@@ -251,6 +254,193 @@ func TestServiceImpl_BuildTeamChatURL(t *testing.T) {
 	}
 }
 
+func newMessagePolicyTestService(m *mockClient, overflow string) *ServiceImpl {
+	conf := new(config.Config)
+	conf.ChannelTalk.Message.MaxBytes = 1000
+	conf.ChannelTalk.Message.MaxBlocks = 3
+	conf.ChannelTalk.Message.Overflow = overflow
+	conf.ChannelTalk.Message.MaxContinuations = 1
+	return NewServiceImpl(logger.NewBasicLogger(conf), m, conf, template.NewEngine(conf, nil, nil, nil))
+}
+
+func TestServiceImpl_WriteMessage_Split(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("WriteGroupMessage", mock.Anything, "1", "2", mock.Anything).Return("root", nil)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, false).Return(nil)
+	s := newMessagePolicyTestService(m, OverflowSplit)
+
+	message := model.NewMessage(
+		model.NewTextBlock("title"),
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+	)
+	messageID, err := s.WriteMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, message)
+	assert.NoError(t, err)
+	assert.Equal(t, "root", messageID)
+	m.AssertCalled(t, "WriteGroupMessage", mock.Anything, "1", "2", model.NewMessage(
+		model.NewTextBlock("title"),
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+	))
+	m.AssertCalled(t, "WriteThreadMessage", mock.Anything, "1", "2", "root", model.NewMessage(model.NewTextBlock("c")), false)
+}
+
+// 이어서 작성하는 message 가 실패해도 root message 는 작성되었으므로 root message 의 id 를 반환합니다.
+func TestServiceImpl_WriteMessage_ContinuationError(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("WriteGroupMessage", mock.Anything, "1", "2", mock.Anything).Return("root", nil)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, false).Return(errors.New("timeout"))
+	s := newMessagePolicyTestService(m, OverflowSplit)
+
+	message := model.NewMessage(
+		model.NewTextBlock("title"),
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+	)
+	messageID, err := s.WriteMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, message)
+	assert.NoError(t, err)
+	assert.Equal(t, "root", messageID)
+}
+
+func TestServiceImpl_WriteMessage_Collapse(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("WriteGroupMessage", mock.Anything, "1", "2", mock.Anything).Return("root", nil)
+	s := newMessagePolicyTestService(m, OverflowCollapse)
+
+	url := "https://github.com/channel-io/cht-app-github/releases/tag/v1.0.0"
+	message := model.NewMessage(
+		model.NewTextBlock("title"),
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+	).WithURL(url)
+	_, err := s.WriteMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, message)
+	assert.NoError(t, err)
+	m.AssertNotCalled(t, "WriteThreadMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	written := m.Calls[0].Arguments.Get(3).(*model.Message)
+	assert.Equal(t, []model.MessageBlock{
+		model.NewTextBlock("title"),
+		model.NewTextBlock("a"),
+		model.NewTextBlock(":scissors: The message is too long and was shortened. See more on " + model.InlineLink(url, "GitHub")),
+	}, written.Blocks)
+}
+
+func TestServiceImpl_WriteThreadMessage_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, mock.Anything).Return(nil)
+	s := newMessagePolicyTestService(m, OverflowSplit)
+
+	message := model.NewMessage(
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+		model.NewTextBlock("d"),
+	)
+	err := s.WriteThreadMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, "root", message, true)
+	assert.NoError(t, err)
+	m.AssertCalled(t, "WriteThreadMessage", mock.Anything, "1", "2", "root", model.NewMessage(
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+	), true)
+	m.AssertCalled(t, "WriteThreadMessage", mock.Anything, "1", "2", "root", model.NewMessage(model.NewTextBlock("d")), false)
+}
+
+func TestServiceImpl_WriteThreadMessage_ContinuationError(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, true).Return(nil)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, false).Return(errors.New("timeout"))
+	s := newMessagePolicyTestService(m, OverflowSplit)
+
+	message := model.NewMessage(
+		model.NewTextBlock("a"),
+		model.NewTextBlock("b"),
+		model.NewTextBlock("c"),
+		model.NewTextBlock("d"),
+	)
+	err := s.WriteThreadMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, "root", message, true)
+	assert.NoError(t, err)
+
+	// 첫 message 가 실패하면 error 를 반환합니다.
+	m = new(mockClient)
+	m.On("WriteThreadMessage", mock.Anything, "1", "2", "root", mock.Anything, true).Return(errors.New("timeout"))
+	s = newMessagePolicyTestService(m, OverflowSplit)
+
+	err = s.WriteThreadMessage(context.TODO(), model.Group{ChannelID: "1", ID: "2"}, "root", message, true)
+	assert.Error(t, err)
+	m.AssertNumberOfCalls(t, "WriteThreadMessage", 1)
+}
+
+func TestServiceImpl_FindTeamByGithubTeam(t *testing.T) {
+	t.Parallel()
+
+	conf := new(config.Config)
+	conf.ChannelTalk.Teams = []config.TeamMapping{
+		{GithubTeam: "channel-io/Backend", ChannelID: "1", TeamID: "10", Name: "Backend"},
+		{GithubTeam: "channel-io/backend", ChannelID: "2", TeamID: "20", Name: "Server"},
+	}
+	s := NewServiceImpl(nil, new(mockClient), conf, template.NewEngine(conf, nil, nil, nil))
+
+	team, err := s.FindTeamByGithubTeam(context.TODO(), "1", "channel-io/backend")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Team{ID: "10", Name: "Backend"}, team)
+
+	team, err = s.FindTeamByGithubTeam(context.TODO(), "2", "channel-io/backend")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Team{ID: "20", Name: "Server"}, team)
+
+	team, err = s.FindTeamByGithubTeam(context.TODO(), "1", "channel-io/frontend")
+	assert.NoError(t, err)
+	assert.Nil(t, team)
+}
+
+func TestServiceImpl_FindTeamByGithubTeam_ListTeams(t *testing.T) {
+	t.Parallel()
+
+	m := new(mockClient)
+	m.On("ListTeams", mock.Anything, "1").Return([]model.Team{
+		{ID: "10", Name: "Backend"},
+		{ID: "20", Name: "Frontend"},
+	}, nil)
+	conf := new(config.Config)
+	conf.ChannelTalk.Teams = []config.TeamMapping{
+		{GithubTeam: "channel-io/backend", ChannelID: "1", TeamID: "10"},
+		{GithubTeam: "channel-io/web", ChannelID: "1", Name: "frontend"},
+		{GithubTeam: "channel-io/infra", ChannelID: "1", Name: "Infra"},
+	}
+	s := NewServiceImpl(nil, m, conf, template.NewEngine(conf, nil, nil, nil))
+
+	team, err := s.FindTeamByGithubTeam(context.TODO(), "1", "channel-io/backend")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Team{ID: "10", Name: "Backend"}, team)
+
+	team, err = s.FindTeamByGithubTeam(context.TODO(), "1", "channel-io/web")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Team{ID: "20", Name: "Frontend"}, team)
+
+	team, err = s.FindTeamByGithubTeam(context.TODO(), "1", "channel-io/infra")
+	assert.NoError(t, err)
+	assert.Nil(t, team)
+
+	team, err = s.FindTeam(context.TODO(), "1", "20")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Team{ID: "20", Name: "Frontend"}, team)
+	m.AssertNumberOfCalls(t, "ListTeams", 1)
+}
+
 type mockClient struct {
 	mock.Mock
 	client.Client
@@ -261,6 +451,21 @@ func (m *mockClient) ListManagers(ctx context.Context, channelID string) ([]mode
 	return args.Get(0).([]model.Manager), args.Error(1)
 }
 
+func (m *mockClient) ListTeams(ctx context.Context, channelID string) ([]model.Team, error) {
+	args := m.Called(ctx, channelID)
+	return args.Get(0).([]model.Team), args.Error(1)
+}
+
+func (m *mockClient) WriteGroupMessage(ctx context.Context, channelID, groupID string, message *model.Message) (string, error) {
+	args := m.Called(ctx, channelID, groupID, message)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockClient) WriteThreadMessage(ctx context.Context, channelID, groupID, rootMessageID string, message *model.Message, broadcast bool) error {
+	args := m.Called(ctx, channelID, groupID, rootMessageID, message, broadcast)
+	return args.Error(0)
+}
+
 type mockManagerCache struct {
 	mock.Mock
 }
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type Stage = string
 
 const (
@@ -37,6 +39,42 @@ type Config struct {
 	Log struct {
 		Level string
 	}
+	// Admin 은 운영자만 호출할 수 있는 api 설정입니다. Token 은 Authorization header 의 bearer token 과 비교합니다.
+	Admin struct {
+		Token string
+	}
+	Storage struct {
+		Driver string
+		Bolt   struct {
+			Path string
+		}
+	}
+	Event struct {
+		Queue struct {
+			Concurrency int
+			MaxAttempts int
+			BaseBackoff time.Duration
+			MaxBackoff  time.Duration
+		}
+		Dedup struct {
+			TTL time.Duration
+		}
+		// Debounce 는 짧은 시간에 연달아 오는 event 를 모아 하나의 message 로 보낼 때 사용합니다.
+		Debounce struct {
+			Wait    time.Duration
+			MaxWait time.Duration
+		}
+		CI struct {
+			// SettleTimeout 이 지나면 진행중인 check 가 남아있더라도 commit 의 ci 결과를 보냅니다.
+			SettleTimeout time.Duration
+		}
+	}
+
+	I18n struct {
+		DefaultLocale string
+		// Channels 는 channel id 별 locale 입니다.
+		Channels map[string]string
+	}
 
 	Github struct {
 		App struct {
@@ -49,6 +87,14 @@ type Config struct {
 			ChannelIdKey      string
 			GroupIdKey        string
 			ReleaseGroupIdKey string
+			FilterKey         string
+			// TeamsKey 는 github team slug 별로 멘션할 channel 의 team(id 혹은 이름)을 작성한 custom property 입니다.
+			TeamsKey string
+		}
+		RepoConfig struct {
+			Path    string
+			OrgPath string
+			TTL     time.Duration
 		}
 	}
 
@@ -63,5 +109,59 @@ type Config struct {
 			ID     string
 			Secret string
 		}
+		// Webhook 은 팀챗 message event 를 받는 webhook 설정입니다. Token 은 webhook url 의 token query 와 비교합니다.
+		Webhook struct {
+			Token string
+		}
+		// Message 는 channel 에 작성하는 message 의 크기 제한입니다. 크기를 넘는 message 는 Overflow(split | collapse)에 따라 작성합니다.
+		Message struct {
+			MaxBytes         int
+			MaxBlocks        int
+			Overflow         string
+			MaxContinuations int
+		}
+		// Teams 는 github team 을 멘션할 때 사용할 channel 의 team 입니다.
+		Teams []TeamMapping
+	}
+
+	// Scheduler 는 digest, reminder 처럼 정해진 시각에 실행하는 job 의 설정입니다.
+	// NOTE : 여러 instance 를 실행하면 같은 요약을 여러 번 보내게 되므로, 한 instance 에서만 Enabled 를 켭니다.
+	Scheduler struct {
+		Enabled bool
+	}
+
+	// Digest 는 group 에 정해진 시각마다 보내는 pull request 요약입니다.
+	Digest struct {
+		// StaleAfter 동안 update 되지 않은 pull request 를 오래된 pull request 로 보냅니다.
+		StaleAfter time.Duration
+		Schedules  []DigestSchedule
 	}
+
+	// Reminder 는 review 요청 후 review 가 없는 pull request 를 Interval 마다 확인합니다. 알리는 시간은 repository 의 channeltalk.yml 에서 설정합니다.
+	Reminder struct {
+		Interval time.Duration
+	}
+}
+
+// TeamMapping 은 github team 과 channel 의 team 을 연결합니다. GithubTeam 은 "org/team-slug" 형식입니다.
+// TeamID 와 Name 중 하나만 작성하면 나머지는 channel 의 team 목록에서 찾습니다.
+type TeamMapping struct {
+	GithubTeam string
+	ChannelID  string
+	TeamID     string
+	Name       string
+}
+
+// DigestSchedule 은 하나의 group 에 보내는 요약입니다.
+// Repositories 가 비어있으면 Org 의 repository 중 group 이 연결된 repository 를 요약합니다.
+type DigestSchedule struct {
+	Name         string
+	ChannelID    string
+	GroupID      string
+	Org          string
+	Repositories []string
+	// Time 은 Timezone 의 "09:30" 형식의 시각입니다. Weekdays(mon, tue, ...)가 비어있으면 매일 보냅니다.
+	Time     string
+	Timezone string
+	Weekdays []string
 }
@@ -59,6 +59,27 @@ func fillDefaultValues() {
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.enableConsole", false)
 	viper.SetDefault("log.enableSentry", false)
+	viper.SetDefault("storage.driver", "memory")
+	viper.SetDefault("storage.bolt.path", "data/cht-app-github.db")
+	viper.SetDefault("event.queue.concurrency", 4)
+	viper.SetDefault("event.queue.maxAttempts", 8)
+	viper.SetDefault("event.queue.baseBackoff", "2s")
+	viper.SetDefault("event.queue.maxBackoff", "10m")
+	viper.SetDefault("event.dedup.ttl", "72h")
+	viper.SetDefault("event.debounce.wait", "10s")
+	viper.SetDefault("event.debounce.maxWait", "1m")
+	viper.SetDefault("event.ci.settleTimeout", "30m")
+	viper.SetDefault("channelTalk.message.maxBytes", 10000)
+	viper.SetDefault("channelTalk.message.maxBlocks", 30)
+	viper.SetDefault("channelTalk.message.overflow", "split")
+	viper.SetDefault("channelTalk.message.maxContinuations", 4)
+	viper.SetDefault("scheduler.enabled", true)
+	viper.SetDefault("digest.staleAfter", "72h")
+	viper.SetDefault("reminder.interval", "10m")
+	viper.SetDefault("i18n.defaultLocale", "en")
+	viper.SetDefault("github.repoConfig.path", ".github/channeltalk.yml")
+	viper.SetDefault("github.repoConfig.orgPath", "channeltalk.yml")
+	viper.SetDefault("github.repoConfig.ttl", "10m")
 }
 
 func readStage() (Stage, error) {
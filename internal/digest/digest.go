@@ -0,0 +1,275 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/pkg/errors"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/scheduler"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+// search query 입니다. 요약할 organization 혹은 repository 조건을 붙여서 검색합니다.
+const (
+	waitingQuery = "type:pr state:open draft:false -review:approved"
+	staleQuery   = "type:pr state:open updated:<%s"
+	failingQuery = "type:pr state:open status:failure"
+)
+
+var markdownEscape = strings.NewReplacer(
+	`\`, `\\`,
+	"[", `\[`,
+	"]", `\]`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"<", `\<`,
+)
+
+// Service 는 설정된 group 에 리뷰를 기다리는 pull request, 오래된 pull request, check 가 실패한 pull request 를 요약해서 보냅니다.
+type Service struct {
+	logger         logger.Logger
+	githubSvc      github.Service
+	channelSvc     channel.Service
+	router         *routing.Router
+	templateEngine *template.Engine
+
+	staleAfter time.Duration
+	now        func() time.Time
+}
+
+func NewService(
+	conf *config.Config,
+	logger logger.Logger,
+	githubSvc github.Service,
+	channelSvc channel.Service,
+	router *routing.Router,
+	templateEngine *template.Engine,
+) *Service {
+	return &Service{
+		logger:         logger,
+		githubSvc:      githubSvc,
+		channelSvc:     channelSvc,
+		router:         router,
+		templateEngine: templateEngine,
+		staleAfter:     conf.Digest.StaleAfter,
+		now:            time.Now,
+	}
+}
+
+// Jobs 는 schedule 마다 요약을 보내는 job 입니다. 시각이나 timezone 이 잘못된 schedule 이 있으면 error 를 반환합니다.
+func (s *Service) Jobs(schedules []config.DigestSchedule) ([]scheduler.Job, error) {
+	jobs := make([]scheduler.Job, 0, len(schedules))
+	for _, schedule := range schedules {
+		schedule := schedule
+		clock, err := scheduler.ParseClock(schedule.Time, schedule.Timezone, schedule.Weekdays)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid digest schedule %s", scheduleName(schedule))
+		}
+		jobs = append(jobs, scheduler.Job{
+			Name:     scheduleName(schedule),
+			Schedule: clock,
+			Run: func(ctx context.Context) error {
+				return s.Send(ctx, schedule)
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// Send 는 schedule 의 group 에 요약을 보냅니다. 요약할 pull request 가 없으면 보내지 않습니다.
+func (s *Service) Send(ctx context.Context, schedule config.DigestSchedule) error {
+	installationID, err := s.githubSvc.FindAppInstallationID(ctx, schedule.Org)
+	if err != nil {
+		return err
+	}
+	if installationID == nil {
+		return errors.Errorf("github app is not installed in Org(%s)", schedule.Org)
+	}
+	installCtx := github.NewInstallationContext(*installationID, schedule.Org)
+	group := model.Group{ChannelID: schedule.ChannelID, ID: schedule.GroupID}
+
+	finder := &groupFinder{service: s, installCtx: installCtx, group: group, included: make(map[string]bool)}
+	now := s.now()
+	waiting, err := s.search(ctx, installCtx, schedule, waitingQuery, finder)
+	if err != nil {
+		return err
+	}
+	stale, err := s.search(ctx, installCtx, schedule, fmt.Sprintf(staleQuery, now.Add(-s.staleAfter).UTC().Format("2006-01-02T15:04:05Z")), finder)
+	if err != nil {
+		return err
+	}
+	failing, err := s.search(ctx, installCtx, schedule, failingQuery, finder)
+	if err != nil {
+		return err
+	}
+
+	if len(waiting) == 0 && len(stale) == 0 && len(failing) == 0 {
+		return nil
+	}
+
+	data := template.DigestData{
+		Scope:      scope(schedule),
+		Waiting:    len(waiting),
+		Stale:      len(stale),
+		Failing:    len(failing),
+		StaleAfter: template.FormatAge(s.staleAfter),
+	}
+	root, err := s.templateEngine.RenderForChannel(group.ChannelID, template.DigestRoot, data)
+	if err != nil {
+		return err
+	}
+	blocks := []model.MessageBlock{model.NewTextBlock(root)}
+
+	sections := []struct {
+		name   template.Name
+		issues []*libgithub.Issue
+		since  func(*libgithub.Issue) time.Time
+	}{
+		{name: template.DigestWaiting, issues: waiting, since: createdAt},
+		{name: template.DigestStale, issues: stale, since: updatedAt},
+		{name: template.DigestFailing, issues: failing, since: createdAt},
+	}
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+		heading, err := s.templateEngine.RenderForChannel(group.ChannelID, section.name, data)
+		if err != nil {
+			return err
+		}
+		items, err := s.channelSvc.BuildMessageBlocksFromMarkdown(ctx, group.ChannelID, buildList(section.issues, section.since, now))
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, model.NewTextBlock(heading))
+		blocks = append(blocks, items...)
+	}
+
+	_, err = s.channelSvc.WriteMessage(ctx, group, model.NewMessage(blocks...))
+	return err
+}
+
+// search 는 schedule 의 repository 에서 query 로 pull request 를 검색합니다.
+// repository 를 지정하지 않은 schedule 은 group 으로 routing 되는 pull request 만 남깁니다.
+func (s *Service) search(ctx context.Context, installCtx github.InstallationContext, schedule config.DigestSchedule, query string, finder *groupFinder) ([]*libgithub.Issue, error) {
+	issues, err := s.githubSvc.SearchIssues(ctx, installCtx, buildQuery(query, schedule))
+	if err != nil {
+		return nil, err
+	}
+	if len(schedule.Repositories) > 0 {
+		return issues, nil
+	}
+
+	filtered := make([]*libgithub.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if finder.includes(ctx, issue) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered, nil
+}
+
+// groupFinder 는 pull request 가 group 으로 routing 되는지 한 번의 요약 동안 기억합니다.
+type groupFinder struct {
+	service    *Service
+	installCtx github.InstallationContext
+	group      model.Group
+	included   map[string]bool
+}
+
+// includes 는 pull request 의 opened message 를 group 으로 routing 하는지 확인합니다.
+// NOTE : 검색 결과에는 base branch 가 없으므로 base branch 조건이 있는 route 는 만족하지 않습니다.
+// org 에는 group 이 연결되지 않은 repository 도 있으므로, routing 할 수 없는 pull request 는 요약에서 제외합니다.
+func (f *groupFinder) includes(ctx context.Context, issue *libgithub.Issue) bool {
+	key := issue.GetHTMLURL()
+	if included, ok := f.included[key]; ok {
+		return included
+	}
+
+	repository := repositoryName(issue)
+	destination, err := f.service.router.Route(ctx, f.installCtx, repository, routing.Target{
+		Family: routing.FamilyPullRequest,
+		Action: "opened",
+		Labels: labelNames(issue.Labels),
+		Number: issue.GetNumber(),
+	})
+	if err != nil {
+		f.service.logger.Debugw("failed to route pull request for digest", "repository", repository, "number", issue.GetNumber(), "error", err)
+	}
+	included := err == nil && destination.Group == f.group
+	f.included[key] = included
+	return included
+}
+
+func labelNames(labels []*libgithub.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+func buildQuery(query string, schedule config.DigestSchedule) string {
+	if len(schedule.Repositories) == 0 {
+		return fmt.Sprintf("%s org:%s", query, schedule.Org)
+	}
+	qualifiers := make([]string, 0, len(schedule.Repositories))
+	for _, repository := range schedule.Repositories {
+		qualifiers = append(qualifiers, fmt.Sprintf("repo:%s/%s", schedule.Org, repository))
+	}
+	return fmt.Sprintf("%s %s", query, strings.Join(qualifiers, " "))
+}
+
+// buildList 는 pull request 를 markdown list 로 작성합니다. since 는 경과 시간을 계산할 시각입니다.
+func buildList(issues []*libgithub.Issue, since func(*libgithub.Issue) time.Time, now time.Time) []byte {
+	var md bytes.Buffer
+	for _, issue := range issues {
+		md.WriteString(fmt.Sprintf("* [%s] [%s](%s) · %s\n",
+			repositoryName(issue),
+			markdownEscape.Replace(issue.GetTitle()),
+			issue.GetHTMLURL(),
+			template.FormatAge(now.Sub(since(issue))),
+		))
+	}
+	return md.Bytes()
+}
+
+// repositoryName 은 검색 결과의 repository 이름입니다.
+func repositoryName(issue *libgithub.Issue) string {
+	_, repository := github.SearchResultRepository(issue)
+	return repository
+}
+
+func createdAt(issue *libgithub.Issue) time.Time {
+	return issue.GetCreatedAt().Time
+}
+
+func updatedAt(issue *libgithub.Issue) time.Time {
+	return issue.GetUpdatedAt().Time
+}
+
+func scope(schedule config.DigestSchedule) string {
+	if len(schedule.Repositories) == 0 {
+		return schedule.Org
+	}
+	return fmt.Sprintf("%s/%s", schedule.Org, strings.Join(schedule.Repositories, ", "))
+}
+
+func scheduleName(schedule config.DigestSchedule) string {
+	if schedule.Name != "" {
+		return schedule.Name
+	}
+	return fmt.Sprintf("digest:%s:%s", schedule.ChannelID, schedule.GroupID)
+}
@@ -0,0 +1,189 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+type fakeGithubSvc struct {
+	github.Service
+
+	// issues 는 query 의 첫 조건(type:pr state:open ...) 별 검색 결과입니다.
+	issues map[string][]*libgithub.Issue
+	// files 는 repository 별 channeltalk.yml 입니다.
+	files map[string][]byte
+	// groups 는 repository 별 group 입니다. 없는 repository 는 custom property 가 없는 것으로 봅니다.
+	groups map[string]model.Group
+}
+
+func (s *fakeGithubSvc) FindAppInstallationID(context.Context, string) (*int64, error) {
+	return lo.ToPtr(int64(1)), nil
+}
+
+func (s *fakeGithubSvc) SearchIssues(_ context.Context, _ github.InstallationContext, query string) ([]*libgithub.Issue, error) {
+	for prefix, issues := range s.issues {
+		if strings.HasPrefix(query, prefix+" ") {
+			return issues, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeGithubSvc) FindRepositoryFile(_ context.Context, _ github.InstallationContext, repository, path string) ([]byte, error) {
+	if path != ".github/channeltalk.yml" {
+		return nil, nil
+	}
+	return s.files[repository], nil
+}
+
+func (s *fakeGithubSvc) FindCustomProperties(context.Context, github.InstallationContext, string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (s *fakeGithubSvc) FindGroup(_ context.Context, installCtx github.InstallationContext, repository string) (model.Group, error) {
+	group, ok := s.groups[repository]
+	if !ok {
+		return model.Group{}, errors.Errorf("cht_group_id custom property required in Org(%s) Repository(%s)", installCtx.OrgLogin, repository)
+	}
+	return group, nil
+}
+
+type fakeChannelSvc struct {
+	channel.Service
+
+	messages []*model.Message
+}
+
+func (s *fakeChannelSvc) BuildMessageBlocksFromMarkdown(_ context.Context, _ string, markdown []byte) ([]model.MessageBlock, error) {
+	return []model.MessageBlock{model.NewTextBlock(string(markdown))}, nil
+}
+
+func (s *fakeChannelSvc) WriteMessage(_ context.Context, _ model.Group, message *model.Message) (string, error) {
+	s.messages = append(s.messages, message)
+	return "root", nil
+}
+
+func newTestService(githubSvc *fakeGithubSvc, channelSvc *fakeChannelSvc) *Service {
+	conf := &config.Config{}
+	conf.Github.RepoConfig.Path = ".github/channeltalk.yml"
+	conf.Digest.StaleAfter = 72 * time.Hour
+	return NewService(
+		conf,
+		logger.NewBasicLogger(conf),
+		githubSvc,
+		channelSvc,
+		routing.NewRouter(conf, githubSvc, repoconfig.NewService(conf, githubSvc)),
+		template.NewEngine(conf, nil, nil, nil),
+	)
+}
+
+func testIssue(repository string, number int) *libgithub.Issue {
+	return &libgithub.Issue{
+		Number:        lo.ToPtr(number),
+		Title:         lo.ToPtr("Add feature"),
+		HTMLURL:       lo.ToPtr(fmt.Sprintf("https://github.com/channel-io/%s/pull/%d", repository, number)),
+		RepositoryURL: lo.ToPtr("https://api.github.com/repos/channel-io/" + repository),
+		CreatedAt:     &libgithub.Timestamp{Time: time.Now().Add(-time.Hour)},
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	assert.Equal(t, "type:pr org:channel-io", buildQuery("type:pr", config.DigestSchedule{Org: "channel-io"}))
+	assert.Equal(t, "type:pr repo:channel-io/ch-api repo:channel-io/ch-web", buildQuery("type:pr", config.DigestSchedule{
+		Org:          "channel-io",
+		Repositories: []string{"ch-api", "ch-web"},
+	}))
+}
+
+func TestBuildList(t *testing.T) {
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+	issues := []*libgithub.Issue{
+		{
+			Title:         libgithub.String("Fix [bug] in *parser*"),
+			HTMLURL:       libgithub.String("https://github.com/channel-io/ch-api/pull/1"),
+			RepositoryURL: libgithub.String("https://api.github.com/repos/channel-io/ch-api"),
+			CreatedAt:     &libgithub.Timestamp{Time: now.Add(-3 * 24 * time.Hour)},
+		},
+		{
+			Title:         libgithub.String("Add feature"),
+			HTMLURL:       libgithub.String("https://github.com/channel-io/ch-web/pull/2"),
+			RepositoryURL: libgithub.String("https://api.github.com/repos/channel-io/ch-web"),
+			CreatedAt:     &libgithub.Timestamp{Time: now.Add(-5 * time.Hour)},
+		},
+	}
+
+	expected := "* [ch-api] [Fix \\[bug\\] in \\*parser\\*](https://github.com/channel-io/ch-api/pull/1) · 3d\n" +
+		"* [ch-web] [Add feature](https://github.com/channel-io/ch-web/pull/2) · 5h\n"
+	assert.Equal(t, expected, string(buildList(issues, createdAt, now)))
+}
+
+func TestService_Jobs(t *testing.T) {
+	s := &Service{}
+	jobs, err := s.Jobs([]config.DigestSchedule{
+		{Name: "morning", Org: "channel-io", Time: "09:30", Timezone: "Asia/Seoul", Weekdays: []string{"mon", "fri"}},
+		{ChannelID: "1", GroupID: "2", Org: "channel-io", Time: "18:00"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "morning", jobs[0].Name)
+	assert.Equal(t, "digest:1:2", jobs[1].Name)
+
+	_, err = s.Jobs([]config.DigestSchedule{{Org: "channel-io", Time: "25:00"}})
+	assert.Error(t, err)
+}
+
+// repository 를 지정하지 않으면 channeltalk.yml 의 route 와 custom property 로 group 에 routing 되는 pull request 만 요약합니다.
+func TestService_Send_Routing(t *testing.T) {
+	githubSvc := &fakeGithubSvc{
+		issues: map[string][]*libgithub.Issue{
+			waitingQuery: {testIssue("ch-api", 1), testIssue("ch-web", 2), testIssue("ch-desk", 3), testIssue("sandbox", 4)},
+		},
+		files: map[string][]byte{
+			"ch-web": []byte("routes:\n  - events: [pull_request]\n    channelId: \"1\"\n    groupId: \"2\"\n"),
+		},
+		groups: map[string]model.Group{
+			"ch-api":  {ChannelID: "1", ID: "2"},
+			"ch-desk": {ChannelID: "1", ID: "3"},
+		},
+	}
+	channelSvc := &fakeChannelSvc{}
+	s := newTestService(githubSvc, channelSvc)
+
+	err := s.Send(context.TODO(), config.DigestSchedule{ChannelID: "1", GroupID: "2", Org: "channel-io"})
+	assert.NoError(t, err)
+	assert.Len(t, channelSvc.messages, 1)
+
+	text := lo.Map(channelSvc.messages[0].Blocks, func(block model.MessageBlock, _ int) string { return block.Text.Value })
+	list := text[len(text)-1]
+	assert.Contains(t, list, "ch-api/pull/1")
+	assert.Contains(t, list, "ch-web/pull/2")
+	assert.NotContains(t, list, "ch-desk")
+	assert.NotContains(t, list, "sandbox")
+}
+
+// 요약할 pull request 가 없으면 보내지 않습니다.
+func TestService_Send_Empty(t *testing.T) {
+	channelSvc := &fakeChannelSvc{}
+	s := newTestService(&fakeGithubSvc{}, channelSvc)
+
+	err := s.Send(context.TODO(), config.DigestSchedule{ChannelID: "1", GroupID: "2", Org: "channel-io"})
+	assert.NoError(t, err)
+	assert.Empty(t, channelSvc.messages)
+}
@@ -0,0 +1,190 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+
+	libgithub "github.com/google/go-github/v60/github"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+// deploymentUpdate 는 deployment 혹은 deployment_status event 입니다. deployment_status 가 아니면 Status 는 nil 입니다.
+// batch 에 저장하기 위해 json 으로 encode 합니다.
+type deploymentUpdate struct {
+	Deployment   *libgithub.Deployment       `json:"deployment"`
+	Status       *libgithub.DeploymentStatus `json:"status,omitempty"`
+	Repo         *libgithub.Repository       `json:"repo"`
+	Sender       *libgithub.User             `json:"sender"`
+	Installation *libgithub.Installation     `json:"installation"`
+	Org          *libgithub.Organization     `json:"org"`
+}
+
+func NewDeploymentEventAny(
+	commonSvc *svc.CommonSvc,
+	statusSvc *svc.StatusSvc,
+	batches *queue.Batches,
+) *DeploymentEventAny {
+	cb := &DeploymentEventAny{
+		commonSvc: commonSvc,
+		statusSvc: statusSvc,
+	}
+	cb.batcher = queue.NewBatcher(batches, "deployments", cb.sync)
+	return cb
+}
+
+// DeploymentEventAny 는 deployment 의 시작과 결과를 보냅니다.
+// 같은 deployment 의 event 를 모아 마지막 상태만 하나의 message 로 작성합니다.
+// 진행중인 상태(queued, in_progress 등)는 deployment 가 생성된 직후가 아니면 보내지 않습니다.
+type DeploymentEventAny struct {
+	commonSvc *svc.CommonSvc
+	statusSvc *svc.StatusSvc
+	batcher   *queue.Batcher[deploymentUpdate]
+}
+
+func (cb *DeploymentEventAny) Register(handler *EventHandler) {
+	handler.OnDeploymentEventAny(func(deliveryID string, eventName string, event *libgithub.DeploymentEvent) error {
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin())
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, "created", event.Sender)); err != nil || !notify {
+			return err
+		}
+
+		return cb.batcher.Add(ctx, deploymentKey(event.Installation.GetID(), event.Repo.GetName(), event.Deployment.GetID()), deploymentUpdate{
+			Deployment:   event.Deployment,
+			Repo:         event.Repo,
+			Sender:       event.Sender,
+			Installation: event.Installation,
+			Org:          event.Org,
+		})
+	})
+
+	handler.OnDeploymentStatusEventAny(func(deliveryID string, eventName string, event *libgithub.DeploymentStatusEvent) error {
+		// NOTE : inactive 는 새로운 deployment 로 대체된 경우이므로 보내지 않습니다.
+		if event.DeploymentStatus.GetState() == "inactive" {
+			return nil
+		}
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin())
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.DeploymentStatus.GetState(), event.Sender)); err != nil || !notify {
+			return err
+		}
+
+		return cb.batcher.Add(ctx, deploymentKey(event.Installation.GetID(), event.Repo.GetName(), event.Deployment.GetID()), deploymentUpdate{
+			Deployment:   event.Deployment,
+			Status:       event.DeploymentStatus,
+			Repo:         event.Repo,
+			Sender:       event.Sender,
+			Installation: event.Installation,
+			Org:          event.Org,
+		})
+	})
+}
+
+func deploymentKey(installationID int64, repository string, deploymentID int64) string {
+	return fmt.Sprintf("%d/%s/%d", installationID, repository, deploymentID)
+}
+
+func (cb *DeploymentEventAny) sync(ctx context.Context, _ string, updates []deploymentUpdate) error {
+	var created, latest *deploymentUpdate
+	for i, update := range updates {
+		if update.Status == nil {
+			created = &updates[i]
+			continue
+		}
+		if latest == nil || update.Status.GetID() > latest.Status.GetID() {
+			latest = &updates[i]
+		}
+	}
+
+	update := created
+	name := template.DeploymentCreated
+	if latest != nil && isFinishedDeploymentState(latest.Status.GetState()) {
+		update = latest
+		name = template.DeploymentStatus
+	}
+	if update == nil {
+		return nil
+	}
+
+	installCtx := github.NewInstallationContext(
+		update.Installation.GetID(),
+		update.Org.GetLogin())
+	repository := update.Repo.GetName()
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, repository, routing.FamilyDeployment, update.Sender.GetLogin())
+	if err != nil {
+		return err
+	}
+	message, err := cb.buildMessage(ctx, installCtx, repository, name, template.DeploymentData{
+		Repository: newRepositoryData(update.Repo),
+		Deployment: newDeploymentData(update.Repo, update.Deployment, update.Status),
+		Sender:     sender,
+	})
+	if err != nil {
+		return err
+	}
+
+	action := "created"
+	if update.Status != nil {
+		action = update.Status.GetState()
+	}
+	return cb.statusSvc.SyncWorkflowWithChannelTalk(ctx, installCtx, repository, update.Deployment.GetSHA(), nil, routing.Target{
+		Family: routing.FamilyDeployment,
+		Action: action,
+	}, message)
+}
+
+func isFinishedDeploymentState(state string) bool {
+	switch state {
+	case "success", "failure", "error":
+		return true
+	}
+	return false
+}
+
+func (cb *DeploymentEventAny) buildMessage(ctx context.Context, installCtx github.InstallationContext, repository string, name template.Name, data template.DeploymentData) (*model.Message, error) {
+	title, err := cb.commonSvc.Render(ctx, installCtx, repository, name, data)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	if description := truncateRunes(data.Deployment.Description, commentBodyMaxRunes); description != "" {
+		blocks = append(blocks, model.NewTextBlock(model.EscapedString(description)))
+	}
+	return model.NewMessage(blocks...), nil
+}
+
+func newDeploymentData(repo *libgithub.Repository, deployment *libgithub.Deployment, status *libgithub.DeploymentStatus) template.Deployment {
+	data := template.Deployment{
+		Environment: deployment.GetEnvironment(),
+		Ref:         deployment.GetRef(),
+		SHA:         deployment.GetSHA(),
+		URL:         fmt.Sprintf("%s/deployments", repo.GetHTMLURL()),
+		Description: deployment.GetDescription(),
+	}
+	if status == nil {
+		return data
+	}
+
+	data.State = status.GetState()
+	data.Duration = formatDuration(deployment.GetCreatedAt().Time, status.GetCreatedAt().Time)
+	if description := status.GetDescription(); description != "" {
+		data.Description = description
+	}
+	for _, url := range []string{status.GetEnvironmentURL(), status.GetLogURL(), status.GetTargetURL()} {
+		if url != "" {
+			data.URL = url
+			break
+		}
+	}
+	return data
+}
@@ -0,0 +1,266 @@
+package callback
+
+import (
+	"context"
+
+	libgithub "github.com/google/go-github/v60/github"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+const (
+	discussionEventClosedAction   = "closed"
+	discussionEventReopenedAction = "reopened"
+)
+
+func NewDiscussionEventCreated(commonSvc *svc.CommonSvc, discussionSvc *svc.DiscussionSvc) *DiscussionEventCreated {
+	return &DiscussionEventCreated{
+		commonSvc:     commonSvc,
+		discussionSvc: discussionSvc,
+	}
+}
+
+type DiscussionEventCreated struct {
+	commonSvc     *svc.CommonSvc
+	discussionSvc *svc.DiscussionSvc
+}
+
+func (cb *DiscussionEventCreated) Register(handler *EventHandler) {
+	handler.OnDiscussionEventCreated(func(deliveryID string, eventName string, event *libgithub.DiscussionEvent) error {
+		ctx := context.TODO()
+		installCtx := newGithubContextFromDiscussion(event)
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+		message, err := cb.buildMessage(ctx, installCtx, event)
+		if err != nil {
+			return err
+		}
+		return cb.discussionSvc.SyncDiscussionWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.Discussion.GetNumber(), message, svc.WithRouteTarget(newRouteTargetFromDiscussion(event.GetAction(), event.Discussion)))
+	})
+}
+
+func (cb *DiscussionEventCreated) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.DiscussionEvent) (*model.Message, error) {
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.DiscussionCreated, template.DiscussionData{
+		Repository: newRepositoryData(event.Repo),
+		Discussion: newDiscussionData(event.Discussion),
+		Sender:     mentionText,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	if body := truncateRunes(event.Discussion.GetBody(), commentBodyMaxRunes); body != "" {
+		blocks = append(blocks, model.NewTextBlock(model.EscapedString(body)))
+	}
+	return model.NewMessage(blocks...), nil
+}
+
+func NewDiscussionEventAnswered(commonSvc *svc.CommonSvc, discussionSvc *svc.DiscussionSvc) *DiscussionEventAnswered {
+	return &DiscussionEventAnswered{
+		commonSvc:     commonSvc,
+		discussionSvc: discussionSvc,
+	}
+}
+
+type DiscussionEventAnswered struct {
+	commonSvc     *svc.CommonSvc
+	discussionSvc *svc.DiscussionSvc
+}
+
+func (cb *DiscussionEventAnswered) Register(handler *EventHandler) {
+	handler.OnDiscussionEventAnswered(func(deliveryID string, eventName string, event *libgithub.DiscussionEvent) error {
+		ctx := context.TODO()
+		installCtx := newGithubContextFromDiscussion(event)
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+		message, err := cb.buildMessage(ctx, installCtx, event)
+		if err != nil {
+			return err
+		}
+		return cb.discussionSvc.SyncDiscussionWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.Discussion.GetNumber(), message, svc.WithRouteTarget(newRouteTargetFromDiscussion(event.GetAction(), event.Discussion)))
+	})
+}
+
+func (cb *DiscussionEventAnswered) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.DiscussionEvent) (*model.Message, error) {
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Discussion.User.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.DiscussionAnswered, template.DiscussionData{
+		Repository: newRepositoryData(event.Repo),
+		Discussion: newDiscussionData(event.Discussion),
+		Sender:     sender,
+		Mentions:   mentionText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewMessage(
+		model.NewTextBlock(title),
+	), nil
+}
+
+func NewDiscussionEventClosed(commonSvc *svc.CommonSvc, discussionSvc *svc.DiscussionSvc) *DiscussionEventClosed {
+	return &DiscussionEventClosed{
+		commonSvc:     commonSvc,
+		discussionSvc: discussionSvc,
+	}
+}
+
+// DiscussionEventClosed 는 closed, reopened action 을 처리합니다.
+// githubevents 에 해당 action 이 없어 OnDiscussionEventAny 로 등록합니다.
+type DiscussionEventClosed struct {
+	commonSvc     *svc.CommonSvc
+	discussionSvc *svc.DiscussionSvc
+}
+
+func (cb *DiscussionEventClosed) Register(handler *EventHandler) {
+	handler.OnDiscussionEventAny(func(deliveryID string, eventName string, event *libgithub.DiscussionEvent) error {
+		var name template.Name
+		switch event.GetAction() {
+		case discussionEventClosedAction:
+			name = template.DiscussionClosed
+		case discussionEventReopenedAction:
+			name = template.DiscussionReopened
+		default:
+			return nil
+		}
+
+		ctx := context.TODO()
+		installCtx := newGithubContextFromDiscussion(event)
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+		message, err := cb.buildMessage(ctx, installCtx, name, event)
+		if err != nil {
+			return err
+		}
+		return cb.discussionSvc.SyncDiscussionWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.Discussion.GetNumber(), message, svc.WithBroadCasting(), svc.StopWithoutRootMessage())
+	})
+}
+
+func (cb *DiscussionEventClosed) buildMessage(ctx context.Context, installCtx github.InstallationContext, name template.Name, event *libgithub.DiscussionEvent) (*model.Message, error) {
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), name, template.DiscussionData{
+		Repository: newRepositoryData(event.Repo),
+		Discussion: newDiscussionData(event.Discussion),
+		Sender:     sender,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewMessage(
+		model.NewTextBlock(title),
+	), nil
+}
+
+func NewDiscussionCommentCreated(commonSvc *svc.CommonSvc, discussionSvc *svc.DiscussionSvc) *DiscussionCommentCreated {
+	return &DiscussionCommentCreated{
+		commonSvc:     commonSvc,
+		discussionSvc: discussionSvc,
+	}
+}
+
+type DiscussionCommentCreated struct {
+	commonSvc     *svc.CommonSvc
+	discussionSvc *svc.DiscussionSvc
+}
+
+func (cb *DiscussionCommentCreated) Register(handler *EventHandler) {
+	handler.OnDiscussionCommentEventCreated(func(deliveryID string, eventName string, event *libgithub.DiscussionCommentEvent) error {
+		if isSentFromBot(event.Sender) {
+			return nil
+		}
+
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin(),
+		)
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+		message, err := cb.buildMessage(ctx, installCtx, event)
+		if err != nil {
+			return err
+		}
+		return cb.discussionSvc.SyncDiscussionWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.Discussion.GetNumber(), message, svc.WithRouteTarget(newRouteTargetFromDiscussion(event.GetAction(), event.Discussion)))
+	})
+}
+
+func (cb *DiscussionCommentCreated) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.DiscussionCommentEvent) (*model.Message, error) {
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Discussion.User.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyDiscussion, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.DiscussionComment, template.DiscussionData{
+		Repository: newRepositoryData(event.Repo),
+		Discussion: newDiscussionData(event.Discussion),
+		Comment: template.Comment{
+			URL:  event.Comment.GetHTMLURL(),
+			Body: event.Comment.GetBody(),
+		},
+		Sender:   sender,
+		Mentions: mentionText,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	if body := truncateRunes(event.Comment.GetBody(), commentBodyMaxRunes); body != "" {
+		blocks = append(blocks, model.NewTextBlock(model.EscapedString(body)))
+	}
+	return model.NewMessage(blocks...), nil
+}
+
+func newGithubContextFromDiscussion(event *libgithub.DiscussionEvent) github.InstallationContext {
+	return github.NewInstallationContext(
+		event.Installation.GetID(),
+		event.Org.GetLogin(),
+	)
+}
+
+func newRouteTargetFromDiscussion(action string, discussion *libgithub.Discussion) routing.Target {
+	return routing.Target{
+		Family: routing.FamilyDiscussion,
+		Action: action,
+		Number: discussion.GetNumber(),
+	}
+}
+
+func newDiscussionData(discussion *libgithub.Discussion) template.Discussion {
+	return template.Discussion{
+		Number:    discussion.GetNumber(),
+		Title:     discussion.GetTitle(),
+		URL:       discussion.GetHTMLURL(),
+		Category:  discussion.GetDiscussionCategory().GetName(),
+		AnswerURL: discussion.GetAnswerHTMLURL(),
+	}
+}
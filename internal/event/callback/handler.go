@@ -0,0 +1,92 @@
+package callback
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cbrgm/githubevents/githubevents"
+	libgithub "github.com/google/go-github/v60/github"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	DiscussionCommentEventCreatedAction = "created"
+)
+
+type DiscussionCommentEventHandleFunc func(deliveryID string, eventName string, event *libgithub.DiscussionCommentEvent) error
+
+// EventHandler 는 githubevents.EventHandler 에 githubevents 가 지원하지 않는 event 의 callback 을 더합니다.
+// 추가한 event 도 githubevents 와 같이 action 별 callback 을 병렬로 실행하고, 실패하면 OnError callback 을 실행합니다.
+type EventHandler struct {
+	*githubevents.EventHandler
+
+	mu                       sync.RWMutex
+	onError                  []githubevents.ErrorEventHandleFunc
+	onDiscussionCommentEvent map[string][]DiscussionCommentEventHandleFunc
+}
+
+func NewEventHandler(webhookSecret string) *EventHandler {
+	return &EventHandler{
+		EventHandler:             githubevents.New(webhookSecret),
+		onDiscussionCommentEvent: make(map[string][]DiscussionCommentEventHandleFunc),
+	}
+}
+
+func (h *EventHandler) OnError(callbacks ...githubevents.ErrorEventHandleFunc) {
+	h.mu.Lock()
+	h.onError = append(h.onError, callbacks...)
+	h.mu.Unlock()
+	h.EventHandler.OnError(callbacks...)
+}
+
+// OnDiscussionCommentEventCreated 는 discussion_comment event 의 created action 에 callback 을 등록합니다.
+//
+// Reference: https://docs.github.com/en/webhooks/webhook-events-and-payloads#discussion_comment
+func (h *EventHandler) OnDiscussionCommentEventCreated(callbacks ...DiscussionCommentEventHandleFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onDiscussionCommentEvent[DiscussionCommentEventCreatedAction] = append(
+		h.onDiscussionCommentEvent[DiscussionCommentEventCreatedAction],
+		callbacks...,
+	)
+}
+
+func (h *EventHandler) DiscussionCommentEvent(deliveryID string, eventName string, event *libgithub.DiscussionCommentEvent) error {
+	if event == nil || event.GetAction() == "" {
+		return fmt.Errorf("event action was empty or nil")
+	}
+
+	h.mu.RLock()
+	callbacks := h.onDiscussionCommentEvent[event.GetAction()]
+	h.mu.RUnlock()
+
+	eg := new(errgroup.Group)
+	for _, callback := range callbacks {
+		handle := callback
+		eg.Go(func() error {
+			return handle(deliveryID, eventName, event)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return h.handleError(deliveryID, eventName, event, err)
+	}
+	return nil
+}
+
+func (h *EventHandler) handleError(deliveryID string, eventName string, event interface{}, err error) error {
+	h.mu.RLock()
+	callbacks := h.onError
+	h.mu.RUnlock()
+
+	eg := new(errgroup.Group)
+	for _, callback := range callbacks {
+		handle := callback
+		eg.Go(func() error {
+			return handle(deliveryID, eventName, event, err)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return err
+}
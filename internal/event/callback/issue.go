@@ -3,22 +3,18 @@ package callback
 import (
 	"bytes"
 	"context"
-	"fmt"
 
-	"github.com/cbrgm/githubevents/githubevents"
 	libgithub "github.com/google/go-github/v60/github"
 
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/event/svc"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
 const (
-	issueCommentCreatedTitleFormat = ":speech_balloon: %s %s commented by %s"
-	issueOpenedTitle               = ":rotating_light: %s New issue opened! %s by %s"
-	issueAssignedTitleFormat       = ":pray: %s assigned to %s"
-	issueClosedTitleFormat         = ":x: %s closed by %s"
-	commentBodyMaxRunes            = 100
+	commentBodyMaxRunes = 100
 )
 
 func truncateRunes(s string, max int) string {
@@ -41,8 +37,10 @@ type IssueCommentCreated struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *IssueCommentCreated) Register(handler *githubevents.EventHandler) {
+func (cb *IssueCommentCreated) Register(handler *EventHandler) {
 	handler.OnIssueCommentCreated(func(deliveryID string, eventName string, event *libgithub.IssueCommentEvent) error {
+		// NOTE : 팀챗 답글로 작성한 comment 는 thread 에 이미 있으므로 다시 보내지 않습니다.
+		// 답글은 app(bot) 이 작성하므로 bot 이 작성한 comment 를 모두 보내지 않는 것으로 충분합니다.
 		if isSentFromBot(event.Sender) {
 			return nil
 		}
@@ -53,11 +51,14 @@ func (cb *IssueCommentCreated) Register(handler *githubevents.EventHandler) {
 		)
 		ctx := context.TODO()
 		issueNumber := event.Issue.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromIssue(eventName, event.GetAction(), event.Sender, event.Issue)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromIssue(event.GetAction(), event.Issue)))
 	})
 }
 
@@ -68,30 +69,38 @@ func (cb *IssueCommentCreated) buildMessage(ctx context.Context, installCtx gith
 			if i > 0 {
 				mentionTexts.WriteString(" ")
 			}
-			mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), assignee.GetLogin())
+			mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, assignee.GetLogin())
 			if err != nil {
 				return nil, err
 			}
 			mentionTexts.WriteString(mentionText)
 		}
 	} else {
-		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Issue.User.GetLogin())
+		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, event.Issue.User.GetLogin())
 		if err != nil {
 			return nil, err
 		}
 		mentionTexts.WriteString(mentionText)
 	}
 
-	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	var title string
+	name := template.IssueCommentCreated
 	if event.Issue.IsPullRequest() {
-		title = fmt.Sprintf(pullRequestReviewCommentedTitleFormat, mentionTexts.String(), model.InlineLink(event.Comment.GetHTMLURL(), "pull request"), sender)
-	} else {
-		title = fmt.Sprintf(issueCommentCreatedTitleFormat, mentionTexts.String(), model.InlineLink(event.Comment.GetHTMLURL(), "issue"), sender)
+		name = template.IssueCommentPullRequest
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), name, template.IssueData{
+		Repository: newRepositoryData(event.Repo),
+		Issue:      newIssueData(event.Issue),
+		Comment:    newCommentData(event.Comment),
+		Sender:     sender,
+		Mentions:   mentionTexts.String(),
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	blocks := []model.MessageBlock{model.NewTextBlock(title)}
@@ -113,27 +122,41 @@ type IssuesEventOpened struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *IssuesEventOpened) Register(handler *githubevents.EventHandler) {
+func (cb *IssuesEventOpened) Register(handler *EventHandler) {
 	handler.OnIssuesEventOpened(func(deliveryID string, eventName string, event *libgithub.IssuesEvent) error {
+		// NOTE : 팀챗 message 로 만든 issue 는 githubCreateIssue 가 thread 에 이미 작성했으므로 다시 보내지 않습니다.
+		if svc.IsChatIssue(event.Sender, event.Issue.GetBody()) {
+			return nil
+		}
 		ctx := context.TODO()
 		installCtx := newGithubContextFromIssue(event)
 		issueNumber := event.Issue.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromIssue(eventName, event.GetAction(), event.Sender, event.Issue)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
 
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithoutTryFindingRootMessage())
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithoutTryFindingRootMessage(), svc.WithRouteTarget(newRouteTargetFromIssue(event.GetAction(), event.Issue)))
 	})
 }
 
 func (cb *IssuesEventOpened) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.IssuesEvent) (*model.Message, error) {
-	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(issueOpenedTitle, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), model.InlineLink(event.Issue.GetHTMLURL(), event.Issue.GetTitle()), mentionText)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.IssueOpened, template.IssueData{
+		Repository: newRepositoryData(event.Repo),
+		Issue:      newIssueData(event.Issue),
+		Sender:     mentionText,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
@@ -151,16 +174,19 @@ type IssuesEventAssigned struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *IssuesEventAssigned) Register(handler *githubevents.EventHandler) {
+func (cb *IssuesEventAssigned) Register(handler *EventHandler) {
 	handler.OnIssuesEventAssigned(func(deliveryID string, eventName string, event *libgithub.IssuesEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromIssue(event)
 		issueNumber := event.Issue.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromIssue(eventName, event.GetAction(), event.Sender, event.Issue)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromIssue(event.GetAction(), event.Issue)))
 	})
 }
 
@@ -171,21 +197,28 @@ func (cb *IssuesEventAssigned) buildMessage(ctx context.Context, installCtx gith
 			if i > 0 {
 				mentionTexts.WriteString(" ")
 			}
-			mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), assignee.GetLogin())
+			mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, assignee.GetLogin())
 			if err != nil {
 				return nil, err
 			}
 			mentionTexts.WriteString(mentionText)
 		}
 	} else {
-		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Issue.User.GetLogin())
+		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, event.Issue.User.GetLogin())
 		if err != nil {
 			return nil, err
 		}
 		mentionTexts.WriteString(mentionText)
 	}
 
-	title := fmt.Sprintf(issueAssignedTitleFormat, model.InlineLink(event.Issue.GetHTMLURL(), "issue"), mentionTexts.String())
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.IssueAssigned, template.IssueData{
+		Repository: newRepositoryData(event.Repo),
+		Issue:      newIssueData(event.Issue),
+		Mentions:   mentionTexts.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
@@ -203,26 +236,36 @@ type IssuesEventClosed struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *IssuesEventClosed) Register(handler *githubevents.EventHandler) {
+func (cb *IssuesEventClosed) Register(handler *EventHandler) {
 	handler.OnIssuesEventClosed(func(deliveryID string, eventName string, event *libgithub.IssuesEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromIssue(event)
 		issueNumber := event.Issue.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromIssue(eventName, event.GetAction(), event.Sender, event.Issue)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithBroadCasting())
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithBroadCasting(), svc.WithRouteTarget(newRouteTargetFromIssue(event.GetAction(), event.Issue)))
 	})
 }
 
 func (cb *IssuesEventClosed) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.IssuesEvent) (*model.Message, error) {
-	mentionManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	mentionManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyIssue, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(issueClosedTitleFormat, model.InlineLink(event.Issue.GetHTMLURL(), "issue"), mentionManager)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.IssueClosed, template.IssueData{
+		Repository: newRepositoryData(event.Repo),
+		Issue:      newIssueData(event.Issue),
+		Sender:     mentionManager,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
@@ -0,0 +1,58 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+)
+
+var errNotifyChecked = errors.New("filter must not be checked")
+
+// repositoryGithubSvc 는 repository 설정을 조회하면 실패합니다. 나머지 method 는 호출되면 안 됩니다.
+type repositoryGithubSvc struct {
+	github.Service
+}
+
+func (s *repositoryGithubSvc) FindCustomProperties(context.Context, github.InstallationContext, string) (map[string]string, error) {
+	return nil, errNotifyChecked
+}
+
+func (s *repositoryGithubSvc) FindRepositoryFile(context.Context, github.InstallationContext, string, string) ([]byte, error) {
+	return nil, errNotifyChecked
+}
+
+// 팀챗 답글로 작성한 comment 는 app(bot) 이 작성하므로 다시 thread 로 보내지 않습니다.
+func TestIssueCommentCreated_IgnoresBotComment(t *testing.T) {
+	conf := &config.Config{}
+	githubSvc := &repositoryGithubSvc{}
+	commonSvc := svc.NewCommonSvc(logger.NewBasicLogger(conf), githubSvc, nil, repoconfig.NewService(conf, githubSvc), nil, nil)
+
+	handler := NewEventHandler("")
+	NewIssueCommentCreated(commonSvc, nil).Register(handler)
+
+	event := func(senderType string) *libgithub.IssueCommentEvent {
+		return &libgithub.IssueCommentEvent{
+			Action:       lo.ToPtr("created"),
+			Installation: &libgithub.Installation{ID: lo.ToPtr(int64(1))},
+			Organization: &libgithub.Organization{Login: lo.ToPtr("channel-io")},
+			Repo:         &libgithub.Repository{Name: lo.ToPtr("ch-api")},
+			Issue:        &libgithub.Issue{Number: lo.ToPtr(1)},
+			Comment:      &libgithub.IssueComment{Body: lo.ToPtr("LGTM\n\n_by @dylan via [Channel Talk](https://desk.channel.io)_")},
+			Sender:       &libgithub.User{Login: lo.ToPtr("channel-talk[bot]"), Type: lo.ToPtr(senderType)},
+		}
+	}
+
+	assert.NoError(t, handler.IssueCommentEvent("1", "issue_comment", event("Bot")))
+	// bot 이 아니면 filter 를 확인합니다.
+	assert.ErrorIs(t, handler.IssueCommentEvent("2", "issue_comment", event("User")), errNotifyChecked)
+}
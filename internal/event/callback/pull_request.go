@@ -3,53 +3,49 @@ package callback
 import (
 	"bytes"
 	"context"
-	"fmt"
 
-	"github.com/cbrgm/githubevents/githubevents"
 	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
 
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/event/svc"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
-const (
-	pullRequestBodyFormat                 = "%s (%s → %s)"
-	pullRequestOpenTitleFormat            = ":writing_hand: %s New pull request opened! by %s"
-	pullRequestReadyForReviewTitleFormat  = ":fire: %s %s ready for review!"
-	pullRequestDraftTitleFormat           = ":building_construction: %s Draft pull request created by %s"
-	pullRequestMergedTitleFormat          = ":white_check_mark: %s pull request merged! by %s"
-	pullRequestClosedTitleFormat          = ":boom: %s pull request closed... by %s"
-	pullRequestAssignedTitleFormat        = ":pray: %s assigned to %s"
-	pullRequestSynchronizedTitleFormat    = ":arrows_counterclockwise: %s has been updated by %s"
-	pullRequestReviewCommentedTitleFormat = ":thinking_face::speech_balloon: %s %s commented by %s"
-	pullRequestReviewApprovedTitleFormat  = ":100: %s %s approved! by %s"
-	pullRequestReviewRequestedTitleFormat = ":pray: %s %s review requested by %s"
-	pullRequestReviewRequestRemovedFormat = ":x: %s %s review request removed by %s"
-)
-
-func NewPullRequestEventReadyForReview(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventReadyForReview {
+func NewPullRequestEventReadyForReview(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc, reminderSvc *svc.ReminderSvc) *PullRequestEventReadyForReview {
 	return &PullRequestEventReadyForReview{
-		commonSvc: commonSvc,
-		issueSvc:  issueSvc,
+		commonSvc:   commonSvc,
+		issueSvc:    issueSvc,
+		reminderSvc: reminderSvc,
 	}
 }
 
 type PullRequestEventReadyForReview struct {
-	commonSvc *svc.CommonSvc
-	issueSvc  *svc.IssueSvc
+	commonSvc   *svc.CommonSvc
+	issueSvc    *svc.IssueSvc
+	reminderSvc *svc.ReminderSvc
 }
 
-func (cb *PullRequestEventReadyForReview) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventReadyForReview) Register(handler *EventHandler) {
 	handler.OnPullRequestEventReadyForReview(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
 		issueNumber := event.PullRequest.GetNumber()
+		// NOTE : draft 에서 요청한 review 는 ready for review 부터 reminder 시간을 셉니다.
+		reviewers := lo.Map(event.PullRequest.RequestedReviewers, func(reviewer *libgithub.User, _ int) string { return reviewer.GetLogin() })
+		if err := cb.reminderSvc.Track(ctx, installCtx, event.Repo.GetName(), event.PullRequest, reviewers...); err != nil {
+			return err
+		}
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
@@ -59,18 +55,54 @@ func (cb *PullRequestEventReadyForReview) buildMessage(ctx context.Context, inst
 		if i > 0 {
 			mentionTexts.WriteString(" ")
 		}
-		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), reviewer.GetLogin())
+		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, reviewer.GetLogin())
+		if err != nil {
+			return nil, err
+		}
+		mentionTexts.WriteString(mentionText)
+	}
+	for _, team := range event.PullRequest.RequestedTeams {
+		if mentionTexts.Len() > 0 {
+			mentionTexts.WriteString(" ")
+		}
+		mentionText, err := cb.commonSvc.BuildTeamMentionText(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, team.GetSlug())
 		if err != nil {
 			return nil, err
 		}
 		mentionTexts.WriteString(mentionText)
 	}
-	title := fmt.Sprintf(pullRequestReadyForReviewTitleFormat, mentionTexts.String(), model.InlineLink(event.PullRequest.GetHTMLURL(), "pull request"))
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.PullRequestReady, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Mentions:    mentionTexts.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
 }
 
+func NewPullRequestEventConvertedToDraft(reminderSvc *svc.ReminderSvc) *PullRequestEventConvertedToDraft {
+	return &PullRequestEventConvertedToDraft{
+		reminderSvc: reminderSvc,
+	}
+}
+
+// PullRequestEventConvertedToDraft 는 draft 로 바뀐 pull request 의 reminder 를 멈춥니다.
+// ready for review 가 되면 요청된 reviewer 의 reminder 를 다시 시작합니다.
+type PullRequestEventConvertedToDraft struct {
+	reminderSvc *svc.ReminderSvc
+}
+
+func (cb *PullRequestEventConvertedToDraft) Register(handler *EventHandler) {
+	handler.OnPullRequestEventConvertedToDraft(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
+		installCtx := newGithubContextFromPullRequest(event)
+		return cb.reminderSvc.UntrackPullRequest(context.TODO(), installCtx, event.Repo.GetName(), event.PullRequest.GetNumber())
+	})
+}
+
 func NewPullRequestEventOpened(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventOpened {
 	return &PullRequestEventOpened{
 		commonSvc: commonSvc,
@@ -83,7 +115,7 @@ type PullRequestEventOpened struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *PullRequestEventOpened) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventOpened) Register(handler *EventHandler) {
 	handler.OnPullRequestEventOpened(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
@@ -96,91 +128,141 @@ func (cb *PullRequestEventOpened) Register(handler *githubevents.EventHandler) {
 			}
 		}
 
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
 
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithoutTryFindingRootMessage())
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithoutTryFindingRootMessage(), svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventOpened) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
-	var title string
-	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
+	name := template.PullRequestOpened
 	if event.PullRequest.GetDraft() {
-		title = fmt.Sprintf(pullRequestDraftTitleFormat, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), mentionText)
-	} else {
-		title = fmt.Sprintf(pullRequestOpenTitleFormat, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), mentionText)
+		name = template.PullRequestDraftOpened
+	}
+	data := template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Sender:      mentionText,
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), name, data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.PullRequestBody, data)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := buildPullRequestSummary(ctx, cb.commonSvc, installCtx, event)
+	if err != nil {
+		return nil, err
 	}
 
-	return model.NewMessage(
+	blocks := []model.MessageBlock{
 		model.NewTextBlock(title),
-		model.NewTextBlock(bodyContentForPullRequest(event.PullRequest)),
-	), nil
+		model.NewTextBlock(body),
+	}
+	blocks = append(blocks, summary...)
+	return model.NewMessage(blocks...).WithURL(event.PullRequest.GetHTMLURL()), nil
 }
 
-func NewPullRequestEventClosed(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventClosed {
+func NewPullRequestEventClosed(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc, reminderSvc *svc.ReminderSvc) *PullRequestEventClosed {
 	return &PullRequestEventClosed{
-		commonSvc: commonSvc,
-		issueSvc:  issueSvc,
+		commonSvc:   commonSvc,
+		issueSvc:    issueSvc,
+		reminderSvc: reminderSvc,
 	}
 }
 
 type PullRequestEventClosed struct {
-	commonSvc *svc.CommonSvc
-	issueSvc  *svc.IssueSvc
+	commonSvc   *svc.CommonSvc
+	issueSvc    *svc.IssueSvc
+	reminderSvc *svc.ReminderSvc
 }
 
-func (cb *PullRequestEventClosed) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventClosed) Register(handler *EventHandler) {
 	handler.OnPullRequestEventClosed(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
 		issueNumber := event.PullRequest.GetNumber()
+		if err := cb.reminderSvc.UntrackPullRequest(ctx, installCtx, event.Repo.GetName(), issueNumber); err != nil {
+			return err
+		}
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithBroadCasting())
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithBroadCasting(), svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventClosed) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
-	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
-	var title string
+	name := template.PullRequestClosed
 	if event.PullRequest.GetMerged() {
-		title = fmt.Sprintf(pullRequestMergedTitleFormat, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), mentionText)
-	} else {
-		title = fmt.Sprintf(pullRequestClosedTitleFormat, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), mentionText)
+		name = template.PullRequestMerged
+	}
+	data := template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Sender:      mentionText,
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), name, data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.PullRequestBody, data)
+	if err != nil {
+		return nil, err
 	}
 
 	return model.NewMessage(
-
 		model.NewTextBlock(title),
-		model.NewTextBlock(bodyContentForPullRequest(event.PullRequest)),
-	), nil
+		model.NewTextBlock(body),
+	).WithURL(event.PullRequest.GetHTMLURL()), nil
 }
 
-func NewPullRequestReviewEventSubmitted(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestReviewEventSubmitted {
+func NewPullRequestReviewEventSubmitted(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc, reminderSvc *svc.ReminderSvc) *PullRequestReviewEventSubmitted {
 	return &PullRequestReviewEventSubmitted{
-		commonSvc: commonSvc,
-		issueSvc:  issueSvc,
+		commonSvc:   commonSvc,
+		issueSvc:    issueSvc,
+		reminderSvc: reminderSvc,
 	}
 }
 
 type PullRequestReviewEventSubmitted struct {
-	commonSvc *svc.CommonSvc
-	issueSvc  *svc.IssueSvc
+	commonSvc   *svc.CommonSvc
+	issueSvc    *svc.IssueSvc
+	reminderSvc *svc.ReminderSvc
 }
 
-func (cb *PullRequestReviewEventSubmitted) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestReviewEventSubmitted) Register(handler *EventHandler) {
 	handler.OnPullRequestReviewEventSubmitted(func(deliveryID string, eventName string, event *libgithub.PullRequestReviewEvent) error {
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Organization.GetLogin(),
+		)
+		ctx := context.TODO()
+		// NOTE : 알림을 보내지 않는 review 라도 reviewer 의 reminder 는 멈춥니다.
+		if err := cb.reminderSvc.Untrack(ctx, installCtx, event.Repo.GetName(), event.PullRequest.GetNumber(), event.Review.GetUser().GetLogin()); err != nil {
+			return err
+		}
+
 		if event.PullRequest.GetDraft() {
 			return nil
 		}
@@ -188,50 +270,50 @@ func (cb *PullRequestReviewEventSubmitted) Register(handler *githubevents.EventH
 		if isSentFromBot(event.Sender) {
 			return nil
 		}
-		installCtx := github.NewInstallationContext(
-			event.Installation.GetID(),
-			event.Organization.GetLogin(),
-		)
-		ctx := context.TODO()
+
+		// NOTE : 본문 없이 code comment 만 남긴 review 는 PullRequestReviewCommentEventCreated 에서 comment 와 함께 보냅니다.
+		if event.Review.GetState() == "commented" && event.Review.GetBody() == "" {
+			return nil
+		}
 		issueNumber := event.PullRequest.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestReviewEventSubmitted) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestReviewEvent) (*model.Message, error) {
-	var mentionTexts bytes.Buffer
-	if len(event.PullRequest.Assignees) > 0 {
-		for i, assignee := range event.PullRequest.Assignees {
-			if i > 0 {
-				mentionTexts.WriteString(" ")
-			}
-			mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), assignee.GetLogin())
-			if err != nil {
-				return nil, err
-			}
-			mentionTexts.WriteString(mentionText)
-		}
-	} else {
-		mentionText, err := cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.PullRequest.User.GetLogin())
-		if err != nil {
-			return nil, err
-		}
-		mentionTexts.WriteString(mentionText)
+	mentionText, err := buildPullRequestOwnerMentionText(ctx, cb.commonSvc, installCtx, event.Repo.GetName(), event.PullRequest)
+	if err != nil {
+		return nil, err
 	}
 
-	senderManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	senderManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
-	var title string
+	name := template.PullRequestReviewComment
 	if event.Review.GetState() == "approved" {
-		title = fmt.Sprintf(pullRequestReviewApprovedTitleFormat, mentionTexts.String(), model.InlineLink(event.Review.GetHTMLURL(), "pull request"), senderManager)
-	} else {
-		title = fmt.Sprintf(pullRequestReviewCommentedTitleFormat, mentionTexts.String(), model.InlineLink(event.Review.GetHTMLURL(), "pull request"), senderManager)
+		name = template.PullRequestReviewApprove
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), name, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Review: template.Review{
+			URL:   event.Review.GetHTMLURL(),
+			State: event.Review.GetState(),
+			Body:  event.Review.GetBody(),
+		},
+		Sender:   senderManager,
+		Mentions: mentionText,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	blocks := []model.MessageBlock{model.NewTextBlock(title)}
@@ -241,124 +323,152 @@ func (cb *PullRequestReviewEventSubmitted) buildMessage(ctx context.Context, ins
 	return model.NewMessage(blocks...), nil
 }
 
-func NewPullRequestEventReviewRequested(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventReviewRequested {
+func NewPullRequestEventReviewRequested(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc, reminderSvc *svc.ReminderSvc) *PullRequestEventReviewRequested {
 	return &PullRequestEventReviewRequested{
-		commonSvc: commonSvc,
-		issueSvc:  issueSvc,
+		commonSvc:   commonSvc,
+		issueSvc:    issueSvc,
+		reminderSvc: reminderSvc,
 	}
 }
 
 type PullRequestEventReviewRequested struct {
-	commonSvc *svc.CommonSvc
-	issueSvc  *svc.IssueSvc
+	commonSvc   *svc.CommonSvc
+	issueSvc    *svc.IssueSvc
+	reminderSvc *svc.ReminderSvc
 }
 
-func (cb *PullRequestEventReviewRequested) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventReviewRequested) Register(handler *EventHandler) {
 	handler.OnPullRequestEventReviewRequested(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
-		// CODE OWNER 로 team 이 설정된 경우, event.RequestedTeam 으로 요청이 옴. 이런 경우, 멘션을 할 수 없기에 우선 ignore 해봄.
-		// 추후 팀멘션 기능 추가시 고려 대상임.
-		if event.RequestedReviewer == nil {
+		// CODE OWNER 로 team 이 설정된 경우, event.RequestedReviewer 대신 event.RequestedTeam 으로 요청이 옴.
+		if event.RequestedReviewer == nil && event.RequestedTeam == nil {
 			return nil
 		}
 
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
 
-		if cb.commonSvc.IgnoreBot(ctx, event.Organization.GetLogin(), event.Repo.GetName()) && event.Sender.GetType() == "Bot" {
-			return nil
-		}
-
 		issueNumber := event.PullRequest.GetNumber()
+		// NOTE : team 에 요청한 review 는 reminder 를 보내지 않습니다.
+		if event.RequestedReviewer != nil && !event.PullRequest.GetDraft() {
+			if err := cb.reminderSvc.Track(ctx, installCtx, event.Repo.GetName(), event.PullRequest, event.RequestedReviewer.GetLogin()); err != nil {
+				return err
+			}
+		}
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventReviewRequested) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
-	var reviewer string
-	var err error
-	if event.PullRequest.GetDraft() {
-		reviewer, err = cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.RequestedReviewer.GetLogin())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		reviewer, err = cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.RequestedReviewer.GetLogin())
-		if err != nil {
-			return nil, err
-		}
+	reviewer, err := buildRequestedReviewer(ctx, cb.commonSvc, installCtx, event)
+	if err != nil {
+		return nil, err
 	}
-	requester, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	requester, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(pullRequestReviewRequestedTitleFormat, reviewer, model.InlineLink(event.PullRequest.GetHTMLURL(), "pull request"), requester)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.ReviewRequested, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Sender:      requester,
+		Reviewer:    reviewer,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
 }
 
-func NewPullRequestEventReviewRequestRemoved(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventReviewRequestRemoved {
+func NewPullRequestEventReviewRequestRemoved(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc, reminderSvc *svc.ReminderSvc) *PullRequestEventReviewRequestRemoved {
 	return &PullRequestEventReviewRequestRemoved{
-		commonSvc: commonSvc,
-		issueSvc:  issueSvc,
+		commonSvc:   commonSvc,
+		issueSvc:    issueSvc,
+		reminderSvc: reminderSvc,
 	}
 }
 
 type PullRequestEventReviewRequestRemoved struct {
-	commonSvc *svc.CommonSvc
-	issueSvc  *svc.IssueSvc
+	commonSvc   *svc.CommonSvc
+	issueSvc    *svc.IssueSvc
+	reminderSvc *svc.ReminderSvc
 }
 
-func (cb *PullRequestEventReviewRequestRemoved) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventReviewRequestRemoved) Register(handler *EventHandler) {
 	handler.OnPullRequestEventReviewRequestRemoved(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		// 팀이 리뷰 요청에서 삭제되는 경우, requested_reviewer 는 비어있고 requested_team 에 값이 들어옴.
-		// 추후 팀멘션 기능 추가시 고려 대상임.
-		if event.RequestedReviewer == nil {
+		if event.RequestedReviewer == nil && event.RequestedTeam == nil {
 			return nil
 		}
 
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
 		issueNumber := event.PullRequest.GetNumber()
+		if event.RequestedReviewer != nil {
+			if err := cb.reminderSvc.Untrack(ctx, installCtx, event.Repo.GetName(), issueNumber, event.RequestedReviewer.GetLogin()); err != nil {
+				return err
+			}
+		}
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventReviewRequestRemoved) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
-	var reviewer string
-	var err error
-
-	if event.PullRequest.GetDraft() {
-		reviewer, err = cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.RequestedReviewer.GetLogin())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		reviewer, err = cb.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.RequestedReviewer.GetLogin())
-		if err != nil {
-			return nil, err
-		}
+	reviewer, err := buildRequestedReviewer(ctx, cb.commonSvc, installCtx, event)
+	if err != nil {
+		return nil, err
 	}
-	requester, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	requester, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(pullRequestReviewRequestRemovedFormat, reviewer, model.InlineLink(event.PullRequest.GetHTMLURL(), "pull request"), requester)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.ReviewRequestRemoved, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Sender:      requester,
+		Reviewer:    reviewer,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
 }
 
+// buildRequestedReviewer 는 review 요청이 추가되거나 삭제된 user 혹은 team 입니다. draft 에서는 멘션하지 않고 이름을 작성합니다.
+func buildRequestedReviewer(ctx context.Context, commonSvc *svc.CommonSvc, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (string, error) {
+	repository := event.Repo.GetName()
+	draft := event.PullRequest.GetDraft()
+	switch {
+	case event.RequestedReviewer == nil && draft:
+		return commonSvc.FindTeamNameByGithubTeam(ctx, installCtx, repository, routing.FamilyPullRequest, event.RequestedTeam.GetSlug())
+	case event.RequestedReviewer == nil:
+		return commonSvc.BuildTeamMentionText(ctx, installCtx, repository, routing.FamilyPullRequest, event.RequestedTeam.GetSlug())
+	case draft:
+		return commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, event.RequestedReviewer.GetLogin())
+	default:
+		return commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, event.RequestedReviewer.GetLogin())
+	}
+}
+
 func NewPullRequestEventAssigned(commonSvc *svc.CommonSvc, issueSvc *svc.IssueSvc) *PullRequestEventAssigned {
 	return &PullRequestEventAssigned{
 		commonSvc: commonSvc,
@@ -371,7 +481,7 @@ type PullRequestEventAssigned struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *PullRequestEventAssigned) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventAssigned) Register(handler *EventHandler) {
 	handler.OnPullRequestEventAssigned(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		installCtx := github.NewInstallationContext(
 			event.Installation.GetID(),
@@ -379,22 +489,32 @@ func (cb *PullRequestEventAssigned) Register(handler *githubevents.EventHandler)
 		)
 		ctx := context.TODO()
 		issueNumber := event.PullRequest.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.StopWithoutRootMessage())
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.StopWithoutRootMessage(), svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventAssigned) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
 
-	assignee, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Assignee.GetLogin())
+	assignee, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Assignee.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(pullRequestAssignedTitleFormat, model.InlineLink(event.PullRequest.GetHTMLURL(), "pull request"), assignee)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.PullRequestAssigned, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Assignee:    assignee,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
@@ -412,33 +532,59 @@ type PullRequestEventSynchronize struct {
 	issueSvc  *svc.IssueSvc
 }
 
-func (cb *PullRequestEventSynchronize) Register(handler *githubevents.EventHandler) {
+func (cb *PullRequestEventSynchronize) Register(handler *EventHandler) {
 	handler.OnPullRequestEventSynchronize(func(deliveryID string, eventName string, event *libgithub.PullRequestEvent) error {
 		ctx := context.TODO()
 		installCtx := newGithubContextFromPullRequest(event)
 		issueNumber := event.PullRequest.GetNumber()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message)
+		return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), issueNumber, message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
 	})
 }
 
 func (cb *PullRequestEventSynchronize) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) (*model.Message, error) {
-	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyPullRequest, event.Sender.GetLogin())
 	if err != nil {
 		return nil, err
 	}
 
-	title := fmt.Sprintf(pullRequestSynchronizedTitleFormat, model.InlineLink(event.PullRequest.GetHTMLURL(), "pull request"), sender)
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.PullRequestSynchronized, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Sender:      sender,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return model.NewMessage(
 		model.NewTextBlock(title),
 	), nil
 }
 
-func bodyContentForPullRequest(pr *libgithub.PullRequest) string {
-	return fmt.Sprintf(pullRequestBodyFormat, model.InlineLink(pr.GetHTMLURL(), pr.GetTitle()), pr.Head.GetLabel(), pr.Base.GetLabel())
+// buildPullRequestOwnerMentionText 는 pull request 의 assignee 들을 멘션합니다. assignee 가 없으면 작성자를 멘션합니다.
+func buildPullRequestOwnerMentionText(ctx context.Context, commonSvc *svc.CommonSvc, installCtx github.InstallationContext, repository string, pullRequest *libgithub.PullRequest) (string, error) {
+	if len(pullRequest.Assignees) == 0 {
+		return commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, pullRequest.User.GetLogin())
+	}
+
+	var mentionTexts bytes.Buffer
+	for i, assignee := range pullRequest.Assignees {
+		if i > 0 {
+			mentionTexts.WriteString(" ")
+		}
+		mentionText, err := commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, assignee.GetLogin())
+		if err != nil {
+			return "", err
+		}
+		mentionTexts.WriteString(mentionText)
+	}
+	return mentionTexts.String(), nil
 }
 
 func newGithubContextFromPullRequest(pr *libgithub.PullRequestEvent) github.InstallationContext {
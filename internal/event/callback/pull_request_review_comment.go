@@ -0,0 +1,171 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	libgithub "github.com/google/go-github/v60/github"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+const (
+	// diffHunkMinLines 는 comment 가 달린 줄 위로 함께 보여줄 diff 의 최소 줄 수입니다.
+	diffHunkMinLines = 4
+	diffHunkMaxLines = 20
+)
+
+var diffLanguage = "diff"
+
+func NewPullRequestReviewCommentEventCreated(
+	commonSvc *svc.CommonSvc,
+	issueSvc *svc.IssueSvc,
+	batches *queue.Batches,
+) *PullRequestReviewCommentEventCreated {
+	cb := &PullRequestReviewCommentEventCreated{
+		commonSvc: commonSvc,
+		issueSvc:  issueSvc,
+	}
+	cb.batcher = queue.NewBatcher(batches, "review_comments", cb.flush)
+	return cb
+}
+
+// PullRequestReviewCommentEventCreated 는 code line 에 작성된 comment 를 pull request 의 thread 로 보냅니다.
+// 하나의 review 를 제출하면 comment 마다 event 가 오기 때문에, 같은 review 의 comment 를 모아 하나의 message 로 작성합니다.
+// 모으는 중인 comment 는 queue 의 batch 로 저장하므로 재시작해도 유실되지 않고, 보내지 못한 경우 job 과 함께 재시도합니다.
+type PullRequestReviewCommentEventCreated struct {
+	commonSvc *svc.CommonSvc
+	issueSvc  *svc.IssueSvc
+	batcher   *queue.Batcher[*libgithub.PullRequestReviewCommentEvent]
+}
+
+func (cb *PullRequestReviewCommentEventCreated) Register(handler *EventHandler) {
+	handler.OnPullRequestReviewCommentEventCreated(func(deliveryID string, eventName string, event *libgithub.PullRequestReviewCommentEvent) error {
+		if event.PullRequest.GetDraft() {
+			return nil
+		}
+
+		if isSentFromBot(event.Sender) {
+			return nil
+		}
+		ctx := context.TODO()
+		installCtx := newGithubContextFromPullRequestReviewComment(event)
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubjectFromPullRequest(eventName, event.GetAction(), event.Sender, event.PullRequest)); err != nil || !notify {
+			return err
+		}
+
+		key := fmt.Sprintf("%d/%s/%d", event.Installation.GetID(), event.Repo.GetName(), event.Comment.GetPullRequestReviewID())
+		return cb.batcher.Add(ctx, key, event)
+	})
+}
+
+func (cb *PullRequestReviewCommentEventCreated) flush(ctx context.Context, _ string, events []*libgithub.PullRequestReviewCommentEvent) error {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Comment.GetID() < events[j].Comment.GetID()
+	})
+
+	event := events[0]
+	installCtx := newGithubContextFromPullRequestReviewComment(event)
+	message, err := cb.buildMessage(ctx, installCtx, events)
+	if err != nil {
+		return err
+	}
+	return cb.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.PullRequest.GetNumber(), message, svc.WithRouteTarget(newRouteTargetFromPullRequest(event.GetAction(), event.PullRequest)))
+}
+
+func (cb *PullRequestReviewCommentEventCreated) buildMessage(ctx context.Context, installCtx github.InstallationContext, events []*libgithub.PullRequestReviewCommentEvent) (*model.Message, error) {
+	event := events[0]
+	repository := event.Repo.GetName()
+	mentionText, err := buildPullRequestOwnerMentionText(ctx, cb.commonSvc, installCtx, repository, event.PullRequest)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]template.ReviewComment, 0, len(events))
+	for _, e := range events {
+		comments = append(comments, newReviewCommentData(e.Comment))
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, repository, template.ReviewCodeComments, template.PullRequestData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(event.PullRequest),
+		Review: template.Review{
+			URL:   fmt.Sprintf("%s#pullrequestreview-%d", event.PullRequest.GetHTMLURL(), event.Comment.GetPullRequestReviewID()),
+			State: "commented",
+		},
+		ReviewComments: comments,
+		Sender:         sender,
+		Mentions:       mentionText,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	for i, e := range events {
+		location, err := cb.commonSvc.Render(ctx, installCtx, repository, template.ReviewCodeLocation, comments[i])
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, model.NewTextBlock(location))
+		if hunk := trimDiffHunk(e.Comment.GetDiffHunk(), comments[i].Line-comments[i].StartLine+1); hunk != "" {
+			blocks = append(blocks, model.NewCodeBlock(hunk, &diffLanguage))
+		}
+		if body := truncateRunes(comments[i].Body, commentBodyMaxRunes); body != "" {
+			blocks = append(blocks, model.NewTextBlock(model.EscapedString(body)))
+		}
+	}
+	return model.NewMessage(blocks...), nil
+}
+
+func newReviewCommentData(comment *libgithub.PullRequestComment) template.ReviewComment {
+	// NOTE : 이후 commit 으로 code 가 바뀌어 comment 가 outdated 된 경우 line 이 비어있습니다.
+	line, startLine := comment.GetLine(), comment.GetStartLine()
+	outdated := comment.Line == nil
+	if outdated {
+		line, startLine = comment.GetOriginalLine(), comment.GetOriginalStartLine()
+	}
+	if startLine == 0 {
+		startLine = line
+	}
+	return template.ReviewComment{
+		URL:       comment.GetHTMLURL(),
+		Path:      comment.GetPath(),
+		StartLine: startLine,
+		Line:      line,
+		Outdated:  outdated,
+		Body:      comment.GetBody(),
+	}
+}
+
+// trimDiffHunk 는 diff hunk 에서 comment 가 달린 줄과 그 위의 몇 줄만 남깁니다.
+// github 는 hunk header 부터 comment 가 달린 줄까지를 diff_hunk 로 보내기 때문에 마지막 줄이 comment 가 달린 줄입니다.
+func trimDiffHunk(hunk string, lineCount int) string {
+	lines := strings.Split(strings.TrimRight(hunk, "\n"), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "@@") {
+		lines = lines[1:]
+	}
+	keep := max(lineCount, diffHunkMinLines)
+	keep = min(keep, diffHunkMaxLines)
+	if len(lines) > keep {
+		lines = lines[len(lines)-keep:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newGithubContextFromPullRequestReviewComment(event *libgithub.PullRequestReviewCommentEvent) github.InstallationContext {
+	return github.NewInstallationContext(
+		event.Installation.GetID(),
+		event.Org.GetLogin(),
+	)
+}
@@ -0,0 +1,46 @@
+package callback
+
+import (
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimDiffHunk(t *testing.T) {
+	hunk := "@@ -1,8 +1,9 @@\n package a\n \n import \"fmt\"\n \n-func A() {\n+func A() error {\n \tfmt.Println(1)\n+\treturn nil\n }\n"
+
+	// 한 줄에 작성된 comment 는 위의 몇 줄을 함께 보여줍니다.
+	assert.Equal(t, "+func A() error {\n \tfmt.Println(1)\n+\treturn nil\n }", trimDiffHunk(hunk, 1))
+	// 여러 줄에 작성된 comment 는 선택한 줄을 모두 보여줍니다.
+	assert.Equal(t, "-func A() {\n+func A() error {\n \tfmt.Println(1)\n+\treturn nil\n }", trimDiffHunk(hunk, 5))
+	// hunk header 는 보여주지 않습니다.
+	assert.Equal(t, " package a\n \n import \"fmt\"\n \n-func A() {\n+func A() error {\n \tfmt.Println(1)\n+\treturn nil\n }", trimDiffHunk(hunk, 100))
+	assert.Equal(t, "", trimDiffHunk("", 1))
+}
+
+func TestNewReviewCommentData(t *testing.T) {
+	single := newReviewCommentData(&libgithub.PullRequestComment{
+		Path: libgithub.String("a.go"),
+		Line: libgithub.Int(10),
+	})
+	assert.Equal(t, 10, single.StartLine)
+	assert.Equal(t, 10, single.Line)
+	assert.False(t, single.Outdated)
+
+	multi := newReviewCommentData(&libgithub.PullRequestComment{
+		Path:      libgithub.String("a.go"),
+		StartLine: libgithub.Int(8),
+		Line:      libgithub.Int(10),
+	})
+	assert.Equal(t, 8, multi.StartLine)
+	assert.Equal(t, 10, multi.Line)
+
+	outdated := newReviewCommentData(&libgithub.PullRequestComment{
+		Path:         libgithub.String("a.go"),
+		OriginalLine: libgithub.Int(3),
+	})
+	assert.Equal(t, 3, outdated.StartLine)
+	assert.Equal(t, 3, outdated.Line)
+	assert.True(t, outdated.Outdated)
+}
@@ -0,0 +1,175 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+var (
+	// closingIssueRegex 는 pull request 본문에서 issue 를 닫는 keyword 입니다. (ex. closes #12, fixes org/repo#3, resolves https://github.com/org/repo/issues/4)
+	closingIssueRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(?:([\w.-]+/[\w.-]+)#(\d+)|#(\d+)|https://github\.com/([\w.-]+/[\w.-]+)/issues/(\d+))\b`)
+	// htmlCommentRegex 는 pull request template 의 안내 문구 등 본문에 보이지 않는 주석입니다.
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// buildPullRequestSummary 는 repository 에 설정된 항목으로 pull request 요약을 작성합니다.
+func buildPullRequestSummary(ctx context.Context, commonSvc *svc.CommonSvc, installCtx github.InstallationContext, event *libgithub.PullRequestEvent) ([]model.MessageBlock, error) {
+	repository := event.Repo.GetName()
+	conf, err := commonSvc.FindPullRequestConfig(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequest := event.PullRequest
+	data := template.PullRequestSummaryData{
+		Repository:  newRepositoryData(event.Repo),
+		PullRequest: newPullRequestData(pullRequest),
+	}
+	if conf.HasSummary(repoconfig.SummaryStats) {
+		data.Stats = &template.PullRequestStats{
+			Additions:    pullRequest.GetAdditions(),
+			Deletions:    pullRequest.GetDeletions(),
+			ChangedFiles: pullRequest.GetChangedFiles(),
+		}
+	}
+	if conf.HasSummary(repoconfig.SummaryLabels) {
+		data.Labels = lo.Map(pullRequest.Labels, func(label *libgithub.Label, _ int) string {
+			return label.GetName()
+		})
+	}
+	if conf.HasSummary(repoconfig.SummaryMilestone) && pullRequest.Milestone != nil {
+		data.Milestone = &template.Milestone{
+			Title: pullRequest.Milestone.GetTitle(),
+			URL:   pullRequest.Milestone.GetHTMLURL(),
+		}
+	}
+	if conf.HasSummary(repoconfig.SummaryReviewers) {
+		reviewers, err := buildReviewerMentions(ctx, commonSvc, installCtx, repository, pullRequest)
+		if err != nil {
+			return nil, err
+		}
+		data.Reviewers = reviewers
+	}
+	if conf.HasSummary(repoconfig.SummaryIssues) {
+		data.Issues = parseClosingIssues(pullRequest.GetBody(), event.Repo.GetFullName(), event.Repo.GetHTMLURL())
+	}
+
+	summary, err := commonSvc.Render(ctx, installCtx, repository, template.PullRequestSummary, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []model.MessageBlock
+	if summary = strings.TrimSpace(summary); summary != "" {
+		blocks = append(blocks, model.NewTextBlock(summary))
+	}
+	if conf.HasSummary(repoconfig.SummaryBody) {
+		body := bodyExcerpt(pullRequest.GetBody(), conf.MaxBodyLines(), pullRequest.GetHTMLURL())
+		if body != "" {
+			bodyBlocks, err := commonSvc.BuildMessageBlocksFromMarkdown(ctx, installCtx, repository, routing.FamilyPullRequest, body)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, bodyBlocks...)
+		}
+	}
+	return blocks, nil
+}
+
+// buildReviewerMentions 는 review 를 요청한 사용자를 manager 멘션으로, team 을 @org/team 으로 작성합니다.
+func buildReviewerMentions(ctx context.Context, commonSvc *svc.CommonSvc, installCtx github.InstallationContext, repository string, pullRequest *libgithub.PullRequest) (string, error) {
+	mentions := make([]string, 0, len(pullRequest.RequestedReviewers)+len(pullRequest.RequestedTeams))
+	for _, reviewer := range pullRequest.RequestedReviewers {
+		mention, err := commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, repository, routing.FamilyPullRequest, reviewer.GetLogin())
+		if err != nil {
+			return "", err
+		}
+		mentions = append(mentions, mention)
+	}
+	for _, team := range pullRequest.RequestedTeams {
+		mention, err := commonSvc.BuildTeamMentionText(ctx, installCtx, repository, routing.FamilyPullRequest, team.GetSlug())
+		if err != nil {
+			return "", err
+		}
+		mentions = append(mentions, mention)
+	}
+	return strings.Join(mentions, " "), nil
+}
+
+// parseClosingIssues 는 pull request 본문에서 merge 되면 닫히는 issue 를 찾습니다.
+// 같은 repository 의 issue 는 #123, 다른 repository 의 issue 는 org/repo#123 으로 작성합니다.
+func parseClosingIssues(body, fullName, repositoryURL string) []template.Issue {
+	baseURL := strings.TrimSuffix(repositoryURL, "/"+fullName)
+	var issues []template.Issue
+	for _, match := range closingIssueRegex.FindAllStringSubmatch(body, -1) {
+		repo, number := fullName, match[3]
+		switch {
+		case match[2] != "":
+			repo, number = match[1], match[2]
+		case match[5] != "":
+			repo, number = match[4], match[5]
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+
+		title := fmt.Sprintf("#%d", n)
+		if !strings.EqualFold(repo, fullName) {
+			title = repo + title
+		}
+		if lo.ContainsBy(issues, func(issue template.Issue) bool { return issue.Title == title }) {
+			continue
+		}
+		issues = append(issues, template.Issue{
+			Number: n,
+			Title:  title,
+			URL:    fmt.Sprintf("%s/%s/issues/%d", baseURL, repo, n),
+		})
+	}
+	return issues
+}
+
+// bodyExcerpt 는 주석을 제외한 pull request 본문의 처음 maxLines 줄입니다.
+// 줄을 생략하면 열려있는 code block 을 닫고 pull request link 를 붙입니다.
+func bodyExcerpt(body string, maxLines int, url string) string {
+	body = htmlCommentRegex.ReplaceAllString(strings.ReplaceAll(body, "\r\n", "\n"), "")
+	body = strings.TrimSpace(body)
+	if body == "" || maxLines <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(body, "\n")
+	if len(lines) <= maxLines {
+		return body
+	}
+	lines = lines[:maxLines]
+
+	var fence string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		}
+	}
+	if fence != "" {
+		lines = append(lines, fence)
+	}
+	return strings.Join(lines, "\n") + fmt.Sprintf("\n\n[…](%s)", url)
+}
@@ -0,0 +1,30 @@
+package callback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+func TestParseClosingIssues(t *testing.T) {
+	body := "Closes #12\nfixes: channel-io/other#3, resolves https://github.com/channel-io/cht-app-github/issues/12\nfixed #4 and refs #5\nclose channel-io/cht-app-github#7"
+	issues := parseClosingIssues(body, "channel-io/cht-app-github", "https://github.com/channel-io/cht-app-github")
+	assert.Equal(t, []template.Issue{
+		{Number: 12, Title: "#12", URL: "https://github.com/channel-io/cht-app-github/issues/12"},
+		{Number: 3, Title: "channel-io/other#3", URL: "https://github.com/channel-io/other/issues/3"},
+		{Number: 4, Title: "#4", URL: "https://github.com/channel-io/cht-app-github/issues/4"},
+		{Number: 7, Title: "#7", URL: "https://github.com/channel-io/cht-app-github/issues/7"},
+	}, issues)
+	assert.Empty(t, parseClosingIssues("no issue #1", "channel-io/cht-app-github", "https://github.com/channel-io/cht-app-github"))
+}
+
+func TestBodyExcerpt(t *testing.T) {
+	url := "https://github.com/channel-io/cht-app-github/pull/1"
+	assert.Equal(t, "", bodyExcerpt("<!-- describe your change -->\r\n", 10, url))
+	assert.Equal(t, "line 1\nline 2", bodyExcerpt("<!-- template -->\r\nline 1\r\nline 2", 2, url))
+	assert.Equal(t, "line 1\n\n[…]("+url+")", bodyExcerpt("line 1\nline 2", 1, url))
+	assert.Equal(t, "```go\nfunc A() {\n```\n\n[…]("+url+")", bodyExcerpt("```go\nfunc A() {\n}\n```", 2, url))
+	assert.Equal(t, "", bodyExcerpt("line", 0, url))
+}
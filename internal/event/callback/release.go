@@ -2,18 +2,14 @@ package callback
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/cbrgm/githubevents/githubevents"
 	libgithub "github.com/google/go-github/v60/github"
 
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/event/svc"
 	"github.com/channel-io/cht-app-github/internal/github"
-)
-
-const (
-	releasedTitleFormat = ":package: %s: %s released by %s"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
 func NewReleaseEventReleased(commonSvc *svc.CommonSvc, releaseSvc *svc.ReleaseSvc) *ReleaseEventReleased {
@@ -28,28 +24,44 @@ type ReleaseEventReleased struct {
 	releaseSvc *svc.ReleaseSvc
 }
 
-func (cb *ReleaseEventReleased) Register(handler *githubevents.EventHandler) {
+func (cb *ReleaseEventReleased) Register(handler *EventHandler) {
 	handler.OnReleaseEventReleased(func(deliveryID string, eventName string, event *libgithub.ReleaseEvent) error {
 		installCtx := github.NewInstallationContext(
 			event.Installation.GetID(),
 			event.Org.GetLogin())
 
 		ctx := context.TODO()
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
 		message, err := cb.buildMessage(ctx, installCtx, event)
 		if err != nil {
 			return err
 		}
-		return cb.releaseSvc.SyncReleaseWithChannelTalk(ctx, installCtx, event.Repo.GetName(), message)
+		return cb.releaseSvc.SyncReleaseWithChannelTalk(ctx, installCtx, event.Repo.GetName(), routing.Target{
+			Family: routing.FamilyRelease,
+			Action: event.GetAction(),
+		}, message)
 	})
 }
 
 func (cb *ReleaseEventReleased) buildMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.ReleaseEvent) (*model.Message, error) {
 
-	mentionManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), event.Sender.GetLogin())
+	mentionManager, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, event.Repo.GetName(), routing.FamilyRelease, event.Sender.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+	title, err := cb.commonSvc.Render(ctx, installCtx, event.Repo.GetName(), template.ReleaseReleased, template.ReleaseData{
+		Repository: newRepositoryData(event.Repo),
+		Release: template.Release{
+			TagName: event.Release.GetTagName(),
+			URL:     event.Release.GetHTMLURL(),
+		},
+		Sender: mentionManager,
+	})
 	if err != nil {
 		return nil, err
 	}
-	title := fmt.Sprintf(releasedTitleFormat, model.InlineLink(event.Repo.GetHTMLURL(), event.Repo.GetName()), model.InlineLink(event.Release.GetHTMLURL(), event.Release.GetTagName()), mentionManager)
 	blocksFromBody, err := cb.releaseSvc.BuildMessageBlocksFromBody(ctx, installCtx, event.Repo.GetName(), event.Release.GetBody())
 	if err != nil {
 		return nil, err
@@ -59,5 +71,5 @@ func (cb *ReleaseEventReleased) buildMessage(ctx context.Context, installCtx git
 		model.NewTextBlock(title),
 	}
 	blocks = append(blocks, blocksFromBody...)
-	return model.NewMessage(blocks...), nil
+	return model.NewMessage(blocks...).WithURL(event.Release.GetHTMLURL()), nil
 }
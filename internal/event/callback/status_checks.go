@@ -2,19 +2,14 @@ package callback
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/cbrgm/githubevents/githubevents"
 	libgithub "github.com/google/go-github/v60/github"
 
-	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/event/svc"
 	"github.com/channel-io/cht-app-github/internal/github"
 )
 
-const (
-	statusResultFormat = ":arrows_counterclockwise: %s pipeline with %s has been %s"
-)
+const githubActionsAppSlug = "github-actions"
 
 func NewStatusEventAny(commonSvc *svc.CommonSvc, statusSvc *svc.StatusSvc) *StatusChecksEventAny {
 	return &StatusChecksEventAny{
@@ -23,78 +18,91 @@ func NewStatusEventAny(commonSvc *svc.CommonSvc, statusSvc *svc.StatusSvc) *Stat
 	}
 }
 
+// StatusChecksEventAny 는 commit 의 status, check run 결과를 StatusSvc 로 모아 commit 단위의 요약으로 보냅니다.
 type StatusChecksEventAny struct {
 	commonSvc *svc.CommonSvc
 	statusSvc *svc.StatusSvc
 }
 
-func (cb *StatusChecksEventAny) Register(handler *githubevents.EventHandler) {
+func (cb *StatusChecksEventAny) Register(handler *EventHandler) {
 	handler.OnStatusEventAny(func(deliveryID string, eventName string, event *libgithub.StatusEvent) error {
+		// https://docs.github.com/en/webhooks/webhook-events-and-payloads#status
+		// pending state 는 무시합니다.
+		if event.GetState() == "pending" {
+			return nil
+		}
 		ctx := context.TODO()
 		installCtx := github.NewInstallationContext(
 			event.Installation.GetID(),
 			event.Org.GetLogin())
-		message, err := cb.buildStatusMessage(ctx, installCtx, event)
-		if err != nil {
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, "", event.Sender)); err != nil || !notify {
 			return err
 		}
-		if message == nil {
-			return nil
-		}
-		return cb.statusSvc.SyncCommitStatusWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.GetSHA(), message)
+		return cb.statusSvc.NotifyCommitCheck(ctx, svc.CommitRef{
+			InstallCtx: installCtx,
+			Repository: event.Repo,
+			SHA:        event.GetSHA(),
+		}, false)
 	})
 
 	handler.OnCheckRunEventCompleted(func(deliveryID string, eventName string, event *libgithub.CheckRunEvent) error {
-		// NOTE: check_suite 의 상태가 completed 인 경우에만 check_run 의 결과를 메시지로 보냅니다.
-		// ref) https://docs.github.com/en/rest/guides/using-the-rest-api-to-interact-with-checks?apiVersion=2022-11-28#about-check-suites
-		if event.GetCheckRun().GetCheckSuite().GetStatus() != "completed" {
+		// NOTE: github actions 의 결과는 WorkflowEventCompleted 에서 workflow run 단위로 보냅니다.
+		if event.GetCheckRun().GetApp().GetSlug() == githubActionsAppSlug {
 			return nil
 		}
 		ctx := context.TODO()
 		installCtx := github.NewInstallationContext(
 			event.Installation.GetID(),
 			event.Org.GetLogin())
-		message, err := cb.buildCheckRunMessage(ctx, installCtx, event)
-		if err != nil {
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
 			return err
 		}
-		if message == nil {
+		// NOTE: check_suite 가 completed 인 경우 debounce 를 기다리지 않고 바로 요약을 보냅니다.
+		// ref) https://docs.github.com/en/rest/guides/using-the-rest-api-to-interact-with-checks?apiVersion=2022-11-28#about-check-suites
+		settled := event.GetCheckRun().GetCheckSuite().GetStatus() == "completed"
+		return cb.statusSvc.NotifyCommitCheck(ctx, newCommitRefFromCheckRun(installCtx, event), settled)
+	})
+
+	// NOTE: check run 의 check_suite.status 는 check run 이 끝난 시점의 값이므로, 마지막 check run 보다 늦게 끝나는 check suite 는 check_suite event 로 처리합니다.
+	handler.OnCheckSuiteEventCompleted(func(deliveryID string, eventName string, event *libgithub.CheckSuiteEvent) error {
+		if event.GetCheckSuite().GetApp().GetSlug() == githubActionsAppSlug {
 			return nil
 		}
-		return cb.statusSvc.SyncCommitStatusWithChannelTalk(ctx, installCtx, event.Repo.GetName(), event.CheckRun.GetHeadSHA(), message)
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin())
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+		return cb.statusSvc.NotifyCommitCheck(ctx, newCommitRefFromCheckSuite(installCtx, event), true)
 	})
 }
 
-func (cb *StatusChecksEventAny) buildStatusMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.StatusEvent) (*model.Message, error) {
-	var title string
-	commitLink := model.InlineLink(event.GetTargetURL(), event.Commit.GetSHA()[:10])
-	switch event.GetState() {
-	case "success", "error", "failure":
-		title = fmt.Sprintf(statusResultFormat, event.GetContext(), commitLink, event.GetState())
-	default:
-		// https://docs.github.com/en/webhooks/webhook-events-and-payloads#status
-		// pending state 는 무시합니다.
-		return nil, nil
+// newCommitRefFromCheckRun 은 payload 의 pull request 를 포함한 commit 을 만듭니다.
+// payload 에는 head 가 같은 열려있는 pull request 만 포함되며, fork 의 branch 이거나 merge 된 commit 인 경우 비어있습니다.
+func newCommitRefFromCheckRun(installCtx github.InstallationContext, event *libgithub.CheckRunEvent) svc.CommitRef {
+	return svc.CommitRef{
+		InstallCtx:         installCtx,
+		Repository:         event.Repo,
+		SHA:                event.GetCheckRun().GetHeadSHA(),
+		PullRequestNumbers: pullRequestNumbers(event.GetCheckRun().PullRequests),
 	}
-
-	return model.NewMessage(
-		model.NewTextBlock(title),
-	), nil
 }
 
-// TODO : event.CheckRun.PullRequests 에 PullRequest 가 들어가 있는 경우는??
-func (cb *StatusChecksEventAny) buildCheckRunMessage(ctx context.Context, installCtx github.InstallationContext, event *libgithub.CheckRunEvent) (*model.Message, error) {
-	var title string
-	switch event.CheckRun.GetConclusion() {
-	case "success", "cancelled", "failure", "timed_out":
-		title = fmt.Sprintf(statusResultFormat, event.CheckRun.GetName(), event.CheckRun.App.GetName(), event.CheckRun.GetConclusion())
-	default:
-		// 외 conclusion 은 다음을 참고합니다.
-		// https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_run
-		return nil, nil
+func newCommitRefFromCheckSuite(installCtx github.InstallationContext, event *libgithub.CheckSuiteEvent) svc.CommitRef {
+	return svc.CommitRef{
+		InstallCtx:         installCtx,
+		Repository:         event.Repo,
+		SHA:                event.GetCheckSuite().GetHeadSHA(),
+		PullRequestNumbers: pullRequestNumbers(event.GetCheckSuite().PullRequests),
 	}
+}
 
-	return model.NewMessage(
-		model.NewTextBlock(title),
-	), nil
+func pullRequestNumbers(pullRequests []*libgithub.PullRequest) []int {
+	var numbers []int
+	for _, pullRequest := range pullRequests {
+		numbers = append(numbers, pullRequest.GetNumber())
+	}
+	return numbers
 }
@@ -0,0 +1,44 @@
+package callback
+
+import (
+	"embed"
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/github"
+)
+
+//go:embed testdata/*
+var testdata embed.FS
+
+func parseTestWebhook(t *testing.T, eventName, path string) any {
+	payload, err := testdata.ReadFile(path)
+	assert.NoError(t, err)
+	event, err := libgithub.ParseWebHook(eventName, payload)
+	assert.NoError(t, err)
+	return event
+}
+
+// 같은 sha 를 head 로 하는 pull request 가 여러 개이면 payload 의 pull request 를 모두 사용합니다.
+func TestNewCommitRefFromCheckRun(t *testing.T) {
+	event := parseTestWebhook(t, "check_run", "testdata/check_run_completed.json").(*libgithub.CheckRunEvent)
+	installCtx := github.NewInstallationContext(event.Installation.GetID(), event.Org.GetLogin())
+
+	ref := newCommitRefFromCheckRun(installCtx, event)
+	assert.Equal(t, "channel-io", ref.InstallCtx.OrgLogin)
+	assert.Equal(t, "cht-app-github", ref.Repository.GetName())
+	assert.Equal(t, "8a3f0d6c2b1e4f5a9c7d0e1b2a3c4d5e6f708192", ref.SHA)
+	assert.Equal(t, []int{412, 415}, ref.PullRequestNumbers)
+}
+
+// fork 의 branch 인 경우 payload 에 pull request 가 없어 commit sha 로 조회합니다.
+func TestNewCommitRefFromCheckSuite_Fork(t *testing.T) {
+	event := parseTestWebhook(t, "check_suite", "testdata/check_suite_completed_fork.json").(*libgithub.CheckSuiteEvent)
+	installCtx := github.NewInstallationContext(event.Installation.GetID(), event.Org.GetLogin())
+
+	ref := newCommitRefFromCheckSuite(installCtx, event)
+	assert.Equal(t, "c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f7", ref.SHA)
+	assert.Empty(t, ref.PullRequestNumbers)
+}
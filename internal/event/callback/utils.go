@@ -2,6 +2,10 @@ package callback
 
 import (
 	"github.com/google/go-github/v60/github"
+
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
 func isSentFromBot(sender *github.User) bool {
@@ -10,3 +14,92 @@ func isSentFromBot(sender *github.User) bool {
 	}
 	return false
 }
+
+func newRouteTargetFromIssue(action string, issue *github.Issue) routing.Target {
+	family := routing.FamilyIssue
+	if issue.IsPullRequest() {
+		family = routing.FamilyPullRequest
+	}
+	return routing.Target{
+		Family: family,
+		Action: action,
+		Labels: labelNames(issue.Labels),
+		Number: issue.GetNumber(),
+	}
+}
+
+func newRouteTargetFromPullRequest(action string, pullRequest *github.PullRequest) routing.Target {
+	return routing.Target{
+		Family:     routing.FamilyPullRequest,
+		Action:     action,
+		Labels:     labelNames(pullRequest.Labels),
+		BaseBranch: pullRequest.GetBase().GetRef(),
+		Number:     pullRequest.GetNumber(),
+	}
+}
+
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+func newFilterSubject(eventName, action string, sender *github.User) repoconfig.FilterSubject {
+	return repoconfig.FilterSubject{
+		Event:       eventName,
+		Action:      action,
+		SenderLogin: sender.GetLogin(),
+		SenderIsBot: isSentFromBot(sender),
+	}
+}
+
+func newFilterSubjectFromIssue(eventName, action string, sender *github.User, issue *github.Issue) repoconfig.FilterSubject {
+	subject := newFilterSubject(eventName, action, sender)
+	subject.Labels = labelNames(issue.Labels)
+	subject.HasLabels = true
+	return subject
+}
+
+func newFilterSubjectFromPullRequest(eventName, action string, sender *github.User, pullRequest *github.PullRequest) repoconfig.FilterSubject {
+	subject := newFilterSubject(eventName, action, sender)
+	subject.Draft = pullRequest.GetDraft()
+	subject.BaseBranch = pullRequest.GetBase().GetRef()
+	subject.Labels = labelNames(pullRequest.Labels)
+	subject.HasLabels = true
+	return subject
+}
+
+func newRepositoryData(repo *github.Repository) template.Repository {
+	return template.Repository{
+		Name: repo.GetName(),
+		URL:  repo.GetHTMLURL(),
+	}
+}
+
+func newIssueData(issue *github.Issue) template.Issue {
+	return template.Issue{
+		Number: issue.GetNumber(),
+		Title:  issue.GetTitle(),
+		URL:    issue.GetHTMLURL(),
+	}
+}
+
+func newCommentData(comment *github.IssueComment) template.Comment {
+	return template.Comment{
+		URL:  comment.GetHTMLURL(),
+		Body: comment.GetBody(),
+	}
+}
+
+func newPullRequestData(pullRequest *github.PullRequest) template.PullRequest {
+	return template.PullRequest{
+		Number: pullRequest.GetNumber(),
+		Title:  pullRequest.GetTitle(),
+		URL:    pullRequest.GetHTMLURL(),
+		Head:   pullRequest.GetHead().GetLabel(),
+		Base:   pullRequest.GetBase().GetLabel(),
+		Draft:  pullRequest.GetDraft(),
+	}
+}
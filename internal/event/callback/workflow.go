@@ -0,0 +1,234 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+// workflowUpdate 는 workflow run 혹은 workflow job 중 하나의 event 입니다. batch 에 저장하기 위해 json 으로 encode 합니다.
+type workflowUpdate struct {
+	Run *libgithub.WorkflowRunEvent `json:"run,omitempty"`
+	Job *libgithub.WorkflowJobEvent `json:"job,omitempty"`
+}
+
+func NewWorkflowEventCompleted(
+	commonSvc *svc.CommonSvc,
+	statusSvc *svc.StatusSvc,
+	batches *queue.Batches,
+) *WorkflowEventCompleted {
+	cb := &WorkflowEventCompleted{
+		commonSvc: commonSvc,
+		statusSvc: statusSvc,
+	}
+	cb.batcher = queue.NewBatcher(batches, "workflow_runs", cb.flush)
+	return cb
+}
+
+// WorkflowEventCompleted 는 github actions 의 workflow run 결과를 요약해서 보냅니다.
+// 같은 run 의 workflow_run, workflow_job event 를 모아 하나의 message 로 작성합니다.
+//   - run 이 끝난 경우 : 결과, 걸린 시간, 실패한 job 목록
+//   - run 이 끝나기 전에 job 이 실패한 경우 : 지금까지 실패한 job 목록
+type WorkflowEventCompleted struct {
+	commonSvc *svc.CommonSvc
+	statusSvc *svc.StatusSvc
+	batcher   *queue.Batcher[workflowUpdate]
+}
+
+func (cb *WorkflowEventCompleted) Register(handler *EventHandler) {
+	handler.OnWorkflowRunEventCompleted(func(deliveryID string, eventName string, event *libgithub.WorkflowRunEvent) error {
+		switch event.WorkflowRun.GetConclusion() {
+		case "success", "cancelled", "failure", "timed_out", "startup_failure":
+		default:
+			// skipped, neutral 등은 무시합니다.
+			return nil
+		}
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin())
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+
+		run := event.WorkflowRun
+		return cb.batcher.Add(ctx, workflowKey(event.Installation.GetID(), event.Repo.GetName(), run.GetID(), int64(run.GetRunAttempt())), workflowUpdate{Run: event})
+	})
+
+	handler.OnWorkflowJobEventCompleted(func(deliveryID string, eventName string, event *libgithub.WorkflowJobEvent) error {
+		if !svc.IsFailedConclusion(event.WorkflowJob.GetConclusion()) {
+			return nil
+		}
+		ctx := context.TODO()
+		installCtx := github.NewInstallationContext(
+			event.Installation.GetID(),
+			event.Org.GetLogin())
+		if notify, err := cb.commonSvc.ShouldNotify(ctx, installCtx, event.Repo.GetName(), newFilterSubject(eventName, event.GetAction(), event.Sender)); err != nil || !notify {
+			return err
+		}
+
+		job := event.WorkflowJob
+		return cb.batcher.Add(ctx, workflowKey(event.Installation.GetID(), event.Repo.GetName(), job.GetRunID(), job.GetRunAttempt()), workflowUpdate{Job: event})
+	})
+}
+
+func workflowKey(installationID int64, repository string, runID, runAttempt int64) string {
+	return fmt.Sprintf("%d/%s/%d/%d", installationID, repository, runID, runAttempt)
+}
+
+func (cb *WorkflowEventCompleted) flush(ctx context.Context, _ string, updates []workflowUpdate) error {
+	if run := lastWorkflowRun(updates); run != nil {
+		return cb.syncRunCompleted(ctx, run)
+	}
+	return cb.syncJobsFailed(ctx, updates)
+}
+
+func lastWorkflowRun(updates []workflowUpdate) *libgithub.WorkflowRunEvent {
+	for i := len(updates) - 1; i >= 0; i-- {
+		if updates[i].Run != nil {
+			return updates[i].Run
+		}
+	}
+	return nil
+}
+
+func (cb *WorkflowEventCompleted) syncRunCompleted(ctx context.Context, event *libgithub.WorkflowRunEvent) error {
+	installCtx := github.NewInstallationContext(
+		event.Installation.GetID(),
+		event.Org.GetLogin())
+	repository := event.Repo.GetName()
+	run := event.WorkflowRun
+
+	var failedJobs []*libgithub.WorkflowJob
+	if run.GetConclusion() != "success" {
+		jobs, err := cb.statusSvc.ListFailedWorkflowJobs(ctx, installCtx, repository, run.GetID())
+		if err != nil {
+			return err
+		}
+		failedJobs = jobs
+	}
+
+	sender, err := cb.commonSvc.FindManagerNameByGithubUsername(ctx, installCtx, repository, routing.FamilyCI, event.Sender.GetLogin())
+	if err != nil {
+		return err
+	}
+	data := template.WorkflowData{
+		Repository: newRepositoryData(event.Repo),
+		Run:        newWorkflowRunData(run),
+		FailedJobs: newWorkflowJobsData(failedJobs),
+		Sender:     sender,
+	}
+	message, err := cb.buildMessage(ctx, installCtx, repository, template.WorkflowRunCompleted, data)
+	if err != nil {
+		return err
+	}
+
+	return cb.statusSvc.SyncWorkflowWithChannelTalk(ctx, installCtx, repository, run.GetHeadSHA(), pullRequestNumbers(run.PullRequests), routing.Target{
+		Family: routing.FamilyCI,
+		Action: run.GetConclusion(),
+	}, message)
+}
+
+func (cb *WorkflowEventCompleted) syncJobsFailed(ctx context.Context, updates []workflowUpdate) error {
+	event := updates[0].Job
+	installCtx := github.NewInstallationContext(
+		event.Installation.GetID(),
+		event.Org.GetLogin())
+	repository := event.Repo.GetName()
+
+	seen := make(map[int64]bool)
+	var failedJobs []*libgithub.WorkflowJob
+	for _, update := range updates {
+		job := update.Job.WorkflowJob
+		if seen[job.GetID()] {
+			continue
+		}
+		seen[job.GetID()] = true
+		failedJobs = append(failedJobs, job)
+	}
+
+	job := event.WorkflowJob
+	data := template.WorkflowData{
+		Repository: newRepositoryData(event.Repo),
+		Run: template.WorkflowRun{
+			Name:   job.GetWorkflowName(),
+			URL:    fmt.Sprintf("%s/actions/runs/%d", event.Repo.GetHTMLURL(), job.GetRunID()),
+			Branch: job.GetHeadBranch(),
+			SHA:    job.GetHeadSHA(),
+		},
+		FailedJobs: newWorkflowJobsData(failedJobs),
+	}
+	message, err := cb.buildMessage(ctx, installCtx, repository, template.WorkflowRunFailing, data)
+	if err != nil {
+		return err
+	}
+	return cb.statusSvc.SyncWorkflowWithChannelTalk(ctx, installCtx, repository, job.GetHeadSHA(), nil, routing.Target{
+		Family: routing.FamilyCI,
+		Action: job.GetConclusion(),
+	}, message)
+}
+
+func (cb *WorkflowEventCompleted) buildMessage(ctx context.Context, installCtx github.InstallationContext, repository string, name template.Name, data template.WorkflowData) (*model.Message, error) {
+	title, err := cb.commonSvc.Render(ctx, installCtx, repository, name, data)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	if len(data.FailedJobs) == 0 {
+		return model.NewMessage(blocks...), nil
+	}
+
+	jobBlocks := make([]model.MessageBlock, 0, len(data.FailedJobs))
+	for _, job := range data.FailedJobs {
+		text, err := cb.commonSvc.Render(ctx, installCtx, repository, template.WorkflowJobFailed, job)
+		if err != nil {
+			return nil, err
+		}
+		jobBlocks = append(jobBlocks, model.NewTextBlock(text))
+	}
+	blocks = append(blocks, model.NewBulletsBlock(jobBlocks))
+	return model.NewMessage(blocks...), nil
+}
+
+func newWorkflowRunData(run *libgithub.WorkflowRun) template.WorkflowRun {
+	return template.WorkflowRun{
+		Name:       run.GetName(),
+		Number:     run.GetRunNumber(),
+		URL:        run.GetHTMLURL(),
+		Event:      run.GetEvent(),
+		Branch:     run.GetHeadBranch(),
+		SHA:        run.GetHeadSHA(),
+		Conclusion: run.GetConclusion(),
+		Duration:   formatDuration(run.GetRunStartedAt().Time, run.GetUpdatedAt().Time),
+	}
+}
+
+func newWorkflowJobsData(jobs []*libgithub.WorkflowJob) []template.WorkflowJob {
+	results := make([]template.WorkflowJob, 0, len(jobs))
+	for _, job := range jobs {
+		results = append(results, template.WorkflowJob{
+			Name:       job.GetName(),
+			URL:        job.GetHTMLURL(),
+			Conclusion: job.GetConclusion(),
+		})
+	}
+	return results
+}
+
+// formatDuration 은 두 시각 사이의 시간을 초 단위로 반올림해서 "1m30s" 형식으로 작성합니다.
+// 시각을 알 수 없는 경우 빈 문자열입니다.
+func formatDuration(from, to time.Time) string {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return ""
+	}
+	return to.Sub(from).Round(time.Second).String()
+}
@@ -0,0 +1,52 @@
+package callback
+
+import (
+	"testing"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "3m5s", formatDuration(from, from.Add(3*time.Minute+5*time.Second+400*time.Millisecond)))
+	assert.Equal(t, "0s", formatDuration(from, from))
+	assert.Equal(t, "", formatDuration(time.Time{}, from))
+	assert.Equal(t, "", formatDuration(from, from.Add(-time.Second)))
+}
+
+func TestLastWorkflowRun(t *testing.T) {
+	first := &libgithub.WorkflowRunEvent{Action: libgithub.String("completed")}
+	last := &libgithub.WorkflowRunEvent{Action: libgithub.String("completed")}
+	job := &libgithub.WorkflowJobEvent{}
+
+	assert.Nil(t, lastWorkflowRun([]workflowUpdate{{Job: job}}))
+	assert.Same(t, last, lastWorkflowRun([]workflowUpdate{{Run: first}, {Job: job}, {Run: last}, {Job: job}}))
+}
+
+func TestNewDeploymentData(t *testing.T) {
+	repo := &libgithub.Repository{HTMLURL: libgithub.String("https://github.com/channel-io/cht-app-github")}
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deployment := &libgithub.Deployment{
+		Environment: libgithub.String("production"),
+		Ref:         libgithub.String("main"),
+		SHA:         libgithub.String("0123456789abcdef"),
+		CreatedAt:   &libgithub.Timestamp{Time: createdAt},
+	}
+
+	created := newDeploymentData(repo, deployment, nil)
+	assert.Equal(t, "", created.State)
+	assert.Equal(t, "https://github.com/channel-io/cht-app-github/deployments", created.URL)
+
+	finished := newDeploymentData(repo, deployment, &libgithub.DeploymentStatus{
+		State:          libgithub.String("success"),
+		CreatedAt:      &libgithub.Timestamp{Time: createdAt.Add(time.Minute)},
+		LogURL:         libgithub.String("https://github.com/channel-io/cht-app-github/actions/runs/1"),
+		EnvironmentURL: libgithub.String(""),
+	})
+	assert.Equal(t, "success", finished.State)
+	assert.Equal(t, "1m0s", finished.Duration)
+	assert.Equal(t, "https://github.com/channel-io/cht-app-github/actions/runs/1", finished.URL)
+}
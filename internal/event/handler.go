@@ -1,34 +1,279 @@
 package event
 
 import (
-	"github.com/cbrgm/githubevents/githubevents"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/channel-io/cht-app-github/internal/config"
 	"github.com/channel-io/cht-app-github/internal/event/callback"
 	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/pkg/cache"
 )
 
+// DeliveryCache 는 처리가 끝난 delivery id 와 처리중인 delivery 의 선점 여부를 기록합니다.
+type DeliveryCache interface {
+	cache.AtomicCache[time.Time]
+}
+
 type GithubEventHandler struct {
-	*githubevents.EventHandler
+	webhookSecret string
+	callbacks     []registeredCallback
+	logger        logger.Logger
+	deliveryCache DeliveryCache
+	deliveryTTL   time.Duration
+}
+
+// registeredCallback 은 하나의 callback 만 등록한 handler 입니다.
+// 재시도할 때 이미 성공한 callback 을 다시 실행하지 않도록 callback 별로 dispatch 합니다.
+type registeredCallback struct {
+	name    string
+	handler *callback.EventHandler
 }
 
 type EventCallback interface {
-	Register(handler *githubevents.EventHandler)
+	Register(handler *callback.EventHandler)
 }
 
 func NewGithubEventHandler(
 	config *config.Config,
 	logger logger.Logger,
 	callbacks []EventCallback,
+	deliveryCache DeliveryCache,
 ) *GithubEventHandler {
-	h := githubevents.New(config.Github.App.WebhookSecret)
-	callback.HandleError(h, logger)
-
-	for _, callback := range callbacks {
-		callback.Register(h)
+	registered := make([]registeredCallback, 0, len(callbacks))
+	for i, cb := range callbacks {
+		h := callback.NewEventHandler(config.Github.App.WebhookSecret)
+		callback.HandleError(h, logger)
+		cb.Register(h)
+		registered = append(registered, registeredCallback{
+			name:    fmt.Sprintf("%d:%T", i, cb),
+			handler: h,
+		})
 	}
 
 	return &GithubEventHandler{
-		EventHandler: h,
+		webhookSecret: config.Github.App.WebhookSecret,
+		callbacks:     registered,
+		logger:        logger,
+		deliveryCache: deliveryCache,
+		deliveryTTL:   config.Event.Dedup.TTL,
+	}
+}
+
+const (
+	forceAttributeKey = "force"
+	runAttributeKey   = "run"
+)
+
+// Delivery 는 서명 검증을 마친 github webhook 요청입니다.
+type Delivery struct {
+	ID        string
+	EventName string
+	Payload   []byte
+	// Force 가 설정된 경우 이미 처리된 delivery 라도 다시 처리합니다.
+	Force bool
+	// Run 은 delivery 를 처리하는 한 번의 실행입니다. 같은 Run 을 재시도하면 성공한 callback 은 건너뜁니다.
+	// 비어있으면 delivery id 를 사용하고, Force 인 경우에는 건너뛰지 않습니다.
+	Run string
+}
+
+func NewDeliveryFromJob(job queue.Job) Delivery {
+	force, _ := strconv.ParseBool(job.Attributes[forceAttributeKey])
+	return Delivery{
+		ID:        job.ID,
+		EventName: job.Name,
+		Payload:   job.Payload,
+		Force:     force,
+		Run:       job.Attributes[runAttributeKey],
+	}
+}
+
+func (d Delivery) Job() queue.Job {
+	job := queue.NewJob(d.ID, d.EventName, d.Payload)
+	if d.Force || d.Run != "" {
+		job.Attributes = make(map[string]string)
+	}
+	if d.Force {
+		job.Attributes[forceAttributeKey] = strconv.FormatBool(d.Force)
+	}
+	if d.Run != "" {
+		job.Attributes[runAttributeKey] = d.Run
+	}
+	return job
+}
+
+func (d Delivery) run() string {
+	if d.Run != "" || d.Force {
+		return d.Run
+	}
+	return d.ID
+}
+
+// ParseDelivery 는 webhook 요청의 서명을 검증하고 payload 를 읽습니다.
+func (h *GithubEventHandler) ParseDelivery(req *http.Request) (Delivery, error) {
+	payload, err := libgithub.ValidatePayload(req, []byte(h.webhookSecret))
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	eventName := libgithub.WebHookType(req)
+	if _, err := libgithub.ParseWebHook(eventName, payload); err != nil {
+		return Delivery{}, err
+	}
+
+	return Delivery{
+		ID:        libgithub.DeliveryID(req),
+		EventName: eventName,
+		Payload:   payload,
+	}, nil
+}
+
+func (h *GithubEventHandler) HandleJob(ctx context.Context, job queue.Job) error {
+	return h.HandleDelivery(ctx, NewDeliveryFromJob(job))
+}
+
+// HandleDelivery 는 이미 처리된 delivery 를 건너뜁니다.
+// github 의 재전송이나 "Redeliver" 로 같은 메시지가 중복 작성되는 것을 막기 위함입니다.
+// 같은 delivery 가 동시에 처리되지 않도록 dispatch 전에 delivery 를 선점하고, 처리가 끝나면 선점을 해제합니다.
+func (h *GithubEventHandler) HandleDelivery(ctx context.Context, delivery Delivery) error {
+	event, err := libgithub.ParseWebHook(delivery.EventName, delivery.Payload)
+	if err != nil {
+		return err
+	}
+
+	run := delivery.run()
+	if run != "" {
+		// NOTE : 선점한 worker 가 종료되더라도 job 의 lease 가 끝나면 다시 처리할 수 있도록 lease 동안만 선점합니다.
+		claimed, err := h.deliveryCache.SetIfAbsent(ctx, claimKey(run), time.Now(), queue.DefaultLease)
+		if err != nil {
+			return err
+		}
+		// NOTE : 선점한 job 이 실패하면 그 job 이 재시도하므로 중복된 job 은 처리하지 않고 끝냅니다.
+		if !claimed {
+			h.logger.Infow("skip delivery being processed", "deliveryID", delivery.ID, "event", delivery.EventName)
+			return nil
+		}
+		defer func() {
+			if err := h.deliveryCache.Delete(ctx, claimKey(run)); err != nil {
+				h.logger.Warnw("failed to release delivery", "deliveryID", delivery.ID, "err", err)
+			}
+		}()
+	}
+
+	// NOTE : 다른 job 이 처리를 끝내고 선점을 해제했을 수 있으므로 선점한 뒤에 처리 여부를 확인합니다.
+	if !delivery.Force && delivery.ID != "" {
+		processedAt, err := h.deliveryCache.Get(ctx, delivery.ID)
+		if err != nil {
+			return err
+		}
+		if processedAt != nil {
+			h.logger.Infow("skip already processed delivery", "deliveryID", delivery.ID, "event", delivery.EventName, "processedAt", *processedAt)
+			return nil
+		}
+	}
+
+	if err := h.dispatchCallbacks(ctx, delivery, event); err != nil {
+		return err
+	}
+
+	if delivery.ID == "" {
+		return nil
+	}
+	return h.deliveryCache.Set(ctx, delivery.ID, time.Now(), h.deliveryTTL)
+}
+
+// dispatchCallbacks 는 callback 을 병렬로 실행합니다. 실패한 callback 이 있으면 성공한 callback 을 run 별로 기록합니다.
+// job 을 재시도하면 실패한 callback 만 다시 실행하여 message 가 중복 작성되지 않도록 합니다.
+func (h *GithubEventHandler) dispatchCallbacks(ctx context.Context, delivery Delivery, event interface{}) error {
+	run := delivery.run()
+	var (
+		mu        sync.Mutex
+		succeeded []string
+	)
+	eg := new(errgroup.Group)
+	for _, cb := range h.callbacks {
+		cb := cb
+		eg.Go(func() error {
+			if run != "" {
+				doneAt, err := h.deliveryCache.Get(ctx, callbackKey(run, cb.name))
+				if err != nil {
+					return err
+				}
+				if doneAt != nil {
+					return nil
+				}
+			}
+			if err := dispatch(cb.handler, delivery.ID, delivery.EventName, event); err != nil {
+				return err
+			}
+			mu.Lock()
+			succeeded = append(succeeded, cb.name)
+			mu.Unlock()
+			return nil
+		})
+	}
+	err := eg.Wait()
+	if err == nil || run == "" {
+		return err
+	}
+
+	for _, name := range succeeded {
+		if err := h.deliveryCache.Set(ctx, callbackKey(run, name), time.Now(), h.deliveryTTL); err != nil {
+			h.logger.Warnw("failed to record processed callback", "deliveryID", delivery.ID, "callback", name, "err", err)
+		}
+	}
+	return err
+}
+
+func claimKey(run string) string {
+	return fmt.Sprintf("claim:%s", run)
+}
+
+func callbackKey(run, name string) string {
+	return fmt.Sprintf("%s/%s", run, name)
+}
+
+// NOTE : callback 이 등록된 이벤트만 dispatch 합니다. 새로운 이벤트에 callback 을 등록하는 경우 case 를 추가해야 합니다.
+func dispatch(h *callback.EventHandler, deliveryID string, eventName string, event interface{}) error {
+	switch event := event.(type) {
+	case *libgithub.CheckRunEvent:
+		return h.CheckRunEvent(deliveryID, eventName, event)
+	case *libgithub.CheckSuiteEvent:
+		return h.CheckSuiteEvent(deliveryID, eventName, event)
+	case *libgithub.DeploymentEvent:
+		return h.DeploymentEvent(deliveryID, eventName, event)
+	case *libgithub.DeploymentStatusEvent:
+		return h.DeploymentStatusEvent(deliveryID, eventName, event)
+	case *libgithub.DiscussionEvent:
+		return h.DiscussionEvent(deliveryID, eventName, event)
+	case *libgithub.DiscussionCommentEvent:
+		return h.DiscussionCommentEvent(deliveryID, eventName, event)
+	case *libgithub.IssueCommentEvent:
+		return h.IssueCommentEvent(deliveryID, eventName, event)
+	case *libgithub.IssuesEvent:
+		return h.IssuesEvent(deliveryID, eventName, event)
+	case *libgithub.PullRequestEvent:
+		return h.PullRequestEvent(deliveryID, eventName, event)
+	case *libgithub.PullRequestReviewEvent:
+		return h.PullRequestReviewEvent(deliveryID, eventName, event)
+	case *libgithub.PullRequestReviewCommentEvent:
+		return h.PullRequestReviewCommentEvent(deliveryID, eventName, event)
+	case *libgithub.ReleaseEvent:
+		return h.ReleaseEvent(deliveryID, eventName, event)
+	case *libgithub.StatusEvent:
+		return h.StatusEvent(deliveryID, eventName, event)
+	case *libgithub.WorkflowJobEvent:
+		return h.WorkflowJobEvent(deliveryID, eventName, event)
+	case *libgithub.WorkflowRunEvent:
+		return h.WorkflowRunEvent(deliveryID, eventName, event)
 	}
+	return nil
 }
@@ -0,0 +1,201 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event/callback"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+const issuesOpenedPayload = `{"action":"opened","issue":{"number":1},"repository":{"name":"cht-app-github"}}`
+
+func TestGithubEventHandler_HandleDelivery_Dedup(t *testing.T) {
+	t.Parallel()
+
+	cb := &countingCallback{}
+	h := NewGithubEventHandler(new(config.Config), nopLogger{}, []EventCallback{cb}, cache.NewLocalCache[time.Time]())
+
+	ctx := context.TODO()
+	delivery := Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+	}
+
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 1, cb.count)
+
+	// redelivery is skipped
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 1, cb.count)
+
+	// forced redelivery is processed again
+	delivery.Force = true
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 2, cb.count)
+
+	// other delivery is processed
+	assert.NoError(t, h.HandleDelivery(ctx, Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0959",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+	}))
+	assert.Equal(t, 3, cb.count)
+}
+
+func TestGithubEventHandler_HandleDelivery_NotRecordedOnError(t *testing.T) {
+	t.Parallel()
+
+	cb := &countingCallback{err: assert.AnError}
+	h := NewGithubEventHandler(new(config.Config), nopLogger{}, []EventCallback{cb}, cache.NewLocalCache[time.Time]())
+
+	ctx := context.TODO()
+	delivery := Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+	}
+
+	assert.Error(t, h.HandleDelivery(ctx, delivery))
+	assert.Error(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 2, cb.count)
+}
+
+func TestGithubEventHandler_HandleDelivery_SkipClaimedDelivery(t *testing.T) {
+	t.Parallel()
+
+	cb := &countingCallback{}
+	deliveryCache := cache.NewLocalCache[time.Time]()
+	h := NewGithubEventHandler(new(config.Config), nopLogger{}, []EventCallback{cb}, deliveryCache)
+
+	ctx := context.TODO()
+	delivery := Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+	}
+
+	// delivery claimed by another job is skipped
+	claimed, err := deliveryCache.SetIfAbsent(ctx, claimKey(delivery.ID), time.Now(), time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 0, cb.count)
+
+	// released delivery is processed and released again
+	assert.NoError(t, deliveryCache.Delete(ctx, claimKey(delivery.ID)))
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 1, cb.count)
+	claim, err := deliveryCache.Get(ctx, claimKey(delivery.ID))
+	assert.NoError(t, err)
+	assert.Nil(t, claim)
+}
+
+func TestGithubEventHandler_HandleDelivery_RetrySkipsSucceededCallbacks(t *testing.T) {
+	t.Parallel()
+
+	succeeded := &countingCallback{}
+	failing := &countingCallback{err: assert.AnError}
+	h := NewGithubEventHandler(new(config.Config), nopLogger{}, []EventCallback{succeeded, failing}, cache.NewLocalCache[time.Time]())
+
+	ctx := context.TODO()
+	delivery := Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+	}
+
+	assert.Error(t, h.HandleDelivery(ctx, delivery))
+	failing.err = nil
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 1, succeeded.count)
+	assert.Equal(t, 2, failing.count)
+
+	// forced run processes every callback again, and its retries skip succeeded callbacks of the run
+	delivery.Force = true
+	delivery.Run = "force-1"
+	failing.err = assert.AnError
+	assert.Error(t, h.HandleDelivery(ctx, delivery))
+	failing.err = nil
+	assert.NoError(t, h.HandleDelivery(ctx, delivery))
+	assert.Equal(t, 2, succeeded.count)
+	assert.Equal(t, 4, failing.count)
+}
+
+func TestGithubEventHandler_HandleDelivery_DiscussionComment(t *testing.T) {
+	t.Parallel()
+
+	var handled []string
+	cb := callbackFunc(func(handler *callback.EventHandler) {
+		handler.OnDiscussionCommentEventCreated(func(deliveryID string, eventName string, event *libgithub.DiscussionCommentEvent) error {
+			handled = append(handled, event.Comment.GetBody())
+			return nil
+		})
+	})
+	h := NewGithubEventHandler(new(config.Config), nopLogger{}, []EventCallback{cb}, cache.NewLocalCache[time.Time]())
+
+	ctx := context.TODO()
+	assert.NoError(t, h.HandleDelivery(ctx, Delivery{
+		EventName: "discussion_comment",
+		Payload:   []byte(`{"action":"created","discussion":{"number":3},"comment":{"body":"hello"}}`),
+	}))
+	assert.NoError(t, h.HandleDelivery(ctx, Delivery{
+		EventName: "discussion_comment",
+		Payload:   []byte(`{"action":"edited","discussion":{"number":3},"comment":{"body":"edited"}}`),
+	}))
+	assert.Equal(t, []string{"hello"}, handled)
+}
+
+func TestDelivery_Job(t *testing.T) {
+	t.Parallel()
+
+	delivery := Delivery{
+		ID:        "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		EventName: "issues",
+		Payload:   []byte(issuesOpenedPayload),
+		Force:     true,
+	}
+	assert.Equal(t, delivery, NewDeliveryFromJob(delivery.Job()))
+
+	delivery.Run = "force-1"
+	assert.Equal(t, delivery, NewDeliveryFromJob(delivery.Job()))
+
+	delivery.Force = false
+	delivery.Run = ""
+	assert.Equal(t, delivery, NewDeliveryFromJob(delivery.Job()))
+}
+
+type countingCallback struct {
+	count int
+	err   error
+}
+
+func (cb *countingCallback) Register(handler *callback.EventHandler) {
+	handler.OnIssuesEventOpened(func(deliveryID string, eventName string, event *libgithub.IssuesEvent) error {
+		cb.count++
+		return cb.err
+	})
+}
+
+type callbackFunc func(handler *callback.EventHandler)
+
+func (f callbackFunc) Register(handler *callback.EventHandler) {
+	f(handler)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Error(...interface{})          {}
+func (nopLogger) Errorw(string, ...interface{}) {}
+func (nopLogger) Warn(...interface{})           {}
+func (nopLogger) Warnw(string, ...interface{})  {}
+func (nopLogger) Info(...interface{})           {}
+func (nopLogger) Infow(string, ...interface{})  {}
+func (nopLogger) Debug(...interface{})          {}
+func (nopLogger) Debugw(string, ...interface{}) {}
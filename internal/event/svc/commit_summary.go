@@ -0,0 +1,323 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+const (
+	commitStateTTL   = 24 * time.Hour
+	pullRequestCITTL = 30 * 24 * time.Hour
+
+	// commitRecheckInterval 은 진행중인 check 가 남아있는 commit 을 다시 확인하는 주기입니다.
+	commitRecheckInterval = time.Minute
+)
+
+// CommitStateCache 는 commit 마다 요약을 보낸 상태를 기록합니다.
+type CommitStateCache interface {
+	cache.AtomicCache[CommitState]
+}
+
+// PullRequestCICache 는 열려있는 pull request 에 마지막으로 보낸 ci 결과를 기록합니다.
+// 실패를 보낸 pull request 의 ci 가 다시 성공했을 때 알리기 위해 사용합니다.
+type PullRequestCICache interface {
+	cache.Cache[PullRequestCI]
+}
+
+type PullRequestCI struct {
+	SHA    string `json:"sha"`
+	Result string `json:"result"`
+}
+
+// CommitRef 는 ci 결과를 요약할 commit 입니다.
+type CommitRef struct {
+	InstallCtx github.InstallationContext
+	Repository *libgithub.Repository
+	SHA        string
+	// PullRequestNumbers 는 check run, check suite payload 에 포함된 pull request 입니다.
+	// 비어있으면 commit sha 로 pull request 를 조회합니다.
+	PullRequestNumbers []int
+}
+
+func (r CommitRef) key() string {
+	return fmt.Sprintf("%s/%s/%s", r.InstallCtx.OrgLogin, r.Repository.GetName(), r.SHA)
+}
+
+// CommitState 는 commit 마다 요약을 보낸 상태입니다.
+type CommitState struct {
+	// FirstSeenAt 은 commit 의 check 결과를 처음 받은 시각입니다.
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	// Posted 는 마지막으로 보낸 요약의 signature 입니다. 결과가 바뀐 경우에만 다시 보냅니다.
+	Posted string `json:"posted"`
+}
+
+// NotifyCommitCheck 는 commit 의 check 결과가 바뀌었을 때 호출합니다.
+// 같은 commit 의 결과를 debounce 기간 동안 모은 뒤 하나의 요약으로 보냅니다. settled 인 경우 (check suite 가 끝난 경우) 바로 보냅니다.
+func (svc *StatusSvc) NotifyCommitCheck(ctx context.Context, ref CommitRef, settled bool) error {
+	key := ref.key()
+	if _, err := svc.commits.SetIfAbsent(ctx, key, CommitState{FirstSeenAt: time.Now()}, commitStateTTL); err != nil {
+		return err
+	}
+	if err := svc.batcher.Add(ctx, key, ref); err != nil {
+		return err
+	}
+	if settled {
+		return svc.batcher.Flush(ctx, key)
+	}
+	return nil
+}
+
+func (svc *StatusSvc) flushCommitSummaries(ctx context.Context, key string, refs []CommitRef) error {
+	ref := refs[len(refs)-1]
+	ref.PullRequestNumbers = mergePullRequestNumbers(refs)
+	return svc.syncCommitSummary(ctx, key, ref)
+}
+
+func (svc *StatusSvc) syncCommitSummary(ctx context.Context, key string, ref CommitRef) error {
+	checks, err := svc.githubSvc.ListCommitChecks(ctx, ref.InstallCtx, ref.Repository.GetName(), ref.SHA)
+	if err != nil {
+		return err
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+	summary := summarizeChecks(checks)
+
+	state, err := svc.findCommitState(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	// NOTE : 진행중인 check 가 남아있으면 모두 끝날 때까지 기다립니다. 끝나지 않는 check 가 있을 수 있어 settleTimeout 이 지나면 보냅니다.
+	// github actions 의 check run 처럼 끝났다는 event 를 받지 않는 check 도 있으므로, event 를 기다리지 않고 settleTimeout 까지 주기적으로 다시 확인합니다.
+	if remaining := svc.settleTimeout - time.Since(state.FirstSeenAt); summary.Pending > 0 && remaining > 0 {
+		return svc.batcher.AddAfter(ctx, key, ref, min(commitRecheckInterval, remaining))
+	}
+	if summary.signature == state.Posted {
+		return nil
+	}
+
+	conf, err := svc.commonSvc.FindCIConfig(ctx, ref.InstallCtx, ref.Repository.GetName())
+	if err != nil {
+		return err
+	}
+	pullRequests, err := svc.listCommitPullRequests(ctx, ref, lo.FromPtr(conf.OpenPullRequests))
+	if err != nil {
+		return err
+	}
+
+	var merged, open []*libgithub.PullRequest
+	for _, pullRequest := range pullRequests {
+		switch {
+		case github.WithMergedPullRequestFilter()(pullRequest):
+			merged = append(merged, pullRequest)
+		case pullRequest.GetState() == "open" && lo.FromPtr(conf.OpenPullRequests):
+			open = append(open, pullRequest)
+		}
+	}
+
+	// NOTE : merge 된 pull request 에는 설정과 관계없이 항상 결과를 보냅니다.
+	if len(merged) > 0 {
+		message, err := svc.buildCommitSummaryMessage(ctx, ref, summary, template.CISummary, "")
+		if err != nil {
+			return err
+		}
+		err = svc.SyncCommitStatusWithChannelTalk(ctx, ref.InstallCtx, ref.Repository.GetName(), merged, routing.Target{
+			Family: routing.FamilyCI,
+			Action: summary.Result,
+		}, message)
+		if err != nil {
+			return err
+		}
+	}
+	for _, pullRequest := range open {
+		if err := svc.syncOpenPullRequestSummary(ctx, ref, pullRequest, summary, lo.FromPtr(conf.NotifyFixed)); err != nil {
+			return err
+		}
+	}
+
+	state.Posted = summary.signature
+	return svc.commits.Set(ctx, key, state, commitStateTTL)
+}
+
+// listCommitPullRequests 는 commit 의 draft 가 아닌 pull request 를 조회합니다.
+// payload 에 pull request 가 있으면 commit sha 로 조회하지 않습니다. payload 의 pull request 는 head 가 commit 인 열려있는 pull request 이므로,
+// 열려있는 pull request 에 보내지 않는 경우에는 조회하지 않습니다.
+func (svc *StatusSvc) listCommitPullRequests(ctx context.Context, ref CommitRef, includeOpen bool) ([]*libgithub.PullRequest, error) {
+	repository := ref.Repository.GetName()
+	if len(ref.PullRequestNumbers) == 0 {
+		return svc.githubSvc.ListPullRequestNumberByCommitSHA(ctx, ref.InstallCtx, repository, ref.SHA, github.WithNotDraftPullRequestFilter())
+	}
+	if !includeOpen {
+		return nil, nil
+	}
+
+	pullRequests := make([]*libgithub.PullRequest, 0, len(ref.PullRequestNumbers))
+	for _, number := range ref.PullRequestNumbers {
+		pullRequest, err := svc.githubSvc.FetchPullRequest(ctx, ref.InstallCtx, repository, number)
+		if err != nil {
+			return nil, err
+		}
+		if github.WithNotDraftPullRequestFilter()(pullRequest) {
+			pullRequests = append(pullRequests, pullRequest)
+		}
+	}
+	return pullRequests, nil
+}
+
+// mergePullRequestNumbers 는 debounce 기간 동안 모은 event 들의 pull request 를 중복 없이 순서대로 합칩니다.
+func mergePullRequestNumbers(refs []CommitRef) []int {
+	var numbers []int
+	for _, ref := range refs {
+		numbers = append(numbers, ref.PullRequestNumbers...)
+	}
+	numbers = lo.Uniq(numbers)
+	sort.Ints(numbers)
+	return numbers
+}
+
+// syncOpenPullRequestSummary 는 열려있는 pull request 의 thread 에 작성자를 멘션해서 ci 실패를 보냅니다.
+// notifyFixed 인 경우 실패를 보냈던 pull request 의 ci 가 성공하면 다시 성공했다고 보냅니다.
+func (svc *StatusSvc) syncOpenPullRequestSummary(ctx context.Context, ref CommitRef, pullRequest *libgithub.PullRequest, summary checkSummary, notifyFixed bool) error {
+	repository := ref.Repository.GetName()
+	key := fmt.Sprintf("%s/%s/%d", ref.InstallCtx.OrgLogin, repository, pullRequest.GetNumber())
+	last, err := svc.pullRequestCI.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var name template.Name
+	switch {
+	case summary.Result == "failure":
+		name = template.CISummary
+	case summary.Result == "success" && notifyFixed && last != nil && last.Result == "failure":
+		name = template.CIFixed
+	default:
+		return nil
+	}
+
+	found, err := svc.threadSvc.FindThread(ctx, ref.InstallCtx, repository, pullRequest.GetNumber(), true)
+	if err != nil {
+		return err
+	}
+	if found == nil {
+		return nil
+	}
+
+	mention, err := svc.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, ref.InstallCtx, repository, routing.FamilyCI, pullRequest.GetUser().GetLogin())
+	if err != nil {
+		return err
+	}
+	message, err := svc.buildCommitSummaryMessage(ctx, ref, summary, name, mention)
+	if err != nil {
+		return err
+	}
+	if err := svc.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, false); err != nil {
+		return err
+	}
+	return svc.pullRequestCI.Set(ctx, key, PullRequestCI{SHA: ref.SHA, Result: summary.Result}, pullRequestCITTL)
+}
+
+func (svc *StatusSvc) findCommitState(ctx context.Context, key string) (CommitState, error) {
+	state, err := svc.commits.Get(ctx, key)
+	if err != nil {
+		return CommitState{}, err
+	}
+	if state == nil {
+		return CommitState{FirstSeenAt: time.Now()}, nil
+	}
+	return *state, nil
+}
+
+func (svc *StatusSvc) buildCommitSummaryMessage(ctx context.Context, ref CommitRef, summary checkSummary, name template.Name, mentions string) (*model.Message, error) {
+	repository := ref.Repository.GetName()
+	data := template.CISummaryData{
+		Repository: template.Repository{
+			Name: repository,
+			URL:  ref.Repository.GetHTMLURL(),
+		},
+		SHA:          ref.SHA,
+		URL:          fmt.Sprintf("%s/commit/%s", ref.Repository.GetHTMLURL(), ref.SHA),
+		Result:       summary.Result,
+		Passed:       summary.Passed,
+		Failed:       len(summary.FailedChecks),
+		Pending:      summary.Pending,
+		FailedChecks: summary.FailedChecks,
+		Mentions:     mentions,
+	}
+	title, err := svc.commonSvc.Render(ctx, ref.InstallCtx, repository, name, data)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []model.MessageBlock{model.NewTextBlock(title)}
+	if len(summary.FailedChecks) == 0 {
+		return model.NewMessage(blocks...), nil
+	}
+
+	checkBlocks := make([]model.MessageBlock, 0, len(summary.FailedChecks))
+	for _, check := range summary.FailedChecks {
+		text, err := svc.commonSvc.Render(ctx, ref.InstallCtx, repository, template.CICheckResult, check)
+		if err != nil {
+			return nil, err
+		}
+		checkBlocks = append(checkBlocks, model.NewTextBlock(text))
+	}
+	blocks = append(blocks, model.NewBulletsBlock(checkBlocks))
+	return model.NewMessage(blocks...), nil
+}
+
+type checkSummary struct {
+	// Result 는 실패한 check 가 있으면 failure, 진행중인 check 가 있으면 pending, 아니면 success 입니다.
+	Result       string
+	Passed       int
+	Pending      int
+	FailedChecks []template.CICheck
+	signature    string
+}
+
+func summarizeChecks(checks []github.CommitCheck) checkSummary {
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+
+	var summary checkSummary
+	signatures := make([]string, 0, len(checks))
+	for _, check := range checks {
+		signatures = append(signatures, check.Name+"="+check.Result)
+		switch {
+		case check.Result == github.CommitCheckPending:
+			summary.Pending++
+		case IsFailedConclusion(check.Result) || check.Result == "error" || check.Result == "cancelled" || check.Result == "action_required":
+			summary.FailedChecks = append(summary.FailedChecks, template.CICheck{
+				Name:   check.Name,
+				URL:    check.URL,
+				Result: check.Result,
+			})
+		default:
+			summary.Passed++
+		}
+	}
+	summary.signature = strings.Join(signatures, ",")
+
+	switch {
+	case len(summary.FailedChecks) > 0:
+		summary.Result = "failure"
+	case summary.Pending > 0:
+		summary.Result = "pending"
+	default:
+		summary.Result = "success"
+	}
+	return summary
+}
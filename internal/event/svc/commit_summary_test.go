@@ -0,0 +1,63 @@
+package svc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+func TestSummarizeChecks(t *testing.T) {
+	checks := []github.CommitCheck{
+		{Name: "lint", Result: "success"},
+		{Name: "test", URL: "https://ci.example.com/test", Result: "failure"},
+		{Name: "build", Result: "skipped"},
+		{Name: "ci/jenkins", URL: "https://ci.example.com/jenkins", Result: "error"},
+		{Name: "e2e", Result: github.CommitCheckPending},
+	}
+
+	summary := summarizeChecks(checks)
+	assert.Equal(t, "failure", summary.Result)
+	assert.Equal(t, 2, summary.Passed)
+	assert.Equal(t, 1, summary.Pending)
+	assert.Equal(t, []template.CICheck{
+		{Name: "ci/jenkins", URL: "https://ci.example.com/jenkins", Result: "error"},
+		{Name: "test", URL: "https://ci.example.com/test", Result: "failure"},
+	}, summary.FailedChecks)
+}
+
+func TestSummarizeChecks_Result(t *testing.T) {
+	pending := summarizeChecks([]github.CommitCheck{
+		{Name: "lint", Result: "success"},
+		{Name: "test", Result: github.CommitCheckPending},
+	})
+	assert.Equal(t, "pending", pending.Result)
+
+	success := summarizeChecks([]github.CommitCheck{
+		{Name: "lint", Result: "success"},
+		{Name: "test", Result: "success"},
+	})
+	assert.Equal(t, "success", success.Result)
+}
+
+// 결과가 같으면 check 의 순서와 관계없이 같은 요약으로 보고 다시 보내지 않습니다.
+func TestSummarizeChecks_Signature(t *testing.T) {
+	a := summarizeChecks([]github.CommitCheck{{Name: "lint", Result: "success"}, {Name: "test", Result: "failure"}})
+	b := summarizeChecks([]github.CommitCheck{{Name: "test", Result: "failure"}, {Name: "lint", Result: "success"}})
+	c := summarizeChecks([]github.CommitCheck{{Name: "test", Result: "success"}, {Name: "lint", Result: "success"}})
+
+	assert.Equal(t, a.signature, b.signature)
+	assert.NotEqual(t, a.signature, c.signature)
+}
+
+func TestMergePullRequestNumbers(t *testing.T) {
+	refs := []CommitRef{
+		{PullRequestNumbers: []int{415}},
+		{},
+		{PullRequestNumbers: []int{412, 415}},
+	}
+	assert.Equal(t, []int{412, 415}, mergePullRequestNumbers(refs))
+	assert.Empty(t, mergePullRequestNumbers([]CommitRef{{}}))
+}
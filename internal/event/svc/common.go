@@ -2,30 +2,45 @@ package svc
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/channel-io/cht-app-github/internal/channel"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
-func NewCommonSvc(githubSvc github.Service, channelSvc channel.Service) *CommonSvc {
+func NewCommonSvc(logger logger.Logger, githubSvc github.Service, channelSvc channel.Service, repoConfigSvc *repoconfig.Service, router *routing.Router, templateEngine *template.Engine) *CommonSvc {
 	return &CommonSvc{
-		githubSvc:  githubSvc,
-		channelSvc: channelSvc,
+		logger:         logger,
+		githubSvc:      githubSvc,
+		channelSvc:     channelSvc,
+		repoConfigSvc:  repoConfigSvc,
+		router:         router,
+		templateEngine: templateEngine,
 	}
 }
 
+// CommonSvc 의 멘션과 markdown 변환은 family 의 message 를 보내는 channel 의 manager 와 team 을 사용합니다.
 type CommonSvc struct {
-	githubSvc  github.Service
-	channelSvc channel.Service
+	logger         logger.Logger
+	githubSvc      github.Service
+	channelSvc     channel.Service
+	repoConfigSvc  *repoconfig.Service
+	router         *routing.Router
+	templateEngine *template.Engine
 }
 
-func (u *CommonSvc) BuildManagerMentionTextByGithubUsername(ctx context.Context, installCtx github.InstallationContext, repository, username string) (string, error) {
-	group, err := u.githubSvc.FindGroup(ctx, installCtx, repository)
+func (u *CommonSvc) BuildManagerMentionTextByGithubUsername(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, username string) (string, error) {
+	channelID, err := u.router.FindChannelID(ctx, installCtx, repository, family)
 	if err != nil {
 		return "", err
 	}
-	manager, err := u.channelSvc.FindManagerByGitHubMentionUsername(ctx, group.ChannelID, username)
+	manager, err := u.channelSvc.FindManagerByGitHubMentionUsername(ctx, channelID, username)
 	if err != nil {
 		return "", err
 	}
@@ -35,12 +50,12 @@ func (u *CommonSvc) BuildManagerMentionTextByGithubUsername(ctx context.Context,
 	return username, nil
 }
 
-func (u *CommonSvc) FindManagerNameByGithubUsername(ctx context.Context, installCtx github.InstallationContext, repository, username string) (string, error) {
-	group, err := u.githubSvc.FindGroup(ctx, installCtx, repository)
+func (u *CommonSvc) FindManagerNameByGithubUsername(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, username string) (string, error) {
+	channelID, err := u.router.FindChannelID(ctx, installCtx, repository, family)
 	if err != nil {
 		return "", err
 	}
-	manager, err := u.channelSvc.FindManagerByGitHubMentionUsername(ctx, group.ChannelID, username)
+	manager, err := u.channelSvc.FindManagerByGitHubMentionUsername(ctx, channelID, username)
 	if err != nil {
 		return "", err
 	}
@@ -50,10 +65,91 @@ func (u *CommonSvc) FindManagerNameByGithubUsername(ctx context.Context, install
 	return username, nil
 }
 
-func (u *CommonSvc) IgnoreBot(ctx context.Context, orgLogin, repoName string) bool {
-	// TODO: config by custom property
-	if orgLogin == "channel-io" && repoName == "k8s" {
-		return true
+// BuildTeamMentionText 는 github team 과 연결한 channel 의 team 을 멘션합니다. 연결한 team 이 없으면 "@org/team-slug" 를 작성합니다.
+func (u *CommonSvc) BuildTeamMentionText(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, slug string) (string, error) {
+	if team := u.findTeam(ctx, installCtx, repository, family, slug); team != nil {
+		return model.Mention(model.MentionTypeTeam, team.ID, team.Name), nil
 	}
-	return false
+	return model.EscapedString(githubTeamName(installCtx, slug)), nil
+}
+
+func (u *CommonSvc) FindTeamNameByGithubTeam(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, slug string) (string, error) {
+	if team := u.findTeam(ctx, installCtx, repository, family, slug); team != nil {
+		return team.Name, nil
+	}
+	return model.EscapedString(githubTeamName(installCtx, slug)), nil
+}
+
+// findTeam 은 github team 과 연결한 team 을 찾습니다. 찾지 못하면 nil 을 반환합니다.
+// NOTE : team 은 멘션에만 사용하므로, 조회에 실패해도 message 를 보내지 못하지 않도록 실패를 기록하고 github team 이름으로 작성합니다.
+func (u *CommonSvc) findTeam(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, slug string) *model.Team {
+	team, err := u.lookupTeam(ctx, installCtx, repository, family, slug)
+	if err != nil {
+		u.logger.Warnw("failed to find team", "org", installCtx.OrgLogin, "repository", repository, "team", slug, "error", err)
+		return nil
+	}
+	return team
+}
+
+// lookupTeam 은 repository 의 team custom property 를 먼저 확인하고, 없으면 서버 설정에서 github team 과 연결한 team 을 찾습니다.
+func (u *CommonSvc) lookupTeam(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, slug string) (*model.Team, error) {
+	channelID, err := u.router.FindChannelID(ctx, installCtx, repository, family)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := u.githubSvc.FindTeamMapping(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if team, ok := mapping[strings.ToLower(slug)]; ok {
+		return u.channelSvc.FindTeam(ctx, channelID, team)
+	}
+	return u.channelSvc.FindTeamByGithubTeam(ctx, channelID, fmt.Sprintf("%s/%s", installCtx.OrgLogin, slug))
+}
+
+func githubTeamName(installCtx github.InstallationContext, slug string) string {
+	return fmt.Sprintf("@%s/%s", installCtx.OrgLogin, slug)
+}
+
+// ShouldNotify 는 repository 의 filter 설정에 따라 event 를 channel talk 으로 보낼지 결정합니다.
+func (u *CommonSvc) ShouldNotify(ctx context.Context, installCtx github.InstallationContext, repository string, subject repoconfig.FilterSubject) (bool, error) {
+	filter, err := u.repoConfigSvc.FindFilter(ctx, installCtx, repository)
+	if err != nil {
+		return false, err
+	}
+	return filter.Allows(subject), nil
+}
+
+// FindCIConfig 는 repository 에 적용할 ci 결과 설정을 반환합니다.
+func (u *CommonSvc) FindCIConfig(ctx context.Context, installCtx github.InstallationContext, repository string) (*repoconfig.CI, error) {
+	return u.repoConfigSvc.FindCI(ctx, installCtx, repository)
+}
+
+// FindSyncConfig 는 repository 에 적용할 동기화 설정을 반환합니다.
+func (u *CommonSvc) FindSyncConfig(ctx context.Context, installCtx github.InstallationContext, repository string) (*repoconfig.Sync, error) {
+	return u.repoConfigSvc.FindSync(ctx, installCtx, repository)
+}
+
+// FindPullRequestConfig 는 repository 에 적용할 pull request 요약 설정을 반환합니다.
+func (u *CommonSvc) FindPullRequestConfig(ctx context.Context, installCtx github.InstallationContext, repository string) (*repoconfig.PullRequest, error) {
+	return u.repoConfigSvc.FindPullRequest(ctx, installCtx, repository)
+}
+
+// FindReminderConfig 는 repository 에 적용할 review reminder 설정을 반환합니다.
+func (u *CommonSvc) FindReminderConfig(ctx context.Context, installCtx github.InstallationContext, repository string) (*repoconfig.Reminder, error) {
+	return u.repoConfigSvc.FindReminder(ctx, installCtx, repository)
+}
+
+// BuildMessageBlocksFromMarkdown 은 github markdown 을 repository 가 연결된 channel 의 manager 멘션으로 변환합니다.
+func (u *CommonSvc) BuildMessageBlocksFromMarkdown(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, markdown string) ([]model.MessageBlock, error) {
+	channelID, err := u.router.FindChannelID(ctx, installCtx, repository, family)
+	if err != nil {
+		return nil, err
+	}
+	return u.channelSvc.BuildMessageBlocksFromMarkdown(ctx, channelID, []byte(markdown))
+}
+
+// Render 는 repository 에 적용된 message template 으로 data 를 작성합니다.
+func (u *CommonSvc) Render(ctx context.Context, installCtx github.InstallationContext, repository string, name template.Name, data any) (string, error) {
+	return u.templateEngine.Render(ctx, installCtx, repository, name, data)
 }
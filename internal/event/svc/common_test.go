@@ -0,0 +1,48 @@
+package svc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+)
+
+func newTestCommonSvc(githubSvc *fakeGithubSvc) *CommonSvc {
+	conf := &config.Config{}
+	conf.Github.RepoConfig.Path = ".github/channeltalk.yml"
+	githubSvc.files = map[string][]byte{".github/channeltalk.yml": []byte("routes:\n  - channelId: \"1\"\n")}
+	repoConfigSvc := repoconfig.NewService(conf, githubSvc)
+	return NewCommonSvc(logger.NewBasicLogger(conf), githubSvc, &fakeChannelSvc{}, repoConfigSvc, routing.NewRouter(conf, githubSvc, repoConfigSvc), nil)
+}
+
+func TestCommonSvc_BuildTeamMentionText(t *testing.T) {
+	commonSvc := newTestCommonSvc(&fakeGithubSvc{teamMapping: map[string]string{"backend": "10"}})
+
+	mention, err := commonSvc.BuildTeamMentionText(context.TODO(), testInstallCtx(), "cht-app-github", routing.FamilyPullRequest, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Mention(model.MentionTypeTeam, "10", "Backend"), mention)
+
+	mention, err = commonSvc.BuildTeamMentionText(context.TODO(), testInstallCtx(), "cht-app-github", routing.FamilyPullRequest, "frontend")
+	assert.NoError(t, err)
+	assert.Equal(t, "@channel-io/frontend", mention)
+}
+
+// team 을 조회하지 못해도 github team 이름으로 작성합니다.
+func TestCommonSvc_BuildTeamMentionText_LookupError(t *testing.T) {
+	commonSvc := newTestCommonSvc(&fakeGithubSvc{teamMappingErr: errors.New("invalid teams custom property")})
+
+	mention, err := commonSvc.BuildTeamMentionText(context.TODO(), testInstallCtx(), "cht-app-github", routing.FamilyPullRequest, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, "@channel-io/backend", mention)
+
+	name, err := commonSvc.FindTeamNameByGithubTeam(context.TODO(), testInstallCtx(), "cht-app-github", routing.FamilyPullRequest, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, "@channel-io/backend", name)
+}
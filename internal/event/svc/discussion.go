@@ -0,0 +1,64 @@
+package svc
+
+import (
+	"context"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+)
+
+type DiscussionSvc struct {
+	channelSvc channel.Service
+	threadSvc  *ThreadSvc
+	router     *routing.Router
+}
+
+func NewDiscussionSvc(channelSvc channel.Service, threadSvc *ThreadSvc, router *routing.Router) *DiscussionSvc {
+	return &DiscussionSvc{
+		channelSvc: channelSvc,
+		threadSvc:  threadSvc,
+		router:     router,
+	}
+}
+
+// SyncDiscussionWithChannelTalk 는 discussion 의 thread 에 message 를 작성합니다. thread 가 없으면 message 로 thread 를 만듭니다.
+// NOTE : discussion 과 issue 는 repository 안에서 같은 번호 체계를 사용하므로 thread store 의 key 가 겹치지 않습니다.
+func (u *DiscussionSvc) SyncDiscussionWithChannelTalk(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	number int,
+	message *model.Message,
+	opts ...SyncOption,
+) error {
+	c := syncConfig{
+		target: routing.Target{Family: routing.FamilyDiscussion, Number: number},
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	// NOTE : discussion comment 는 REST API 로 조회할 수 없어 issue 처럼 desk url comment 로 thread 를 찾지 않습니다.
+	found, err := u.threadSvc.FindThread(ctx, installCtx, repository, number, false)
+	if err != nil {
+		return err
+	}
+
+	if found == nil && c.stopWithoutRootMessage {
+		return nil
+	}
+
+	if found != nil {
+		return u.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, c.broadcast)
+	}
+
+	destination, err := u.router.Route(ctx, installCtx, repository, c.target)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.threadSvc.writeRootMessage(ctx, u.channelSvc, installCtx, repository, number, destination.Group, message)
+	return err
+}
@@ -2,26 +2,47 @@ package svc
 
 import (
 	"context"
+	"strings"
+
+	libgithub "github.com/google/go-github/v60/github"
 
 	"github.com/channel-io/cht-app-github/internal/channel"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
 )
 
-func NewIssueSvc(githubSvc github.Service, channelSvc channel.Service) *IssueSvc {
+// chatIssueMarker 는 팀챗 message 로 만든 github issue 의 본문에 남기는 표시입니다.
+// issue 를 만들면서 thread 에 이미 작성하므로 IssuesEventOpened 가 다시 보내지 않도록 합니다.
+const chatIssueMarker = "<!-- channel-talk-issue -->"
+
+// MarkChatIssue 는 팀챗 message 로 만드는 issue 의 본문에 표시를 남깁니다.
+func MarkChatIssue(body string) string {
+	return body + "\n" + chatIssueMarker
+}
+
+// IsChatIssue 는 팀챗 message 로 만든 issue 인지 확인합니다.
+// NOTE : 사용자가 본문에 표시를 직접 적어 알림을 피할 수 없도록 app(bot) 이 만든 issue 만 확인합니다.
+func IsChatIssue(sender *libgithub.User, body string) bool {
+	return sender.GetType() == "Bot" && strings.Contains(body, chatIssueMarker)
+}
+
+func NewIssueSvc(githubSvc github.Service, channelSvc channel.Service, threadSvc *ThreadSvc, router *routing.Router) *IssueSvc {
 	return &IssueSvc{
 		githubSvc:  githubSvc,
 		channelSvc: channelSvc,
+		threadSvc:  threadSvc,
+		router:     router,
 	}
 }
 
 type IssueSvc struct {
 	githubSvc  github.Service
 	channelSvc channel.Service
+	threadSvc  *ThreadSvc
+	router     *routing.Router
 }
 
-const defaultTryCountFindingComment = 3
-
 func (u *IssueSvc) SyncIssueWithChannelTalk(
 	ctx context.Context,
 	installCtx github.InstallationContext,
@@ -30,35 +51,34 @@ func (u *IssueSvc) SyncIssueWithChannelTalk(
 	message *model.Message,
 	opts ...SyncOption,
 ) (err error) {
-	group, err := u.githubSvc.FindGroup(ctx, installCtx, repository)
-	if err != nil {
-		return err
+	c := syncConfig{
+		target: routing.Target{Family: routing.FamilyIssue, Number: issueNumber},
 	}
-
-	c := syncConfig{}
 	for _, opt := range opts {
 		opt(&c)
 	}
 
-	tryFindingCommentCount := defaultTryCountFindingComment
-	if c.noRetry {
-		tryFindingCommentCount = 0
-	}
-
-	rootMessageID, err := u.githubSvc.FindRootMessageID(ctx, installCtx, repository, issueNumber, tryFindingCommentCount)
+	found, err := u.threadSvc.FindThread(ctx, installCtx, repository, issueNumber, !c.noRetry)
 	if err != nil {
 		return err
 	}
 
-	if rootMessageID == nil && c.stopWithoutRootMessage {
+	if found == nil && c.stopWithoutRootMessage {
 		return nil
 	}
 
-	if rootMessageID != nil {
-		return u.channelSvc.WriteThreadMessage(ctx, group, *rootMessageID, message, c.broadcast)
+	if found != nil {
+		return u.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, c.broadcast)
+	}
+
+	// NOTE : routing 은 root message 를 작성할 때만 적용됩니다. 이후 message 는 thread 가 있는 group 으로 보냅니다.
+	destination, err := u.router.Route(ctx, installCtx, repository, c.target)
+	if err != nil {
+		return err
 	}
+	group := destination.Group
 
-	messageID, err := u.channelSvc.WriteMessage(ctx, group, message)
+	messageID, err := u.threadSvc.writeRootMessage(ctx, u.channelSvc, installCtx, repository, issueNumber, group, message)
 	if err != nil {
 		return err
 	}
@@ -71,6 +91,7 @@ type syncConfig struct {
 	broadcast              bool
 	noRetry                bool
 	stopWithoutRootMessage bool
+	target                 routing.Target
 }
 
 type SyncOption func(*syncConfig)
@@ -93,6 +114,13 @@ func StopWithoutRootMessage() SyncOption {
 	}
 }
 
+// WithRouteTarget 은 root message 를 보낼 group 을 찾을 때 사용할 정보를 지정합니다.
+func WithRouteTarget(target routing.Target) SyncOption {
+	return func(config *syncConfig) {
+		config.target = target
+	}
+}
+
 func (u *IssueSvc) AddAssigneeToIssue(
 	ctx context.Context,
 	installCtx github.InstallationContext,
@@ -0,0 +1,58 @@
+package svc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+func TestIsChatIssue(t *testing.T) {
+	bot := &libgithub.User{Login: lo.ToPtr("channel-talk[bot]"), Type: lo.ToPtr("Bot")}
+	user := &libgithub.User{Login: lo.ToPtr("mallory"), Type: lo.ToPtr("User")}
+
+	assert.True(t, IsChatIssue(bot, MarkChatIssue("It is broken")))
+	assert.False(t, IsChatIssue(bot, "It is broken"))
+	// 사용자가 직접 표시를 적은 issue 는 알립니다.
+	assert.False(t, IsChatIssue(user, MarkChatIssue("It is broken")))
+}
+
+// 같은 issue 의 event 가 동시에 처리되어도 root message 는 한 번만 작성하고, 늦은 event 는 retry 될 때 thread 에 작성합니다.
+func TestIssueSvc_SyncIssueWithChannelTalk_Concurrent(t *testing.T) {
+	conf := &config.Config{}
+	githubSvc := &fakeGithubSvc{}
+	channelSvc := &fakeChannelSvc{}
+	router := routing.NewRouter(conf, githubSvc, repoconfig.NewService(conf, githubSvc))
+	issueSvc := NewIssueSvc(githubSvc, channelSvc, NewThreadSvc(githubSvc, thread.NewMemoryStore()), router)
+
+	sync412 := func() error {
+		return issueSvc.SyncIssueWithChannelTalk(context.TODO(), testInstallCtx(), "cht-app-github", 412, model.NewMessage(), WithoutTryFindingRootMessage())
+	}
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = sync412()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, channelSvc.rootMessages)
+	assert.Len(t, githubSvc.comments, 1)
+	assert.Len(t, lo.Filter(errs, func(err error, _ int) bool { return err != nil }), 1)
+
+	assert.NoError(t, sync412())
+	assert.Equal(t, 1, channelSvc.rootMessages)
+	assert.Equal(t, []string{"root-1"}, channelSvc.threadMessages)
+}
@@ -6,35 +6,38 @@ import (
 	"github.com/channel-io/cht-app-github/internal/channel"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
 )
 
 type ReleaseSvc struct {
 	githubSvc  github.Service
 	channelSvc channel.Service
+	router     *routing.Router
 }
 
-func NewReleaseSvc(githubSvc github.Service, channelSvc channel.Service) *ReleaseSvc {
+func NewReleaseSvc(githubSvc github.Service, channelSvc channel.Service, router *routing.Router) *ReleaseSvc {
 	return &ReleaseSvc{
 		githubSvc:  githubSvc,
 		channelSvc: channelSvc,
+		router:     router,
 	}
 }
 func (svc *ReleaseSvc) BuildMessageBlocksFromBody(ctx context.Context, installCtx github.InstallationContext, repository, body string) ([]model.MessageBlock, error) {
-	group, err := svc.githubSvc.FindReleaseGroup(ctx, installCtx, repository)
+	channelID, err := svc.router.FindChannelID(ctx, installCtx, repository, routing.FamilyRelease)
 	if err != nil {
 		return nil, err
 	}
 
-	return svc.channelSvc.BuildMessageBlocksFromMarkdown(ctx, group.ChannelID, []byte(body))
+	return svc.channelSvc.BuildMessageBlocksFromMarkdown(ctx, channelID, []byte(body))
 }
 
-func (svc *ReleaseSvc) SyncReleaseWithChannelTalk(ctx context.Context, installCtx github.InstallationContext, repository string, message *model.Message) error {
-	group, err := svc.githubSvc.FindReleaseGroup(ctx, installCtx, repository)
+func (svc *ReleaseSvc) SyncReleaseWithChannelTalk(ctx context.Context, installCtx github.InstallationContext, repository string, target routing.Target, message *model.Message) error {
+	destination, err := svc.router.Route(ctx, installCtx, repository, target)
 	if err != nil {
 		return err
 	}
 
-	_, err = svc.channelSvc.WriteMessage(ctx, group, message)
+	_, err = svc.channelSvc.WriteMessage(ctx, destination.Group, message)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,246 @@
+package svc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/reminder"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/scheduler"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+// ReminderSvc 는 review 를 요청한 뒤 review 가 없는 pull request 의 thread 에 reviewer 를 다시 멘션합니다.
+// 요청 시각은 reminder.Store 에 저장하고, interval 마다 repository 의 reminder 설정과 비교합니다.
+type ReminderSvc struct {
+	logger    logger.Logger
+	commonSvc *CommonSvc
+	issueSvc  *IssueSvc
+	store     reminder.Store
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewReminderSvc(
+	conf *config.Config,
+	logger logger.Logger,
+	commonSvc *CommonSvc,
+	issueSvc *IssueSvc,
+	store reminder.Store,
+) *ReminderSvc {
+	return &ReminderSvc{
+		logger:    logger,
+		commonSvc: commonSvc,
+		issueSvc:  issueSvc,
+		store:     store,
+		interval:  conf.Reminder.Interval,
+		now:       time.Now,
+	}
+}
+
+// Track 은 reviewers 에게 요청한 review 를 저장합니다. reminder 를 설정하지 않은 repository 는 저장하지 않습니다.
+// 이미 저장된 reviewer 에게 다시 요청하면 요청한 시각부터 다시 셉니다.
+func (u *ReminderSvc) Track(ctx context.Context, installCtx github.InstallationContext, repository string, pullRequest *libgithub.PullRequest, reviewers ...string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	conf, err := u.commonSvc.FindReminderConfig(ctx, installCtx, repository)
+	if err != nil || !conf.Enabled() {
+		return err
+	}
+
+	key := thread.NewKey(installCtx.OrgLogin, repository, pullRequest.GetNumber())
+	now := u.now()
+	for _, reviewer := range reviewers {
+		err := u.store.Save(ctx, reminder.Request{
+			PullRequest:    key,
+			Reviewer:       reviewer,
+			InstallationID: installCtx.InstallationId,
+			Title:          pullRequest.GetTitle(),
+			URL:            pullRequest.GetHTMLURL(),
+			RequestedAt:    now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Untrack 은 reviewer 가 review 를 작성했거나 요청이 취소된 pull request 의 reminder 를 멈춥니다.
+func (u *ReminderSvc) Untrack(ctx context.Context, installCtx github.InstallationContext, repository string, number int, reviewer string) error {
+	return u.store.Delete(ctx, thread.NewKey(installCtx.OrgLogin, repository, number), reviewer)
+}
+
+// UntrackPullRequest 는 닫혔거나 draft 로 바뀐 pull request 의 reminder 를 모두 멈춥니다.
+func (u *ReminderSvc) UntrackPullRequest(ctx context.Context, installCtx github.InstallationContext, repository string, number int) error {
+	return u.store.DeletePullRequest(ctx, thread.NewKey(installCtx.OrgLogin, repository, number))
+}
+
+// Job 은 interval 마다 Remind 를 실행합니다.
+func (u *ReminderSvc) Job() scheduler.Job {
+	return scheduler.Job{
+		Name:     "review-reminder",
+		Schedule: scheduler.Every(u.interval),
+		Run:      u.Remind,
+	}
+}
+
+// Remind 는 저장된 요청 중 reminder 혹은 escalation 시간이 지난 요청을 pull request 별로 모아서 보냅니다.
+// pull request 하나를 보내지 못하더라도 나머지 pull request 는 계속 보냅니다.
+func (u *ReminderSvc) Remind(ctx context.Context) error {
+	requests, err := u.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pullRequest := range lo.PartitionBy(requests, func(request reminder.Request) thread.Key { return request.PullRequest }) {
+		if err := u.remindPullRequest(ctx, pullRequest); err != nil {
+			u.logger.Warnw("failed to send review reminder", "pullRequest", pullRequest[0].PullRequest.String(), "err", err)
+		}
+	}
+	return nil
+}
+
+// remindPullRequest 는 한 pull request 의 요청들을 확인합니다. 보낸 reminder 는 thread 가 없어서 작성하지 못했더라도 다시 보내지 않습니다.
+func (u *ReminderSvc) remindPullRequest(ctx context.Context, requests []reminder.Request) error {
+	key := requests[0].PullRequest
+	installCtx := github.NewInstallationContext(requests[0].InstallationID, key.Org)
+	conf, err := u.commonSvc.FindReminderConfig(ctx, installCtx, key.Repository)
+	if err != nil {
+		return err
+	}
+	// NOTE : reminder 설정을 지운 repository 의 요청은 더 이상 확인하지 않습니다.
+	if !conf.Enabled() {
+		return u.store.DeletePullRequest(ctx, key)
+	}
+
+	now := u.now()
+	reminders, escalations := dueRequests(conf, requests, now)
+	if len(reminders) > 0 {
+		reviewers, err := u.buildReviewers(ctx, installCtx, key.Repository, reminders, u.commonSvc.BuildManagerMentionTextByGithubUsername)
+		if err != nil {
+			return err
+		}
+		err = u.send(ctx, installCtx, template.ReviewReminder, reminders, template.ReviewReminderData{
+			Reviewers: reviewers,
+			Waiting:   template.FormatAge(now.Sub(oldestRequest(reminders))),
+		})
+		if err != nil {
+			return err
+		}
+		if err := u.saveRequests(ctx, reminders); err != nil {
+			return err
+		}
+	}
+	if len(escalations) > 0 {
+		reviewers, err := u.buildReviewers(ctx, installCtx, key.Repository, escalations, u.commonSvc.FindManagerNameByGithubUsername)
+		if err != nil {
+			return err
+		}
+		leads := make([]string, 0, len(conf.EscalateTo))
+		for _, lead := range conf.EscalateTo {
+			mention, err := u.commonSvc.BuildManagerMentionTextByGithubUsername(ctx, installCtx, key.Repository, routing.FamilyPullRequest, lead)
+			if err != nil {
+				return err
+			}
+			leads = append(leads, mention)
+		}
+		var opts []SyncOption
+		if len(leads) == 0 {
+			opts = append(opts, WithBroadCasting())
+		}
+		err = u.send(ctx, installCtx, template.ReviewEscalated, escalations, template.ReviewReminderData{
+			Reviewers: reviewers,
+			Waiting:   template.FormatAge(now.Sub(oldestRequest(escalations))),
+			Leads:     strings.Join(leads, " "),
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		if err := u.saveRequests(ctx, escalations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveRequests 는 보낸 요청을 바로 저장합니다. 다음 message 를 보내지 못해도 보낸 reminder 를 다시 보내지 않습니다.
+func (u *ReminderSvc) saveRequests(ctx context.Context, requests []reminder.Request) error {
+	for _, request := range requests {
+		if err := u.store.Save(ctx, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *ReminderSvc) buildReviewers(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	requests []reminder.Request,
+	build func(ctx context.Context, installCtx github.InstallationContext, repository string, family routing.Family, username string) (string, error),
+) (string, error) {
+	reviewers := make([]string, 0, len(requests))
+	for _, request := range requests {
+		reviewer, err := build(ctx, installCtx, repository, routing.FamilyPullRequest, request.Reviewer)
+		if err != nil {
+			return "", err
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	return strings.Join(reviewers, " "), nil
+}
+
+// send 는 pull request 의 thread 가 있는 경우에만 작성합니다.
+func (u *ReminderSvc) send(ctx context.Context, installCtx github.InstallationContext, name template.Name, requests []reminder.Request, data template.ReviewReminderData, opts ...SyncOption) error {
+	key := requests[0].PullRequest
+	data.Repository = template.Repository{Name: key.Repository}
+	data.PullRequest = template.PullRequest{
+		Number: key.Number,
+		Title:  requests[0].Title,
+		URL:    requests[0].URL,
+	}
+	text, err := u.commonSvc.Render(ctx, installCtx, key.Repository, name, data)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, StopWithoutRootMessage(), WithoutTryFindingRootMessage())
+	return u.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, key.Repository, key.Number, model.NewMessage(model.NewTextBlock(text)), opts...)
+}
+
+// dueRequests 는 reminder 와 escalation 을 보낼 요청을 보낸 것으로 기록해서 반환합니다.
+// escalation 을 보내는 요청에는 reminder 를 함께 보내지 않습니다. 여러 reminder 시간이 지났더라도 한 번만 보냅니다.
+func dueRequests(conf *repoconfig.Reminder, requests []reminder.Request, now time.Time) (reminders, escalations []reminder.Request) {
+	for _, request := range requests {
+		elapsed := now.Sub(request.RequestedAt)
+		reminded := lo.CountBy(conf.After, func(after time.Duration) bool { return elapsed >= after })
+
+		switch {
+		case conf.EscalateAfter != nil && elapsed >= *conf.EscalateAfter && !request.Escalated:
+			request.Escalated = true
+			request.Reminded = max(request.Reminded, reminded)
+			escalations = append(escalations, request)
+		case reminded > request.Reminded:
+			request.Reminded = reminded
+			reminders = append(reminders, request)
+		}
+	}
+	return reminders, escalations
+}
+
+func oldestRequest(requests []reminder.Request) time.Time {
+	return lo.MinBy(requests, func(a, b reminder.Request) bool {
+		return a.RequestedAt.Before(b.RequestedAt)
+	}).RequestedAt
+}
@@ -0,0 +1,87 @@
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/reminder"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+func TestDueRequests(t *testing.T) {
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+	conf := &repoconfig.Reminder{
+		After:         []time.Duration{24 * time.Hour, 72 * time.Hour},
+		EscalateAfter: lo.ToPtr(120 * time.Hour),
+	}
+	requests := []reminder.Request{
+		{Reviewer: "fresh", RequestedAt: now.Add(-time.Hour)},
+		{Reviewer: "first", RequestedAt: now.Add(-25 * time.Hour)},
+		{Reviewer: "reminded", RequestedAt: now.Add(-30 * time.Hour), Reminded: 1},
+		// NOTE : 두 reminder 시간이 모두 지났더라도 한 번만 보냄
+		{Reviewer: "late", RequestedAt: now.Add(-100 * time.Hour)},
+		{Reviewer: "escalate", RequestedAt: now.Add(-121 * time.Hour), Reminded: 2},
+		{Reviewer: "escalated", RequestedAt: now.Add(-200 * time.Hour), Reminded: 2, Escalated: true},
+	}
+
+	reminders, escalations := dueRequests(conf, requests, now)
+	assert.Equal(t, []string{"first", "late"}, lo.Map(reminders, func(r reminder.Request, _ int) string { return r.Reviewer }))
+	assert.Equal(t, []int{1, 2}, lo.Map(reminders, func(r reminder.Request, _ int) int { return r.Reminded }))
+	assert.Len(t, escalations, 1)
+	assert.Equal(t, "escalate", escalations[0].Reviewer)
+	assert.True(t, escalations[0].Escalated)
+
+	reminders, escalations = dueRequests(&repoconfig.Reminder{After: []time.Duration{24 * time.Hour}}, requests[5:], now)
+	assert.Empty(t, reminders)
+	assert.Empty(t, escalations)
+}
+
+// escalation 을 보내지 못해도 먼저 보낸 reminder 는 저장해서 다음 interval 에 다시 보내지 않습니다.
+func TestReminderSvc_Remind_SaveSentReminders(t *testing.T) {
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+	conf := &config.Config{}
+	conf.Github.RepoConfig.Path = ".github/channeltalk.yml"
+	githubSvc := &fakeGithubSvc{
+		files: map[string][]byte{".github/channeltalk.yml": []byte("routes:\n  - channelId: \"1\"\nreminder:\n  after: [24h]\n  escalateAfter: 120h\n")},
+	}
+	channelSvc := &fakeChannelSvc{broadcastErr: errors.New("timeout")}
+	threadStore := thread.NewMemoryStore()
+	key := thread.NewKey("channel-io", "cht-app-github", 412)
+	assert.NoError(t, threadStore.Save(context.TODO(), key, thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: "root-412"}))
+
+	repoConfigSvc := repoconfig.NewService(conf, githubSvc)
+	router := routing.NewRouter(conf, githubSvc, repoConfigSvc)
+	commonSvc := NewCommonSvc(logger.NewBasicLogger(conf), githubSvc, channelSvc, repoConfigSvc, router, template.NewEngine(conf, router, repoConfigSvc, logger.NewBasicLogger(conf)))
+	issueSvc := NewIssueSvc(githubSvc, channelSvc, NewThreadSvc(githubSvc, threadStore), router)
+	store := reminder.NewMemoryStore()
+	reminderSvc := NewReminderSvc(conf, logger.NewBasicLogger(conf), commonSvc, issueSvc, store)
+	reminderSvc.now = func() time.Time { return now }
+
+	for reviewer, requestedAt := range map[string]time.Time{"dylan": now.Add(-25 * time.Hour), "lento": now.Add(-121 * time.Hour)} {
+		assert.NoError(t, store.Save(context.TODO(), reminder.Request{PullRequest: key, Reviewer: reviewer, InstallationID: 1, RequestedAt: requestedAt}))
+	}
+
+	assert.NoError(t, reminderSvc.Remind(context.TODO()))
+	assert.Equal(t, []string{"root-412"}, channelSvc.threadMessages)
+
+	requests, err := store.List(context.TODO())
+	assert.NoError(t, err)
+	reminded := lo.SliceToMap(requests, func(r reminder.Request) (string, reminder.Request) { return r.Reviewer, r })
+	assert.Equal(t, 1, reminded["dylan"].Reminded)
+	assert.False(t, reminded["lento"].Escalated)
+
+	// escalation 만 다시 보냅니다.
+	channelSvc.broadcastErr = nil
+	assert.NoError(t, reminderSvc.Remind(context.TODO()))
+	assert.Equal(t, []string{"root-412", "root-412"}, channelSvc.threadMessages)
+}
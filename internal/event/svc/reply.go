@@ -0,0 +1,139 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/thread"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+// MirroredMessageCache 는 github comment 로 작성한 팀챗 message id 를 기록합니다.
+type MirroredMessageCache interface {
+	cache.AtomicCache[time.Time]
+}
+
+func NewReplySvc(
+	conf *config.Config,
+	logger logger.Logger,
+	githubSvc github.Service,
+	channelSvc channel.Service,
+	commonSvc *CommonSvc,
+	threadStore thread.Store,
+	mirrored MirroredMessageCache,
+) *ReplySvc {
+	return &ReplySvc{
+		logger:      logger,
+		githubSvc:   githubSvc,
+		channelSvc:  channelSvc,
+		commonSvc:   commonSvc,
+		threadStore: threadStore,
+		mirrored:    mirrored,
+		mirroredTTL: conf.Event.Dedup.TTL,
+	}
+}
+
+// ReplySvc 는 issue, pull request thread 에 manager 가 작성한 답글을 github comment 로 작성합니다.
+type ReplySvc struct {
+	logger      logger.Logger
+	githubSvc   github.Service
+	channelSvc  channel.Service
+	commonSvc   *CommonSvc
+	threadStore thread.Store
+	mirrored    MirroredMessageCache
+	mirroredTTL time.Duration
+}
+
+// MirrorReply 는 sync.replies 가 켜진 repository 의 thread 답글만 작성합니다. 그 외의 message 는 무시합니다.
+func (u *ReplySvc) MirrorReply(ctx context.Context, message model.ChatMessage) error {
+	if !message.IsManagerThreadReply() {
+		return nil
+	}
+
+	key, err := u.threadStore.FindKey(ctx, thread.Thread{
+		ChannelID:     message.ChannelID,
+		GroupID:       message.ChatID,
+		RootMessageID: message.RootMessageID,
+	})
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+
+	installationID, err := u.githubSvc.FindAppInstallationID(ctx, key.Org)
+	if err != nil {
+		return err
+	}
+	if installationID == nil {
+		u.logger.Warnw("github app is not installed", "org", key.Org, "messageID", message.ID)
+		return nil
+	}
+	installCtx := github.NewInstallationContext(*installationID, key.Org)
+
+	syncConf, err := u.commonSvc.FindSyncConfig(ctx, installCtx, key.Repository)
+	if err != nil {
+		return err
+	}
+	if !lo.FromPtr(syncConf.Replies) {
+		return nil
+	}
+
+	body, err := u.buildMarkdown(ctx, message)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil
+	}
+
+	manager, err := u.channelSvc.FetchManagerByManagerID(ctx, message.ChannelID, message.PersonID)
+	if err != nil {
+		return err
+	}
+	author := manager.Name
+	if manager.GithubUsername != nil {
+		author = "@" + *manager.GithubUsername
+	}
+
+	comment := fmt.Sprintf("%s\n\n_by %s via [Channel Talk](%s)_",
+		strings.TrimSpace(body),
+		author,
+		u.channelSvc.BuildTeamChatURL(model.Group{ChannelID: message.ChannelID, ID: message.ChatID}, message.RootMessageID),
+	)
+
+	// NOTE : webhook 이 재전송되거나 동시에 처리되어도 comment 가 중복 작성되지 않도록 message id 를 먼저 선점합니다.
+	// comment 작성에 실패하면 다시 시도할 수 있도록 선점을 해제합니다.
+	claimed, err := u.mirrored.SetIfAbsent(ctx, message.ID, time.Now(), u.mirroredTTL)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+	if err := u.githubSvc.CreateComment(ctx, installCtx, key.Repository, key.Number, comment); err != nil {
+		if deleteErr := u.mirrored.Delete(ctx, message.ID); deleteErr != nil {
+			u.logger.Errorw("failed to release mirrored message", "messageID", message.ID, "error", deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// buildMarkdown 은 block 을 github markdown 으로 변환합니다. block 이 없으면 plain text 를 사용합니다.
+func (u *ReplySvc) buildMarkdown(ctx context.Context, message model.ChatMessage) (string, error) {
+	if len(message.Blocks) == 0 {
+		return message.PlainText, nil
+	}
+	return u.channelSvc.BuildGithubMarkdown(ctx, message.ChannelID, message.Blocks)
+}
@@ -0,0 +1,72 @@
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/thread"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+func newTestReplySvc(t *testing.T, githubSvc *fakeGithubSvc) *ReplySvc {
+	conf := &config.Config{}
+	conf.Github.RepoConfig.Path = ".github/channeltalk.yml"
+	conf.Event.Dedup.TTL = time.Hour
+	githubSvc.files = map[string][]byte{".github/channeltalk.yml": []byte("sync:\n  replies: true\n")}
+
+	threadStore := thread.NewMemoryStore()
+	err := threadStore.Save(context.TODO(), thread.NewKey("channel-io", "cht-app-github", 412), thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: "root-412"})
+	assert.NoError(t, err)
+
+	channelSvc := &fakeChannelSvc{}
+	repoConfigSvc := repoconfig.NewService(conf, githubSvc)
+	commonSvc := NewCommonSvc(logger.NewBasicLogger(conf), githubSvc, channelSvc, repoConfigSvc, routing.NewRouter(conf, githubSvc, repoConfigSvc), nil)
+	return NewReplySvc(conf, logger.NewBasicLogger(conf), githubSvc, channelSvc, commonSvc, threadStore, cache.NewLocalCache[time.Time]())
+}
+
+func testThreadReply(id string) model.ChatMessage {
+	return model.ChatMessage{
+		ID:            id,
+		ChannelID:     "1",
+		ChatType:      model.ChatTypeGroup,
+		ChatID:        "10",
+		PersonType:    model.PersonTypeManager,
+		PersonID:      "2",
+		RootMessageID: "root-412",
+		PlainText:     "LGTM",
+	}
+}
+
+// 같은 message 가 여러 번 와도 comment 는 한 번만 작성합니다.
+func TestReplySvc_MirrorReply_Once(t *testing.T) {
+	githubSvc := &fakeGithubSvc{}
+	replySvc := newTestReplySvc(t, githubSvc)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, replySvc.MirrorReply(context.TODO(), testThreadReply("message-1")))
+	}
+	assert.Len(t, githubSvc.comments, 1)
+	assert.Contains(t, githubSvc.comments[0], "LGTM\n\n_by @dylan via [Channel Talk]")
+}
+
+// comment 작성에 실패하면 다시 시도할 때 작성합니다.
+func TestReplySvc_MirrorReply_RetryAfterFailure(t *testing.T) {
+	githubSvc := &fakeGithubSvc{commentErr: assert.AnError}
+	replySvc := newTestReplySvc(t, githubSvc)
+
+	assert.ErrorIs(t, replySvc.MirrorReply(context.TODO(), testThreadReply("message-1")), assert.AnError)
+	assert.Empty(t, githubSvc.comments)
+
+	githubSvc.commentErr = nil
+	assert.NoError(t, replySvc.MirrorReply(context.TODO(), testThreadReply("message-1")))
+	assert.NoError(t, replySvc.MirrorReply(context.TODO(), testThreadReply("message-1")))
+	assert.Len(t, githubSvc.comments, 1)
+}
@@ -2,60 +2,180 @@ package svc
 
 import (
 	"context"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
 
 	"github.com/channel-io/cht-app-github/internal/channel"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
 	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/routing"
 )
 
 type StatusSvc struct {
 	githubSvc  github.Service
 	channelSvc channel.Service
+	threadSvc  *ThreadSvc
+	router     *routing.Router
+	commonSvc  *CommonSvc
+
+	settleTimeout time.Duration
+	commits       CommitStateCache
+	batcher       *queue.Batcher[CommitRef]
+	pullRequestCI PullRequestCICache
 }
 
-func NewStatusSvc(githubSvc github.Service, channelSvc channel.Service) *StatusSvc {
-	return &StatusSvc{
-		githubSvc:  githubSvc,
-		channelSvc: channelSvc,
+func NewStatusSvc(
+	conf *config.Config,
+	githubSvc github.Service,
+	channelSvc channel.Service,
+	threadSvc *ThreadSvc,
+	router *routing.Router,
+	commonSvc *CommonSvc,
+	pullRequestCI PullRequestCICache,
+	commits CommitStateCache,
+	batches *queue.Batches,
+) *StatusSvc {
+	svc := &StatusSvc{
+		githubSvc:     githubSvc,
+		channelSvc:    channelSvc,
+		threadSvc:     threadSvc,
+		router:        router,
+		commonSvc:     commonSvc,
+		settleTimeout: conf.Event.CI.SettleTimeout,
+		commits:       commits,
+		pullRequestCI: pullRequestCI,
 	}
+	svc.batcher = queue.NewBatcher(batches, "commit_summaries", svc.flushCommitSummaries)
+	return svc
 }
 
+// SyncCommitStatusWithChannelTalk 는 merge 된 pull request 들의 thread 에 commit 의 ci 결과를 작성합니다. thread 가 없는 pull request 는 건너뜁니다.
 func (svc *StatusSvc) SyncCommitStatusWithChannelTalk(
 	ctx context.Context,
 	installCtx github.InstallationContext,
 	repository string,
-	commitSHA string,
+	pullRequests []*libgithub.PullRequest,
+	target routing.Target,
 	message *model.Message,
 ) (err error) {
-	// NOTE : filter closed 를 하는 이유는, 해당 pr이 merge 된 이후에만 status 를 메시지로 작성하기 위함입니다.
-	pullRequests, err := svc.githubSvc.ListPullRequestNumberByCommitSHA(
-		ctx,
-		installCtx,
-		repository,
-		commitSHA,
-		github.WithClosedPullRequestFilter(),
-		github.WithNotDraftPullRequestFilter(),
-		github.WithMergedPullRequestFilter(),
-	)
-	if err != nil {
-		return err
-	}
 	if len(pullRequests) == 0 {
 		return nil
 	}
 
-	rootMessageID, err := svc.githubSvc.FindRootMessageID(ctx, installCtx, repository, pullRequests[0].GetNumber(), defaultTryCountFindingComment)
+	pullRequest := pullRequests[0]
+	target.Number = pullRequest.GetNumber()
+	target.BaseBranch = pullRequest.GetBase().GetRef()
+	target.Labels = append(target.Labels, labelNames(pullRequest.Labels)...)
+
+	// NOTE : ci 결과를 보낼 group 이 지정된 경우 pull request thread 대신 해당 group 에 작성합니다.
+	destination, err := svc.router.Route(ctx, installCtx, repository, target)
 	if err != nil {
 		return err
 	}
+	if destination.Explicit {
+		_, err = svc.channelSvc.WriteMessage(ctx, destination.Group, message)
+		return err
+	}
+
+	for _, pullRequest := range pullRequests {
+		found, err := svc.threadSvc.FindThread(ctx, installCtx, repository, pullRequest.GetNumber(), true)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			continue
+		}
+		if err := svc.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncWorkflowWithChannelTalk 는 workflow run 이나 deployment 의 결과를 commit 에 연결된 모든 pull request 의 thread 에 작성합니다.
+// pullRequestNumbers 가 비어있으면 commit sha 로 pull request 를 조회합니다. thread 가 없는 pull request 는 건너뜁니다.
+func (svc *StatusSvc) SyncWorkflowWithChannelTalk(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	commitSHA string,
+	pullRequestNumbers []int,
+	target routing.Target,
+	message *model.Message,
+) error {
+	if len(pullRequestNumbers) == 0 {
+		pullRequests, err := svc.githubSvc.ListPullRequestNumberByCommitSHA(ctx, installCtx, repository, commitSHA, github.WithNotDraftPullRequestFilter())
+		if err != nil {
+			return err
+		}
+		for _, pullRequest := range pullRequests {
+			pullRequestNumbers = append(pullRequestNumbers, pullRequest.GetNumber())
+		}
+		if len(pullRequests) > 0 && target.BaseBranch == "" {
+			target.BaseBranch = pullRequests[0].GetBase().GetRef()
+			target.Labels = labelNames(pullRequests[0].Labels)
+		}
+	}
+	if len(pullRequestNumbers) > 0 {
+		target.Number = pullRequestNumbers[0]
+	}
 
-	group, err := svc.githubSvc.FindGroup(ctx, installCtx, repository)
+	// NOTE : deploy group 등 보낼 group 이 지정된 경우 pull request thread 대신 해당 group 에 작성합니다.
+	destination, err := svc.router.Route(ctx, installCtx, repository, target)
 	if err != nil {
 		return err
 	}
+	if destination.Explicit {
+		_, err = svc.channelSvc.WriteMessage(ctx, destination.Group, message)
+		return err
+	}
 
-	if rootMessageID != nil {
-		return svc.channelSvc.WriteThreadMessage(ctx, group, *rootMessageID, message, false)
+	for _, number := range pullRequestNumbers {
+		found, err := svc.threadSvc.FindThread(ctx, installCtx, repository, number, true)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			continue
+		}
+		if err := svc.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, false); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// ListFailedWorkflowJobs 는 workflow run 의 마지막 시도에서 실패한 job 들을 조회합니다.
+func (svc *StatusSvc) ListFailedWorkflowJobs(ctx context.Context, installCtx github.InstallationContext, repository string, runID int64) ([]*libgithub.WorkflowJob, error) {
+	jobs, err := svc.githubSvc.ListWorkflowJobs(ctx, installCtx, repository, runID)
+	if err != nil {
+		return nil, err
+	}
+	var failed []*libgithub.WorkflowJob
+	for _, job := range jobs {
+		if IsFailedConclusion(job.GetConclusion()) {
+			failed = append(failed, job)
+		}
+	}
+	return failed, nil
+}
+
+// IsFailedConclusion 은 check run, workflow job 의 conclusion 이 실패인지 확인합니다.
+func IsFailedConclusion(conclusion string) bool {
+	switch conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return true
+	}
+	return false
+}
+
+func labelNames(labels []*libgithub.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
@@ -0,0 +1,248 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+const testCommitSHA = "8a3f0d6c2b1e4f5a9c7d0e1b2a3c4d5e6f708192"
+
+type fakeGithubSvc struct {
+	github.Service
+
+	mu           sync.Mutex
+	pullRequests map[int]*libgithub.PullRequest
+	// commitPullRequests 는 commit sha 로 조회되는 pull request 입니다.
+	commitPullRequests []*libgithub.PullRequest
+	commitLookups      int
+	// files 는 path 별 repository file 입니다.
+	files      map[string][]byte
+	comments   []string
+	commentErr error
+	// teamMapping 과 teamMappingErr 는 repository 의 team custom property 입니다.
+	teamMapping    map[string]string
+	teamMappingErr error
+}
+
+func (s *fakeGithubSvc) FindTeamMapping(context.Context, github.InstallationContext, string) (map[string]string, error) {
+	return s.teamMapping, s.teamMappingErr
+}
+
+func (s *fakeGithubSvc) ListPullRequestNumberByCommitSHA(_ context.Context, _ github.InstallationContext, _, _ string, predicates ...github.FilterPullRequestPredicate) ([]*libgithub.PullRequest, error) {
+	s.commitLookups++
+	return lo.Filter(s.commitPullRequests, func(pr *libgithub.PullRequest, _ int) bool {
+		for _, predicate := range predicates {
+			if !predicate(pr) {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+func (s *fakeGithubSvc) FetchPullRequest(_ context.Context, _ github.InstallationContext, _ string, number int) (*libgithub.PullRequest, error) {
+	return s.pullRequests[number], nil
+}
+
+func (s *fakeGithubSvc) FindRepositoryFile(_ context.Context, _ github.InstallationContext, _, path string) ([]byte, error) {
+	return s.files[path], nil
+}
+
+func (s *fakeGithubSvc) FindAppInstallationID(context.Context, string) (*int64, error) {
+	return lo.ToPtr(int64(1)), nil
+}
+
+func (s *fakeGithubSvc) CreateComment(_ context.Context, _ github.InstallationContext, _ string, _ int, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.commentErr != nil {
+		return s.commentErr
+	}
+	s.comments = append(s.comments, body)
+	return nil
+}
+
+func (s *fakeGithubSvc) FindCustomProperties(context.Context, github.InstallationContext, string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (s *fakeGithubSvc) FindGroup(context.Context, github.InstallationContext, string) (model.Group, error) {
+	return model.Group{ChannelID: "1", ID: "10"}, nil
+}
+
+func (s *fakeGithubSvc) FindRootMessageID(context.Context, github.InstallationContext, string, int) (*string, error) {
+	return nil, nil
+}
+
+type fakeChannelSvc struct {
+	channel.Service
+
+	mu sync.Mutex
+	// rootMessages 는 작성한 root message 의 수입니다.
+	rootMessages int
+	// threadMessages 는 thread 에 작성한 root message id 입니다.
+	threadMessages []string
+	// broadcastErr 는 broadcast 하는 thread message 를 작성할 때 반환합니다.
+	broadcastErr error
+}
+
+func (s *fakeChannelSvc) WriteMessage(context.Context, model.Group, *model.Message) (string, error) {
+	// NOTE : 동시에 온 event 가 모두 thread 를 찾지 못하도록 root message 작성에 시간이 걸리게 합니다.
+	time.Sleep(10 * time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rootMessages++
+	return fmt.Sprintf("root-%d", s.rootMessages), nil
+}
+
+func (s *fakeChannelSvc) WriteThreadMessage(_ context.Context, _ model.Group, rootMessageID string, _ *model.Message, broadcast bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if broadcast && s.broadcastErr != nil {
+		return s.broadcastErr
+	}
+	s.threadMessages = append(s.threadMessages, rootMessageID)
+	return nil
+}
+
+func (s *fakeChannelSvc) FindTeam(_ context.Context, _, idOrName string) (*model.Team, error) {
+	return &model.Team{ID: idOrName, Name: "Backend"}, nil
+}
+
+func (s *fakeChannelSvc) FindTeamByGithubTeam(context.Context, string, string) (*model.Team, error) {
+	return nil, nil
+}
+
+func (s *fakeChannelSvc) FindManagerByGitHubMentionUsername(context.Context, string, string) (*model.Manager, error) {
+	return nil, nil
+}
+
+func (s *fakeChannelSvc) FetchManagerByManagerID(_ context.Context, _, managerID string) (model.Manager, error) {
+	return model.Manager{ID: managerID, Name: "Dylan", GithubUsername: lo.ToPtr("dylan")}, nil
+}
+
+func (s *fakeChannelSvc) BuildTeamChatURL(group model.Group, rootMessageID string) string {
+	return fmt.Sprintf("https://desk.channel.io/#/channels/%s/team_chats/groups/%s/%s", group.ChannelID, group.ID, rootMessageID)
+}
+
+func newTestStatusSvc(t *testing.T, githubSvc *fakeGithubSvc, channelSvc *fakeChannelSvc, threads map[int]string) *StatusSvc {
+	conf := &config.Config{}
+	threadSvc := NewThreadSvc(githubSvc, thread.NewMemoryStore())
+	for number, rootMessageID := range threads {
+		err := threadSvc.SaveThread(context.TODO(), testInstallCtx(), "cht-app-github", number, thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: rootMessageID})
+		assert.NoError(t, err)
+	}
+	return &StatusSvc{
+		githubSvc:  githubSvc,
+		channelSvc: channelSvc,
+		threadSvc:  threadSvc,
+		router:     routing.NewRouter(conf, githubSvc, repoconfig.NewService(conf, githubSvc)),
+	}
+}
+
+func testInstallCtx() github.InstallationContext {
+	return github.NewInstallationContext(1, "channel-io")
+}
+
+func testPullRequest(number int, draft bool) *libgithub.PullRequest {
+	return &libgithub.PullRequest{
+		Number: lo.ToPtr(number),
+		State:  lo.ToPtr("open"),
+		Draft:  lo.ToPtr(draft),
+		Base:   &libgithub.PullRequestBranch{Ref: lo.ToPtr("main")},
+	}
+}
+
+// payload 에 pull request 가 없으면 (fork 의 branch, merge 된 commit) commit sha 로 draft 가 아닌 pull request 를 조회합니다.
+func TestStatusSvc_ListCommitPullRequests_FallbackToCommitSHA(t *testing.T) {
+	githubSvc := &fakeGithubSvc{
+		commitPullRequests: []*libgithub.PullRequest{testPullRequest(412, false), testPullRequest(413, true)},
+	}
+	statusSvc := newTestStatusSvc(t, githubSvc, &fakeChannelSvc{}, nil)
+
+	ref := CommitRef{InstallCtx: testInstallCtx(), Repository: &libgithub.Repository{Name: lo.ToPtr("cht-app-github")}, SHA: testCommitSHA}
+	pullRequests, err := statusSvc.listCommitPullRequests(context.TODO(), ref, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, githubSvc.commitLookups)
+	assert.Equal(t, []int{412}, lo.Map(pullRequests, func(pr *libgithub.PullRequest, _ int) int { return pr.GetNumber() }))
+}
+
+// payload 에 pull request 가 있으면 commit sha 로 조회하지 않고, 열려있는 pull request 에 보내지 않는 경우에는 조회하지 않습니다.
+func TestStatusSvc_ListCommitPullRequests_FromPayload(t *testing.T) {
+	githubSvc := &fakeGithubSvc{
+		pullRequests: map[int]*libgithub.PullRequest{
+			412: testPullRequest(412, false),
+			415: testPullRequest(415, true),
+		},
+	}
+	statusSvc := newTestStatusSvc(t, githubSvc, &fakeChannelSvc{}, nil)
+
+	ref := CommitRef{InstallCtx: testInstallCtx(), Repository: &libgithub.Repository{Name: lo.ToPtr("cht-app-github")}, SHA: testCommitSHA, PullRequestNumbers: []int{412, 415}}
+	pullRequests, err := statusSvc.listCommitPullRequests(context.TODO(), ref, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{412}, lo.Map(pullRequests, func(pr *libgithub.PullRequest, _ int) int { return pr.GetNumber() }))
+
+	pullRequests, err = statusSvc.listCommitPullRequests(context.TODO(), ref, false)
+	assert.NoError(t, err)
+	assert.Empty(t, pullRequests)
+	assert.Zero(t, githubSvc.commitLookups)
+}
+
+// 같은 commit 을 head 로 하는 pull request 가 여러 개이면 thread 가 있는 pull request 마다 작성합니다.
+func TestStatusSvc_SyncWorkflowWithChannelTalk_MultiplePullRequests(t *testing.T) {
+	channelSvc := &fakeChannelSvc{}
+	statusSvc := newTestStatusSvc(t, &fakeGithubSvc{}, channelSvc, map[int]string{412: "root-412", 415: "root-415"})
+
+	err := statusSvc.SyncWorkflowWithChannelTalk(context.TODO(), testInstallCtx(), "cht-app-github", testCommitSHA, []int{412, 414, 415}, routing.Target{
+		Family: routing.FamilyCI,
+		Action: "failure",
+	}, model.NewMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root-412", "root-415"}, channelSvc.threadMessages)
+}
+
+// pull request 가 payload 에 없으면 commit sha 로 조회한 pull request 마다 작성합니다.
+func TestStatusSvc_SyncWorkflowWithChannelTalk_FallbackToCommitSHA(t *testing.T) {
+	githubSvc := &fakeGithubSvc{
+		commitPullRequests: []*libgithub.PullRequest{testPullRequest(412, false), testPullRequest(413, true), testPullRequest(415, false)},
+	}
+	channelSvc := &fakeChannelSvc{}
+	statusSvc := newTestStatusSvc(t, githubSvc, channelSvc, map[int]string{412: "root-412", 413: "root-413", 415: "root-415"})
+
+	err := statusSvc.SyncWorkflowWithChannelTalk(context.TODO(), testInstallCtx(), "cht-app-github", testCommitSHA, nil, routing.Target{
+		Family: routing.FamilyCI,
+		Action: "failure",
+	}, model.NewMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, githubSvc.commitLookups)
+	assert.Equal(t, []string{"root-412", "root-415"}, channelSvc.threadMessages)
+}
+
+func TestStatusSvc_SyncCommitStatusWithChannelTalk_MultiplePullRequests(t *testing.T) {
+	channelSvc := &fakeChannelSvc{}
+	statusSvc := newTestStatusSvc(t, &fakeGithubSvc{}, channelSvc, map[int]string{412: "root-412", 415: "root-415"})
+
+	pullRequests := []*libgithub.PullRequest{testPullRequest(412, false), testPullRequest(415, false)}
+	err := statusSvc.SyncCommitStatusWithChannelTalk(context.TODO(), testInstallCtx(), "cht-app-github", pullRequests, routing.Target{
+		Family: routing.FamilyCI,
+		Action: "success",
+	}, model.NewMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root-412", "root-415"}, channelSvc.threadMessages)
+}
@@ -0,0 +1,129 @@
+package svc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+type ThreadSvc struct {
+	githubSvc   github.Service
+	threadStore thread.Store
+}
+
+func NewThreadSvc(githubSvc github.Service, threadStore thread.Store) *ThreadSvc {
+	return &ThreadSvc{
+		githubSvc:   githubSvc,
+		threadStore: threadStore,
+	}
+}
+
+// FindThread 는 issue 에 연결된 팀챗 thread 를 찾습니다.
+// store 에 매핑이 없고 findFromComments 가 true 이면 issue comment 의 desk url 로부터 한 번 찾습니다.
+func (svc *ThreadSvc) FindThread(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	number int,
+	findFromComments bool,
+) (*thread.Thread, error) {
+	key := thread.NewKey(installCtx.OrgLogin, repository, number)
+	found, err := svc.threadStore.Find(ctx, key)
+	if err != nil || found != nil || !findFromComments {
+		return found, err
+	}
+
+	// NOTE : store 도입 이전에 생성된 thread 는 app 이 작성한 comment 에서만 찾을 수 있습니다.
+	// 찾은 경우 store 에 채워넣어 이후에는 comment 를 조회하지 않도록 합니다.
+	rootMessageID, err := svc.githubSvc.FindRootMessageID(ctx, installCtx, repository, number)
+	if err != nil || rootMessageID == nil {
+		return nil, err
+	}
+	group, err := svc.githubSvc.FindGroup(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	legacy := thread.Thread{
+		ChannelID:     group.ChannelID,
+		GroupID:       group.ID,
+		RootMessageID: *rootMessageID,
+	}
+	if err := svc.threadStore.Save(ctx, key, legacy); err != nil {
+		return nil, err
+	}
+	return &legacy, nil
+}
+
+func (svc *ThreadSvc) SaveThread(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	number int,
+	t thread.Thread,
+) error {
+	return svc.threadStore.Save(ctx, thread.NewKey(installCtx.OrgLogin, repository, number), t)
+}
+
+// ClaimThread 는 root message 를 작성하기 전에 issue 의 thread 를 선점합니다.
+// 다른 event 가 먼저 선점했으면 error 를 반환합니다. 해당 event 는 retry 될 때 먼저 작성된 root message 의 thread 에 작성합니다.
+func (svc *ThreadSvc) ClaimThread(
+	ctx context.Context,
+	installCtx github.InstallationContext,
+	repository string,
+	number int,
+	group model.Group,
+) error {
+	key := thread.NewKey(installCtx.OrgLogin, repository, number)
+	claimed, err := svc.threadStore.SaveIfAbsent(ctx, key, thread.NewPendingThread(group, time.Now()))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errors.Errorf("thread of %s is being created by another event", key)
+	}
+	return nil
+}
+
+// ReleaseThread 는 root message 를 작성하지 못한 thread 의 선점을 풉니다.
+func (svc *ThreadSvc) ReleaseThread(ctx context.Context, installCtx github.InstallationContext, repository string, number int) error {
+	return svc.threadStore.Delete(ctx, thread.NewKey(installCtx.OrgLogin, repository, number))
+}
+
+// writeRootMessage 는 thread 를 선점한 뒤 root message 를 작성하고 thread 로 저장합니다.
+func (svc *ThreadSvc) writeRootMessage(
+	ctx context.Context,
+	channelSvc channel.Service,
+	installCtx github.InstallationContext,
+	repository string,
+	number int,
+	group model.Group,
+	message *model.Message,
+) (string, error) {
+	if err := svc.ClaimThread(ctx, installCtx, repository, number, group); err != nil {
+		return "", err
+	}
+
+	messageID, err := channelSvc.WriteMessage(ctx, group, message)
+	if err != nil {
+		if releaseErr := svc.ReleaseThread(ctx, installCtx, repository, number); releaseErr != nil {
+			return "", errors.Wrapf(err, "failed to release thread: %v", releaseErr)
+		}
+		return "", err
+	}
+
+	err = svc.SaveThread(ctx, installCtx, repository, number, thread.Thread{
+		ChannelID:     group.ChannelID,
+		GroupID:       group.ID,
+		RootMessageID: messageID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return messageID, nil
+}
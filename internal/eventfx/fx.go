@@ -1,14 +1,71 @@
 package eventfx
 
 import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
 
 	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/config"
 	"github.com/channel-io/cht-app-github/internal/event"
 	"github.com/channel-io/cht-app-github/internal/event/callback"
 	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
+type QueueMetricsResult struct {
+	fx.Out
+
+	QueueMetrics *queue.Metrics
+	Collector    prometheus.Collector `group:"metric.collector"`
+}
+
+func NewQueueMetrics() QueueMetricsResult {
+	qm := queue.NewMetrics()
+	return QueueMetricsResult{
+		QueueMetrics: qm,
+		Collector:    qm,
+	}
+}
+
+func NewEventWorker(
+	lc fx.Lifecycle,
+	conf *config.Config,
+	jobQueue queue.Queue,
+	eventHandler *event.GithubEventHandler,
+	batches *queue.Batches,
+	metrics *queue.Metrics,
+	logger logger.Logger,
+) *queue.Worker {
+	worker := queue.NewWorker(jobQueue, batches.Handler(eventHandler.HandleJob), metrics, logger, queue.WorkerConfig{
+		Concurrency: conf.Event.Queue.Concurrency,
+		MaxAttempts: conf.Event.Queue.MaxAttempts,
+		BaseBackoff: conf.Event.Queue.BaseBackoff,
+		MaxBackoff:  conf.Event.Queue.MaxBackoff,
+	})
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			worker.Start()
+			return nil
+		},
+		OnStop: worker.Stop,
+	})
+	return worker
+}
+
+// NewBatches 는 짧은 시간에 연달아 오는 event 를 모아 보내는 batch 의 flush job 을 실행합니다.
+func NewBatches(conf *config.Config, jobQueue queue.Queue, store queue.BatchStore) *queue.Batches {
+	return queue.NewBatches(jobQueue, store, queue.BatchConfig{
+		Wait:    conf.Event.Debounce.Wait,
+		MaxWait: conf.Event.Debounce.MaxWait,
+	})
+}
+
 var Option = fx.Options(
 	fx.Provide(
 		fx.Annotate(
@@ -17,9 +74,13 @@ var Option = fx.Options(
 		),
 		fx.Annotate(
 			event.NewGithubEventHandler,
-			fx.ParamTags("", "", `group:"event.callbacks"`),
+			fx.ParamTags("", "", `group:"event.callbacks"`, ""),
 		),
+		NewQueueMetrics,
+		NewBatches,
+		NewEventWorker,
 	),
+	fx.Invoke(func(*queue.Worker) {}),
 
 	fx.Provide(
 		// Issue
@@ -30,6 +91,7 @@ var Option = fx.Options(
 
 		// Pull Request
 		eventCallback(callback.NewPullRequestEventReadyForReview),
+		eventCallback(callback.NewPullRequestEventConvertedToDraft),
 		eventCallback(callback.NewPullRequestEventOpened),
 		eventCallback(callback.NewPullRequestEventClosed),
 		eventCallback(callback.NewPullRequestReviewEventSubmitted),
@@ -37,16 +99,37 @@ var Option = fx.Options(
 		eventCallback(callback.NewPullRequestEventReviewRequestRemoved),
 		eventCallback(callback.NewPullRequestEventAssigned),
 		eventCallback(callback.NewPullRequestEventSynchronize),
+		eventCallback(callback.NewPullRequestReviewCommentEventCreated),
+
+		// Discussion
+		eventCallback(callback.NewDiscussionEventCreated),
+		eventCallback(callback.NewDiscussionEventAnswered),
+		eventCallback(callback.NewDiscussionEventClosed),
+		eventCallback(callback.NewDiscussionCommentCreated),
 
 		eventCallback(callback.NewReleaseEventReleased),
 		eventCallback(callback.NewStatusEventAny),
+
+		// Workflow, Deployment
+		eventCallback(callback.NewWorkflowEventCompleted),
+		eventCallback(callback.NewDeploymentEventAny),
 	),
 
 	fx.Provide(
+		svc.NewThreadSvc,
 		svc.NewIssueSvc,
 		svc.NewCommonSvc,
 		svc.NewStatusSvc,
 		svc.NewReleaseSvc,
+		svc.NewDiscussionSvc,
+		svc.NewReplySvc,
+		svc.NewReminderSvc,
+	),
+
+	fx.Provide(
+		repoconfig.NewService,
+		routing.NewRouter,
+		template.NewEngine,
 	),
 )
 
@@ -0,0 +1,434 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/client/appstore"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+// rootMessageIDAttribute 는 thread 에서 실행한 command 의 trigger attribute 중 root message id 입니다.
+const rootMessageIDAttribute = "rootMessageId"
+
+const (
+	permissionRead  = "read"
+	permissionWrite = "write"
+)
+
+var (
+	issueURLPattern = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/(?:pull|issues)/(\d+)`)
+
+	permissionRanks = map[string]int{
+		"none":          0,
+		permissionRead:  1,
+		permissionWrite: 2,
+		"admin":         3,
+	}
+
+	mergeMethods = []string{"merge", "squash", "rebase"}
+)
+
+// CommandFunction 은 팀챗 thread 에 연결된 github issue(pull request)에 동작을 실행하는 function 들입니다.
+// 실행 결과는 thread 에 작성합니다.
+//
+// NOTE : github app 은 사용자 대신 동작할 수 없으므로 app 의 권한으로 실행합니다.
+// 대신 caller 의 github-username 이 organization 이 인증한 email 로 caller 본인인지 확인한 뒤 그 사용자의 repository 권한을 확인하고,
+// review 와 comment 에는 caller 를 함께 적습니다. url 로 지정한 issue 는 caller 의 channel 로 event 를 보내는 repository 인 경우에만 실행합니다.
+type CommandFunction struct {
+	logger         logger.Logger
+	githubSvc      github.Service
+	channelSvc     channel.Service
+	threadStore    thread.Store
+	router         *routing.Router
+	templateEngine *template.Engine
+}
+
+func NewCommandFunction(
+	logger logger.Logger,
+	githubSvc github.Service,
+	channelSvc channel.Service,
+	threadStore thread.Store,
+	router *routing.Router,
+	templateEngine *template.Engine,
+) *CommandFunction {
+	return &CommandFunction{
+		logger:         logger,
+		githubSvc:      githubSvc,
+		channelSvc:     channelSvc,
+		threadStore:    threadStore,
+		router:         router,
+		templateEngine: templateEngine,
+	}
+}
+
+// CommandParams 는 command function 의 입력입니다. command 마다 필요한 값만 사용합니다.
+type CommandParams struct {
+	// URL 은 대상 issue(pull request)의 url 입니다. 비어있으면 command 를 실행한 thread 에 연결된 issue 입니다.
+	URL       string   `json:"url"`
+	Body      string   `json:"body"`
+	Assignees []string `json:"assignees"`
+	Labels    []string `json:"labels"`
+	// Method 는 merge 방법(merge, squash, rebase)입니다. 기본값은 merge 입니다.
+	Method string `json:"method"`
+}
+
+// commandTarget 은 command 를 실행할 issue 와 caller 입니다.
+type commandTarget struct {
+	installCtx github.InstallationContext
+	repository string
+	number     int
+	issue      *libgithub.Issue
+	username   string
+	params     CommandParams
+}
+
+type commandAction struct {
+	name template.Name
+	// permission 은 caller 에게 필요한 최소 repository 권한입니다.
+	permission      string
+	pullRequestOnly bool
+	run             func(ctx context.Context, target commandTarget, data *template.CommandData) error
+}
+
+func (f *CommandFunction) Register(registry HandlerRegistry) {
+	registry.Register("githubApprove", f.handle("githubApprove", commandAction{
+		name:            template.CommandApproved,
+		permission:      permissionWrite,
+		pullRequestOnly: true,
+		run:             f.approve,
+	}))
+	registry.Register("githubRequestChanges", f.handle("githubRequestChanges", commandAction{
+		name:            template.CommandChangesRequested,
+		permission:      permissionWrite,
+		pullRequestOnly: true,
+		run:             f.requestChanges,
+	}))
+	registry.Register("githubComment", f.handle("githubComment", commandAction{
+		name:       template.CommandCommented,
+		permission: permissionRead,
+		run:        f.comment,
+	}))
+	registry.Register("githubAssign", f.handle("githubAssign", commandAction{
+		name:       template.CommandAssigned,
+		permission: permissionWrite,
+		run:        f.assign,
+	}))
+	registry.Register("githubAddLabels", f.handle("githubAddLabels", commandAction{
+		name:       template.CommandLabeled,
+		permission: permissionWrite,
+		run:        f.addLabels,
+	}))
+	registry.Register("githubRemoveLabels", f.handle("githubRemoveLabels", commandAction{
+		name:       template.CommandUnlabeled,
+		permission: permissionWrite,
+		run:        f.removeLabels,
+	}))
+	registry.Register("githubClose", f.handle("githubClose", commandAction{
+		name:       template.CommandClosed,
+		permission: permissionWrite,
+		run:        f.editState("closed"),
+	}))
+	registry.Register("githubReopen", f.handle("githubReopen", commandAction{
+		name:       template.CommandReopened,
+		permission: permissionWrite,
+		run:        f.editState("open"),
+	}))
+	registry.Register("githubMerge", f.handle("githubMerge", commandAction{
+		name:            template.CommandMerged,
+		permission:      permissionWrite,
+		pullRequestOnly: true,
+		run:             f.merge,
+	}))
+}
+
+func (f *CommandFunction) handle(method string, action commandAction) HandlerFunc {
+	return func(ctx context.Context, params json.RawMessage, fnCtx appstore.Context) error {
+		if fnCtx.Caller.Type != appstore.ManagerCallerType {
+			return errors.Errorf("caller type %s is not manager ", fnCtx.Caller.Type)
+		}
+
+		var fnParams appstore.CommandParams
+		if err := json.Unmarshal(params, &fnParams); err != nil {
+			return err
+		}
+		var commandParams CommandParams
+		if len(fnParams.Input) > 0 {
+			if err := json.Unmarshal(fnParams.Input, &commandParams); err != nil {
+				return err
+			}
+		}
+
+		found := &thread.Thread{
+			ChannelID:     fnCtx.Channel.ID,
+			GroupID:       fnParams.Chat.ID,
+			RootMessageID: fnParams.Trigger.Attributes[rootMessageIDAttribute],
+		}
+		if found.RootMessageID == "" {
+			found = nil
+		}
+
+		text, resultThread, err := f.execute(ctx, fnCtx, found, commandParams, action)
+		if err == nil {
+			return f.writeResult(ctx, fnCtx, fnParams, resultThread, text)
+		}
+
+		f.logger.Errorw("failed to execute command", "method", method, "error", err)
+		failed, renderErr := f.templateEngine.RenderForChannel(fnCtx.Channel.ID, template.CommandFailed, template.CommandData{
+			Command: method,
+			Error:   err.Error(),
+		})
+		if renderErr != nil {
+			return err
+		}
+		if writeErr := f.writeResult(ctx, fnCtx, fnParams, found, failed); writeErr != nil {
+			f.logger.Errorw("failed to write command result", "method", method, "error", writeErr)
+		}
+		return err
+	}
+}
+
+// execute 는 command 를 실행하고, 결과 message 와 결과를 작성할 thread 를 반환합니다.
+func (f *CommandFunction) execute(
+	ctx context.Context,
+	fnCtx appstore.Context,
+	current *thread.Thread,
+	params CommandParams,
+	action commandAction,
+) (string, *thread.Thread, error) {
+	manager, err := f.channelSvc.FetchManagerByManagerID(ctx, fnCtx.Channel.ID, fnCtx.Caller.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	if manager.GithubUsername == nil {
+		return "", nil, errors.New("github-username property not found.")
+	}
+
+	key, resultThread, err := f.resolveKey(ctx, current, params.URL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	installCtx, err := findInstallation(ctx, f.githubSvc, key.Org)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := verifyGithubUsername(ctx, f.githubSvc, installCtx, manager); err != nil {
+		return "", nil, err
+	}
+	permission, err := f.githubSvc.FindPermissionLevel(ctx, installCtx, key.Repository, *manager.GithubUsername)
+	if err != nil {
+		return "", nil, err
+	}
+	if !hasPermission(permission, action.permission) {
+		return "", nil, errors.Errorf("%s needs %s permission on Repository(%s)", *manager.GithubUsername, action.permission, key.Repository)
+	}
+
+	issue, err := f.githubSvc.FetchIssue(ctx, installCtx, key.Repository, key.Number)
+	if err != nil {
+		return "", nil, err
+	}
+	if action.pullRequestOnly && !issue.IsPullRequest() {
+		return "", nil, errors.Errorf("%s is not a pull request", key)
+	}
+	// NOTE : command 를 실행한 thread 의 issue 는 이미 caller 의 channel 에 연결되어 있으므로 url 로 지정한 경우만 확인합니다.
+	if params.URL != "" {
+		family := routing.FamilyIssue
+		if issue.IsPullRequest() {
+			family = routing.FamilyPullRequest
+		}
+		if err := verifyChannelRepository(ctx, f.router, installCtx, key.Repository, family, fnCtx.Channel.ID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	data := template.CommandData{
+		Manager: manager.Name,
+		Title:   issue.GetTitle(),
+		URL:     issue.GetHTMLURL(),
+	}
+	err = action.run(ctx, commandTarget{
+		installCtx: installCtx,
+		repository: key.Repository,
+		number:     key.Number,
+		issue:      issue,
+		username:   *manager.GithubUsername,
+		params:     params,
+	}, &data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	text, err := f.templateEngine.Render(ctx, installCtx, key.Repository, action.name, data)
+	if err != nil {
+		return "", nil, err
+	}
+	return text, resultThread, nil
+}
+
+// resolveKey 는 command 를 실행할 issue 와 결과를 작성할 thread 를 찾습니다.
+// url 이 주어지면 해당 issue 의 thread 에, 아니면 command 를 실행한 thread 에 결과를 작성합니다.
+func (f *CommandFunction) resolveKey(ctx context.Context, current *thread.Thread, url string) (thread.Key, *thread.Thread, error) {
+	if url != "" {
+		key, err := parseIssueURL(url)
+		if err != nil {
+			return thread.Key{}, nil, err
+		}
+		found, err := f.threadStore.Find(ctx, key)
+		if err != nil {
+			return thread.Key{}, nil, err
+		}
+		return key, found, nil
+	}
+
+	if current == nil {
+		return thread.Key{}, nil, errors.New("url is required when the command is not run in an issue or pull request thread")
+	}
+	key, err := f.threadStore.FindKey(ctx, *current)
+	if err != nil {
+		return thread.Key{}, nil, err
+	}
+	if key == nil {
+		return thread.Key{}, nil, errors.New("this thread is not linked to an issue or pull request. url is required")
+	}
+	return *key, current, nil
+}
+
+// writeResult 는 결과를 thread 에 작성합니다. thread 를 찾지 못한 경우 command 를 실행한 group 에 작성합니다.
+func (f *CommandFunction) writeResult(ctx context.Context, fnCtx appstore.Context, fnParams appstore.CommandParams, found *thread.Thread, text string) error {
+	message := model.NewMessage(model.NewTextBlock(text))
+	if found != nil {
+		return f.channelSvc.WriteThreadMessage(ctx, found.Group(), found.RootMessageID, message, false)
+	}
+	_, err := f.channelSvc.WriteMessage(ctx, model.Group{
+		ChannelID: fnCtx.Channel.ID,
+		ID:        fnParams.Chat.ID,
+	}, message)
+	return err
+}
+
+// approve 는 pull request 작성자의 approve 를 거절합니다. app 이 review 를 작성하므로 github 이 작성자의 approve 를 막지 않습니다.
+// NOTE : app 의 approve 는 branch protection 의 필수 review 로 집계되지 않으므로, 결과 message 에 이를 함께 알립니다.
+func (f *CommandFunction) approve(ctx context.Context, target commandTarget, _ *template.CommandData) error {
+	if err := refuseAuthorReview(target); err != nil {
+		return err
+	}
+	return f.githubSvc.CreateReview(ctx, target.installCtx, target.repository, target.number, "APPROVE", attributedBody(target.params.Body, target.username))
+}
+
+func (f *CommandFunction) requestChanges(ctx context.Context, target commandTarget, _ *template.CommandData) error {
+	if err := refuseAuthorReview(target); err != nil {
+		return err
+	}
+	if strings.TrimSpace(target.params.Body) == "" {
+		return errors.New("body is required to request changes")
+	}
+	return f.githubSvc.CreateReview(ctx, target.installCtx, target.repository, target.number, "REQUEST_CHANGES", attributedBody(target.params.Body, target.username))
+}
+
+func (f *CommandFunction) comment(ctx context.Context, target commandTarget, _ *template.CommandData) error {
+	if strings.TrimSpace(target.params.Body) == "" {
+		return errors.New("body is required to comment")
+	}
+	return f.githubSvc.CreateComment(ctx, target.installCtx, target.repository, target.number, attributedBody(target.params.Body, target.username))
+}
+
+// assign 은 assignees 가 비어있으면 caller 에게 할당합니다.
+func (f *CommandFunction) assign(ctx context.Context, target commandTarget, data *template.CommandData) error {
+	assignees := target.params.Assignees
+	if len(assignees) == 0 {
+		assignees = []string{target.username}
+	}
+	data.Assignees = strings.Join(assignees, ", ")
+	return f.githubSvc.AddAssigneeToIssue(ctx, target.installCtx, target.repository, target.number, assignees)
+}
+
+func (f *CommandFunction) addLabels(ctx context.Context, target commandTarget, data *template.CommandData) error {
+	if len(target.params.Labels) == 0 {
+		return errors.New("labels are required")
+	}
+	data.Labels = strings.Join(target.params.Labels, ", ")
+	return f.githubSvc.AddLabelsToIssue(ctx, target.installCtx, target.repository, target.number, target.params.Labels)
+}
+
+func (f *CommandFunction) removeLabels(ctx context.Context, target commandTarget, data *template.CommandData) error {
+	if len(target.params.Labels) == 0 {
+		return errors.New("labels are required")
+	}
+	data.Labels = strings.Join(target.params.Labels, ", ")
+	for _, label := range target.params.Labels {
+		if err := f.githubSvc.RemoveLabelFromIssue(ctx, target.installCtx, target.repository, target.number, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *CommandFunction) editState(state string) func(context.Context, commandTarget, *template.CommandData) error {
+	return func(ctx context.Context, target commandTarget, _ *template.CommandData) error {
+		if target.issue.GetState() == state {
+			return errors.Errorf("%s is already %s", target.issue.GetHTMLURL(), state)
+		}
+		return f.githubSvc.EditIssueState(ctx, target.installCtx, target.repository, target.number, state)
+	}
+}
+
+func (f *CommandFunction) merge(ctx context.Context, target commandTarget, data *template.CommandData) error {
+	method := target.params.Method
+	if method == "" {
+		method = mergeMethods[0]
+	}
+	if !lo.Contains(mergeMethods, method) {
+		return errors.Errorf("invalid merge method %s. one of %s", method, strings.Join(mergeMethods, ", "))
+	}
+	data.Method = method
+	return f.githubSvc.MergePullRequest(ctx, target.installCtx, target.repository, target.number, method)
+}
+
+func refuseAuthorReview(target commandTarget) error {
+	if strings.EqualFold(target.issue.GetUser().GetLogin(), target.username) {
+		return errors.Errorf("%s can not review own pull request", target.username)
+	}
+	return nil
+}
+
+func parseIssueURL(url string) (thread.Key, error) {
+	matches := issueURLPattern.FindStringSubmatch(url)
+	if matches == nil {
+		return thread.Key{}, errors.Errorf("invalid issue or pull request url: %s", url)
+	}
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return thread.Key{}, err
+	}
+	return thread.NewKey(matches[1], matches[2], number), nil
+}
+
+// hasPermission 은 permission 이 required 이상인지 확인합니다. 알 수 없는 권한은 none 으로 취급합니다.
+func hasPermission(permission, required string) bool {
+	return permissionRanks[permission] >= permissionRanks[required]
+}
+
+// attributedBody 는 app 이 작성하는 review, comment 에 실제로 실행한 사용자를 적습니다.
+func attributedBody(body, username string) string {
+	attribution := fmt.Sprintf("_by @%s via Channel Talk_", username)
+	if body = strings.TrimSpace(body); body == "" {
+		return attribution
+	}
+	return body + "\n\n" + attribution
+}
@@ -0,0 +1,287 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/client/appstore"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+func TestParseIssueURL(t *testing.T) {
+	key, err := parseIssueURL("https://github.com/channel-io/cht-app-github/pull/412/files")
+	assert.NoError(t, err)
+	assert.Equal(t, thread.NewKey("channel-io", "cht-app-github", 412), key)
+
+	key, err = parseIssueURL("https://github.com/channel-io/cht-app-github/issues/7")
+	assert.NoError(t, err)
+	assert.Equal(t, thread.NewKey("channel-io", "cht-app-github", 7), key)
+
+	// url 의 대소문자와 관계없이 webhook 이 저장한 thread 를 찾습니다.
+	key, err = parseIssueURL("https://github.com/Channel-IO/CHT-App-GitHub/pull/412")
+	assert.NoError(t, err)
+	assert.Equal(t, thread.Key{Org: "channel-io", Repository: "cht-app-github", Number: 412}, key)
+
+	_, err = parseIssueURL("https://github.com/channel-io/cht-app-github/discussions/3")
+	assert.Error(t, err)
+}
+
+func TestHasPermission(t *testing.T) {
+	assert.True(t, hasPermission("admin", permissionWrite))
+	assert.True(t, hasPermission("write", permissionWrite))
+	assert.False(t, hasPermission("read", permissionWrite))
+	assert.True(t, hasPermission("read", permissionRead))
+	assert.False(t, hasPermission("none", permissionRead))
+	assert.False(t, hasPermission("", permissionRead))
+}
+
+func TestAttributedBody(t *testing.T) {
+	assert.Equal(t, "_by @dylan via Channel Talk_", attributedBody("  ", "dylan"))
+	assert.Equal(t, "LGTM\n\n_by @dylan via Channel Talk_", attributedBody("LGTM\n", "dylan"))
+}
+
+const testChannelIDKey = "cht_channel_id"
+
+type fakeGithubSvc struct {
+	github.Service
+
+	permissions    map[string]string
+	verifiedEmails map[string][]string
+	channels       map[string]string
+	issues         map[int]*libgithub.Issue
+	reviews        []string
+	createdIssues  []*libgithub.Issue
+	// issueOrgs 는 issue 를 작성한 installation 의 organization 입니다.
+	issueOrgs []string
+}
+
+func (s *fakeGithubSvc) FindAppInstallationID(context.Context, string) (*int64, error) {
+	return lo.ToPtr(int64(1)), nil
+}
+
+func (s *fakeGithubSvc) ListOrgInstallations(context.Context) ([]github.InstallationContext, error) {
+	return []github.InstallationContext{github.NewInstallationContext(1, "channel-io"), github.NewInstallationContext(2, "other-org")}, nil
+}
+
+func (s *fakeGithubSvc) ListVerifiedDomainEmails(_ context.Context, _ github.InstallationContext, username string) ([]string, error) {
+	return s.verifiedEmails[username], nil
+}
+
+func (s *fakeGithubSvc) FindPermissionLevel(_ context.Context, _ github.InstallationContext, _, user string) (string, error) {
+	return s.permissions[user], nil
+}
+
+func (s *fakeGithubSvc) FetchIssue(_ context.Context, _ github.InstallationContext, _ string, number int) (*libgithub.Issue, error) {
+	return s.issues[number], nil
+}
+
+func (s *fakeGithubSvc) CreateReview(_ context.Context, _ github.InstallationContext, _ string, number int, event, _ string) error {
+	s.reviews = append(s.reviews, fmt.Sprintf("%d:%s", number, event))
+	return nil
+}
+
+func (s *fakeGithubSvc) CreateIssue(_ context.Context, installCtx github.InstallationContext, repository, title, body string, _, _ []string) (*libgithub.Issue, error) {
+	s.issueOrgs = append(s.issueOrgs, installCtx.OrgLogin)
+	number := 100 + len(s.createdIssues)
+	issue := &libgithub.Issue{
+		Number:  lo.ToPtr(number),
+		Title:   lo.ToPtr(title),
+		Body:    lo.ToPtr(body),
+		HTMLURL: lo.ToPtr(fmt.Sprintf("https://github.com/channel-io/%s/issues/%d", repository, number)),
+	}
+	s.createdIssues = append(s.createdIssues, issue)
+	return issue, nil
+}
+
+func (s *fakeGithubSvc) FindRepositoryFile(context.Context, github.InstallationContext, string, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *fakeGithubSvc) FindCustomProperties(_ context.Context, _ github.InstallationContext, repository string) (map[string]string, error) {
+	// github 과 같이 repository 이름의 대소문자를 구분하지 않습니다.
+	return map[string]string{testChannelIDKey: s.channels[strings.ToLower(repository)]}, nil
+}
+
+type fakeChannelSvc struct {
+	channel.Service
+
+	managers map[string]model.Manager
+	// written 은 작성한 message 입니다. thread 에 작성한 경우 root message id 를 앞에 붙입니다.
+	written []string
+}
+
+func (s *fakeChannelSvc) FetchManagerByManagerID(_ context.Context, _, managerID string) (model.Manager, error) {
+	return s.managers[managerID], nil
+}
+
+func (s *fakeChannelSvc) WriteThreadMessage(_ context.Context, _ model.Group, rootMessageID string, message *model.Message, _ bool) error {
+	s.written = append(s.written, rootMessageID+" "+message.Blocks[0].Text.Value)
+	return nil
+}
+
+func (s *fakeChannelSvc) WriteMessage(_ context.Context, _ model.Group, message *model.Message) (string, error) {
+	s.written = append(s.written, message.Blocks[0].Text.Value)
+	return fmt.Sprintf("message-%d", len(s.written)), nil
+}
+
+func (s *fakeChannelSvc) BuildGithubMarkdown(_ context.Context, _ string, blocks []model.MessageBlock) (string, error) {
+	return blocks[0].Text.Value, nil
+}
+
+func (s *fakeChannelSvc) BuildTeamChatURL(group model.Group, rootMessageID string) string {
+	return fmt.Sprintf("https://desk.channel.io/#/channels/%s/team_chats/groups/%s/%s", group.ChannelID, group.ID, rootMessageID)
+}
+
+type commandTest struct {
+	githubSvc   *fakeGithubSvc
+	channelSvc  *fakeChannelSvc
+	threadStore thread.Store
+	router      *routing.Router
+	engine      *template.Engine
+	registry    HandlerRegistry
+}
+
+// newCommandTest 는 channel 1 에 연결된 channel-io/cht-app-github 와, 그 pull request #412 의 thread(root-412)를 준비합니다.
+// manager 1(lento)은 write 권한이 있고 email 이 인증된 사용자이며, #412 의 작성자는 dylan 입니다.
+func newCommandTest(t *testing.T) *commandTest {
+	conf := new(config.Config)
+	conf.Github.Properties.ChannelIdKey = testChannelIDKey
+
+	githubSvc := &fakeGithubSvc{
+		permissions:    map[string]string{"lento": permissionWrite, "dylan": permissionWrite, "reader": permissionRead},
+		verifiedEmails: map[string][]string{"lento": {"Lento@channel.io"}, "dylan": {"dylan@channel.io"}, "reader": {"reader@channel.io"}},
+		channels:       map[string]string{"cht-app-github": "1", "ch-api": "2"},
+		issues: map[int]*libgithub.Issue{
+			412: {
+				Number:           lo.ToPtr(412),
+				Title:            lo.ToPtr("Add commands"),
+				HTMLURL:          lo.ToPtr("https://github.com/channel-io/cht-app-github/pull/412"),
+				User:             &libgithub.User{Login: lo.ToPtr("dylan")},
+				PullRequestLinks: &libgithub.PullRequestLinks{URL: lo.ToPtr("https://api.github.com/repos/channel-io/cht-app-github/pulls/412")},
+			},
+		},
+	}
+	channelSvc := &fakeChannelSvc{managers: map[string]model.Manager{
+		"1": {ID: "1", Name: "Lento", Email: lo.ToPtr("lento@channel.io"), GithubUsername: lo.ToPtr("lento")},
+		"2": {ID: "2", Name: "Dylan", Email: lo.ToPtr("dylan@channel.io"), GithubUsername: lo.ToPtr("dylan")},
+		"3": {ID: "3", Name: "Mallory", Email: lo.ToPtr("mallory@example.com"), GithubUsername: lo.ToPtr("lento")},
+		"4": {ID: "4", Name: "Reader", Email: lo.ToPtr("reader@channel.io"), GithubUsername: lo.ToPtr("reader")},
+	}}
+	threadStore := thread.NewMemoryStore()
+	err := threadStore.Save(context.TODO(), thread.NewKey("channel-io", "cht-app-github", 412), thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: "root-412"})
+	assert.NoError(t, err)
+
+	repoConfigSvc := repoconfig.NewService(conf, githubSvc)
+	router := routing.NewRouter(conf, githubSvc, repoConfigSvc)
+	engine := template.NewEngine(conf, router, repoConfigSvc, nil)
+
+	ct := &commandTest{
+		githubSvc:   githubSvc,
+		channelSvc:  channelSvc,
+		threadStore: threadStore,
+		router:      router,
+		engine:      engine,
+		registry:    HandlerRegistry{},
+	}
+	log := logger.NewBasicLogger(conf)
+	issueSvc := svc.NewIssueSvc(githubSvc, channelSvc, svc.NewThreadSvc(githubSvc, threadStore), router)
+	NewCommandFunction(log, githubSvc, channelSvc, threadStore, router, engine).Register(ct.registry)
+	NewIssueFunction(log, githubSvc, channelSvc, issueSvc, threadStore, router, engine).Register(ct.registry)
+	return ct
+}
+
+// run 은 channel 1 의 group 10 에서 manager 가 function 을 실행합니다. rootMessageID 가 있으면 해당 thread 에서 실행합니다.
+func (ct *commandTest) run(method, managerID, rootMessageID, input string) error {
+	return ct.runWithAttributes(method, managerID, map[string]string{rootMessageIDAttribute: rootMessageID}, input)
+}
+
+func (ct *commandTest) runWithAttributes(method, managerID string, attributes map[string]string, input string) error {
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	params := fmt.Sprintf(`{"chat":{"type":"group","id":"10"},"trigger":{"type":"command","attributes":%s},"input":%s}`, encoded, input)
+	return ct.registry[method](context.TODO(), json.RawMessage(params), appstore.Context{
+		Caller:  appstore.Caller{Type: appstore.ManagerCallerType, ID: managerID},
+		Channel: appstore.Channel{ID: "1"},
+	})
+}
+
+func TestCommandFunction_ApproveInThread(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.run("githubApprove", "1", "root-412", `{"body":"LGTM"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"412:APPROVE"}, ct.githubSvc.reviews)
+	assert.Equal(t, []string{
+		fmt.Sprintf("root-412 :100: %s approved by Lento (approved as the GitHub App, which does not count toward required reviews of branch protection)", model.InlineLink("https://github.com/channel-io/cht-app-github/pull/412", "Add commands")),
+	}, ct.channelSvc.written)
+}
+
+// url 로 지정한 issue 의 결과는 command 를 실행한 곳이 아니라 issue 의 thread 에 작성합니다.
+func TestCommandFunction_ApproveByURL(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.run("githubApprove", "1", "", `{"url":"https://github.com/channel-io/cht-app-github/pull/412"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"412:APPROVE"}, ct.githubSvc.reviews)
+	assert.Len(t, ct.channelSvc.written, 1)
+	assert.True(t, strings.HasPrefix(ct.channelSvc.written[0], "root-412 "))
+}
+
+func TestCommandFunction_PermissionDenied(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.run("githubApprove", "4", "root-412", `{}`)
+	assert.ErrorContains(t, err, "reader needs write permission")
+	assert.Empty(t, ct.githubSvc.reviews)
+	assert.Equal(t, []string{"root-412 :warning: githubApprove failed: reader needs write permission on Repository(cht-app-github)"}, ct.channelSvc.written)
+}
+
+// 다른 사람의 github-username 을 property 에 적어도 organization 이 인증한 email 이 다르면 실행하지 않습니다.
+func TestCommandFunction_UnverifiedGithubUsername(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.run("githubApprove", "3", "root-412", `{}`)
+	assert.ErrorContains(t, err, "github user lento has no verified email mallory@example.com")
+	assert.Empty(t, ct.githubSvc.reviews)
+}
+
+func TestCommandFunction_RefuseAuthorApproval(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.run("githubApprove", "2", "root-412", `{}`)
+	assert.ErrorContains(t, err, "dylan can not review own pull request")
+	assert.Empty(t, ct.githubSvc.reviews)
+}
+
+// caller 의 channel 로 event 를 보내지 않는 repository 의 url 은 실행하지 않습니다.
+func TestCommandFunction_URLOfOtherChannel(t *testing.T) {
+	ct := newCommandTest(t)
+	ct.githubSvc.issues[7] = &libgithub.Issue{
+		Number:  lo.ToPtr(7),
+		Title:   lo.ToPtr("Bug"),
+		HTMLURL: lo.ToPtr("https://github.com/channel-io/ch-api/issues/7"),
+	}
+
+	err := ct.run("githubComment", "1", "", `{"url":"https://github.com/channel-io/ch-api/issues/7","body":"hi"}`)
+	assert.ErrorContains(t, err, "Repository(channel-io/ch-api) is not connected to this channel")
+	assert.Len(t, ct.channelSvc.written, 1)
+	assert.False(t, strings.HasPrefix(ct.channelSvc.written[0], "root-"))
+}
@@ -20,9 +20,11 @@ type JsonFunctionDelegator struct {
 	registry HandlerRegistry
 }
 
-func NewJsonFunctionDelegator(todoFunc *TODOFunction) *JsonFunctionDelegator {
+func NewJsonFunctionDelegator(todoFunc *TODOFunction, commandFunc *CommandFunction, issueFunc *IssueFunction) *JsonFunctionDelegator {
 	registry := make(HandlerRegistry)
 	todoFunc.Register(registry)
+	commandFunc.Register(registry)
+	issueFunc.Register(registry)
 	return &JsonFunctionDelegator{registry: registry}
 }
 
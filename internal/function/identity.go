@@ -0,0 +1,53 @@
+package function
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/routing"
+)
+
+// verifyGithubUsername 은 manager 의 github-username property 가 manager 본인의 github 계정인지 확인합니다.
+// github-username 은 manager 가 직접 수정할 수 있으므로, organization 이 인증한 domain 의 email 중 manager 의 email 이 있는 경우에만 같은 사용자로 봅니다.
+func verifyGithubUsername(ctx context.Context, githubSvc github.Service, installCtx github.InstallationContext, manager model.Manager) error {
+	username := lo.FromPtr(manager.GithubUsername)
+	if manager.Email == nil || *manager.Email == "" {
+		return errors.Errorf("email of manager %s is required to verify github user %s", manager.Name, username)
+	}
+
+	emails, err := githubSvc.ListVerifiedDomainEmails(ctx, installCtx, username)
+	if err != nil {
+		return err
+	}
+	for _, email := range emails {
+		if strings.EqualFold(email, *manager.Email) {
+			return nil
+		}
+	}
+	return errors.Errorf("github user %s has no verified email %s in Org(%s)", username, *manager.Email, installCtx.OrgLogin)
+}
+
+// verifyChannelRepository 는 repository 의 event 를 function 을 실행한 channel 로 보내는지 확인합니다.
+// 다른 channel 의 manager 가 url 이나 repository 를 지정해서 자신의 channel 과 관계없는 repository 에 동작하지 않도록 합니다.
+func verifyChannelRepository(
+	ctx context.Context,
+	router *routing.Router,
+	installCtx github.InstallationContext,
+	repository string,
+	family routing.Family,
+	channelID string,
+) error {
+	routed, err := router.FindChannelID(ctx, installCtx, repository, family)
+	if err != nil {
+		return err
+	}
+	if routed != channelID {
+		return errors.Errorf("Repository(%s/%s) is not connected to this channel", installCtx.OrgLogin, repository)
+	}
+	return nil
+}
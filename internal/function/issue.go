@@ -0,0 +1,249 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/client/appstore"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/internal/template"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+// messageIDAttribute 는 message 에서 실행한 command 의 trigger attribute 중 message id 입니다.
+//
+// githubCreateIssue 는 trigger attribute 로 issue 의 root message 가 될 message 를 찾습니다.
+//   - messageId : message 에서 실행한 경우 그 message 가 root message 가 됩니다.
+//   - rootMessageId : thread 에서 실행한 경우 thread 의 root message 가 root message 가 됩니다.
+//
+// 둘 다 없거나 message 가 이미 다른 issue(pull request)의 thread 이면 issue 를 만든 뒤 repository 의 group 에 새 root message 를 작성합니다.
+const messageIDAttribute = "messageId"
+
+// IssueFunction 은 팀챗 message 로 github issue 를 만드는 function 입니다.
+// caller 의 github-username 을 확인하고, caller 의 channel 로 event 를 보내는 repository 에만 issue 를 만듭니다.
+type IssueFunction struct {
+	logger         logger.Logger
+	githubSvc      github.Service
+	channelSvc     channel.Service
+	issueSvc       *svc.IssueSvc
+	threadStore    thread.Store
+	router         *routing.Router
+	templateEngine *template.Engine
+}
+
+func NewIssueFunction(
+	logger logger.Logger,
+	githubSvc github.Service,
+	channelSvc channel.Service,
+	issueSvc *svc.IssueSvc,
+	threadStore thread.Store,
+	router *routing.Router,
+	templateEngine *template.Engine,
+) *IssueFunction {
+	return &IssueFunction{
+		logger:         logger,
+		githubSvc:      githubSvc,
+		channelSvc:     channelSvc,
+		issueSvc:       issueSvc,
+		threadStore:    threadStore,
+		router:         router,
+		templateEngine: templateEngine,
+	}
+}
+
+// CreateIssueParams 는 githubCreateIssue 의 입력입니다.
+type CreateIssueParams struct {
+	// Repository 는 org/repo 또는 repo 입니다. org 가 없으면 caller 의 github organization 입니다.
+	Repository string `json:"repository"`
+	Title      string `json:"title"`
+	// Body 는 팀챗 message 의 text 입니다. github markdown 으로 변환해서 작성합니다.
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels"`
+	Assignees []string `json:"assignees"`
+}
+
+func (f *IssueFunction) Register(registry HandlerRegistry) {
+	registry.Register("githubCreateIssue", f.createIssue)
+}
+
+func (f *IssueFunction) createIssue(ctx context.Context, params json.RawMessage, fnCtx appstore.Context) error {
+	if fnCtx.Caller.Type != appstore.ManagerCallerType {
+		return errors.Errorf("caller type %s is not manager ", fnCtx.Caller.Type)
+	}
+
+	var fnParams appstore.CommandParams
+	if err := json.Unmarshal(params, &fnParams); err != nil {
+		return err
+	}
+	var issueParams CreateIssueParams
+	if len(fnParams.Input) > 0 {
+		if err := json.Unmarshal(fnParams.Input, &issueParams); err != nil {
+			return err
+		}
+	}
+
+	err := f.execute(ctx, fnCtx, fnParams, issueParams)
+	if err == nil {
+		return nil
+	}
+
+	f.logger.Errorw("failed to create issue", "error", err)
+	failed, renderErr := f.templateEngine.RenderForChannel(fnCtx.Channel.ID, template.CommandFailed, template.CommandData{
+		Command: "githubCreateIssue",
+		Error:   err.Error(),
+	})
+	if renderErr != nil {
+		return err
+	}
+	group := model.Group{ChannelID: fnCtx.Channel.ID, ID: fnParams.Chat.ID}
+	if _, writeErr := f.channelSvc.WriteMessage(ctx, group, model.NewMessage(model.NewTextBlock(failed))); writeErr != nil {
+		f.logger.Errorw("failed to write command result", "method", "githubCreateIssue", "error", writeErr)
+	}
+	return err
+}
+
+func (f *IssueFunction) execute(ctx context.Context, fnCtx appstore.Context, fnParams appstore.CommandParams, params CreateIssueParams) error {
+	if strings.TrimSpace(params.Title) == "" {
+		return errors.New("title is required")
+	}
+
+	manager, err := f.channelSvc.FetchManagerByManagerID(ctx, fnCtx.Channel.ID, fnCtx.Caller.ID)
+	if err != nil {
+		return err
+	}
+	if manager.GithubUsername == nil {
+		return errors.New("github-username property not found.")
+	}
+
+	org, repository, err := parseRepository(params.Repository, manager.GithubOrganization)
+	if err != nil {
+		return err
+	}
+
+	installCtx, err := findInstallation(ctx, f.githubSvc, org)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyChannelRepository(ctx, f.router, installCtx, repository, routing.FamilyIssue, fnCtx.Channel.ID); err != nil {
+		return err
+	}
+	if err := verifyGithubUsername(ctx, f.githubSvc, installCtx, manager); err != nil {
+		return err
+	}
+	permission, err := f.githubSvc.FindPermissionLevel(ctx, installCtx, repository, *manager.GithubUsername)
+	if err != nil {
+		return err
+	}
+	if !hasPermission(permission, permissionRead) {
+		return errors.Errorf("%s needs %s permission on Repository(%s)", *manager.GithubUsername, permissionRead, repository)
+	}
+
+	origin, err := f.findOrigin(ctx, fnCtx, fnParams)
+	if err != nil {
+		return err
+	}
+
+	body, err := f.channelSvc.BuildGithubMarkdown(ctx, fnCtx.Channel.ID, []model.MessageBlock{model.NewTextBlock(params.Body)})
+	if err != nil {
+		return err
+	}
+	if origin != nil {
+		url := f.channelSvc.BuildTeamChatURL(origin.Group(), origin.RootMessageID)
+		body = strings.TrimSpace(fmt.Sprintf("%s\n\n[Team chat](%s)", body, url))
+	}
+
+	issue, err := f.githubSvc.CreateIssue(ctx, installCtx, repository, params.Title, svc.MarkChatIssue(attributedBody(body, *manager.GithubUsername)), params.Labels, params.Assignees)
+	if err != nil {
+		return err
+	}
+
+	if origin != nil {
+		key := thread.NewKey(installCtx.OrgLogin, repository, issue.GetNumber())
+		if err := f.threadStore.Save(ctx, key, *origin); err != nil {
+			return err
+		}
+	}
+
+	text, err := f.templateEngine.Render(ctx, installCtx, repository, template.CommandIssueCreated, template.CommandData{
+		Manager: manager.Name,
+		Title:   issue.GetTitle(),
+		URL:     issue.GetHTMLURL(),
+	})
+	if err != nil {
+		return err
+	}
+	return f.issueSvc.SyncIssueWithChannelTalk(ctx, installCtx, repository, issue.GetNumber(), model.NewMessage(model.NewTextBlock(text)), svc.WithoutTryFindingRootMessage())
+}
+
+// findOrigin 은 command 를 실행한 message 를 찾습니다. 이 message 가 issue 의 root message 가 됩니다.
+// 이미 다른 issue(pull request)의 thread 라면 새 root message 를 작성하도록 nil 을 반환합니다.
+func (f *IssueFunction) findOrigin(ctx context.Context, fnCtx appstore.Context, fnParams appstore.CommandParams) (*thread.Thread, error) {
+	messageID := fnParams.Trigger.Attributes[messageIDAttribute]
+	if messageID == "" {
+		messageID = fnParams.Trigger.Attributes[rootMessageIDAttribute]
+	}
+	if messageID == "" {
+		return nil, nil
+	}
+
+	origin := &thread.Thread{
+		ChannelID:     fnCtx.Channel.ID,
+		GroupID:       fnParams.Chat.ID,
+		RootMessageID: messageID,
+	}
+	linked, err := f.threadStore.FindKey(ctx, *origin)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		return nil, nil
+	}
+	return origin, nil
+}
+
+// parseRepository 는 org/repo 또는 repo 형식의 repository 를 나눕니다.
+func parseRepository(repository string, defaultOrg *string) (string, string, error) {
+	repository = strings.TrimSpace(repository)
+	if repository == "" {
+		return "", "", errors.New("repository is required")
+	}
+
+	org, name, found := strings.Cut(repository, "/")
+	if !found {
+		if defaultOrg == nil || *defaultOrg == "" {
+			return "", "", errors.Errorf("organization of Repository(%s) is unknown. use org/repo", repository)
+		}
+		return *defaultOrg, repository, nil
+	}
+	if org == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", errors.Errorf("invalid repository: %s", repository)
+	}
+	return org, name, nil
+}
+
+// findInstallation 은 org 에 설치된 app 의 installation 을 찾습니다.
+// NOTE : 사용자가 입력한 org 는 대소문자가 다를 수 있으므로 installation 의 organization login 을 사용합니다.
+func findInstallation(ctx context.Context, githubSvc github.Service, org string) (github.InstallationContext, error) {
+	installCtxs, err := githubSvc.ListOrgInstallations(ctx)
+	if err != nil {
+		return github.InstallationContext{}, err
+	}
+	installCtx, found := lo.Find(installCtxs, func(installCtx github.InstallationContext) bool {
+		return strings.EqualFold(installCtx.OrgLogin, org)
+	})
+	if !found {
+		return github.InstallationContext{}, errors.Errorf("github app is not installed in Org(%s)", org)
+	}
+	return installCtx, nil
+}
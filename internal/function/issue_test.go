@@ -0,0 +1,89 @@
+package function
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+func TestParseRepository(t *testing.T) {
+	org, repo, err := parseRepository("channel-io/cht-app-github", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "channel-io", org)
+	assert.Equal(t, "cht-app-github", repo)
+
+	org, repo, err = parseRepository("cht-app-github", lo.ToPtr("channel-io"))
+	assert.NoError(t, err)
+	assert.Equal(t, "channel-io", org)
+	assert.Equal(t, "cht-app-github", repo)
+
+	_, _, err = parseRepository("cht-app-github", nil)
+	assert.Error(t, err)
+
+	_, _, err = parseRepository("channel-io/cht-app-github/issues", nil)
+	assert.Error(t, err)
+
+	_, _, err = parseRepository("", lo.ToPtr("channel-io"))
+	assert.Error(t, err)
+}
+
+// message 에서 실행하면 그 message 가 issue 의 root message 가 되고, 결과를 그 thread 에 작성합니다.
+func TestIssueFunction_CreateIssueFromMessage(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.runWithAttributes("githubCreateIssue", "1", map[string]string{messageIDAttribute: "message-1"}, `{"repository":"channel-io/cht-app-github","title":"Bug","body":"It is broken"}`)
+	assert.NoError(t, err)
+	assert.Len(t, ct.githubSvc.createdIssues, 1)
+	issue := ct.githubSvc.createdIssues[0]
+	assert.True(t, svc.IsChatIssue(&libgithub.User{Type: lo.ToPtr("Bot")}, issue.GetBody()))
+
+	found, err := ct.threadStore.Find(context.TODO(), thread.NewKey("channel-io", "cht-app-github", issue.GetNumber()))
+	assert.NoError(t, err)
+	assert.Equal(t, &thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: "message-1"}, found)
+	assert.Len(t, ct.channelSvc.written, 1)
+	assert.True(t, strings.HasPrefix(ct.channelSvc.written[0], "message-1 "))
+}
+
+// 입력한 repository 의 대소문자와 관계없이 installation 의 organization 으로 issue 를 작성하고 thread 를 연결합니다.
+func TestIssueFunction_CreateIssueInMixedCaseRepository(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.runWithAttributes("githubCreateIssue", "1", map[string]string{messageIDAttribute: "message-1"}, `{"repository":"Channel-IO/CHT-App-GitHub","title":"Bug"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"channel-io"}, ct.githubSvc.issueOrgs)
+
+	found, err := ct.threadStore.Find(context.TODO(), thread.NewKey("channel-io", "cht-app-github", ct.githubSvc.createdIssues[0].GetNumber()))
+	assert.NoError(t, err)
+	assert.Equal(t, &thread.Thread{ChannelID: "1", GroupID: "10", RootMessageID: "message-1"}, found)
+}
+
+func TestIssueFunction_AppNotInstalled(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.runWithAttributes("githubCreateIssue", "1", nil, `{"repository":"unknown-org/cht-app-github","title":"Bug"}`)
+	assert.ErrorContains(t, err, "github app is not installed in Org(unknown-org)")
+	assert.Empty(t, ct.githubSvc.createdIssues)
+}
+
+func TestIssueFunction_UnverifiedGithubUsername(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.runWithAttributes("githubCreateIssue", "3", map[string]string{messageIDAttribute: "message-1"}, `{"repository":"channel-io/cht-app-github","title":"Bug"}`)
+	assert.ErrorContains(t, err, "github user lento has no verified email")
+	assert.Empty(t, ct.githubSvc.createdIssues)
+}
+
+func TestIssueFunction_RepositoryOfOtherChannel(t *testing.T) {
+	ct := newCommandTest(t)
+
+	err := ct.runWithAttributes("githubCreateIssue", "1", nil, `{"repository":"channel-io/ch-api","title":"Bug"}`)
+	assert.ErrorContains(t, err, "Repository(channel-io/ch-api) is not connected to this channel")
+	assert.Empty(t, ct.githubSvc.createdIssues)
+}
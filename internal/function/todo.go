@@ -1,37 +1,66 @@
 package function
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"sort"
+	"time"
 
+	libgithub "github.com/google/go-github/v60/github"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 
 	"github.com/channel-io/cht-app-github/internal/channel"
 	"github.com/channel-io/cht-app-github/internal/channel/client/appstore"
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/github"
 	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/template"
 )
 
+// todoSection 은 TODO 에 작성하는 목록입니다. query 의 %[1]s 는 github username 이고, 검색할 때 organization 조건을 붙입니다.
+type todoSection struct {
+	name  template.Name
+	query string
+}
+
+// teamReviewRequestedQuery 의 %s 는 "org/team-slug" 입니다.
+const teamReviewRequestedQuery = "type:pr state:open team-review-requested:%s"
+
+var todoSections = []todoSection{
+	// NOTE : team 에 요청된 review 는 team 별 목록에 작성하기 때문에, 직접 요청받은 review 만 검색합니다.
+	{name: template.TODOReviewRequested, query: "type:pr state:open user-review-requested:%[1]s"},
+	{name: template.TODOAssignedPullRequests, query: "type:pr state:open assignee:%[1]s"},
+	{name: template.TODOChangesRequested, query: "type:pr state:open author:%[1]s review:changes_requested"},
+	{name: template.TODOFailingChecks, query: "type:pr state:open author:%[1]s status:failure"},
+	{name: template.TODOApproved, query: "type:pr state:open author:%[1]s review:approved"},
+	{name: template.TODOAssignedIssues, query: "type:issue state:open assignee:%[1]s"},
+	// NOTE : github app 은 사용자의 notification 을 읽을 수 없어서, 읽지 않은 멘션 대신 사용자를 멘션한 열린 issue 를 작성합니다.
+	{name: template.TODOMentions, query: "state:open mentions:%[1]s -author:%[1]s"},
+}
+
 type TODOFunction struct {
-	logger     logger.Logger
-	githubSvc  github.Service
-	channelSvc channel.Service
+	logger         logger.Logger
+	githubSvc      github.Service
+	channelSvc     channel.Service
+	templateEngine *template.Engine
+	now            func() time.Time
 }
 
-func NewTODOFunction(logger logger.Logger, githubSvc github.Service, channelSvc channel.Service) *TODOFunction {
+func NewTODOFunction(logger logger.Logger, githubSvc github.Service, channelSvc channel.Service, templateEngine *template.Engine) *TODOFunction {
 	return &TODOFunction{
-		logger:     logger,
-		githubSvc:  githubSvc,
-		channelSvc: channelSvc,
+		logger:         logger,
+		githubSvc:      githubSvc,
+		channelSvc:     channelSvc,
+		templateEngine: templateEngine,
+		now:            time.Now,
 	}
 }
 
 func (f *TODOFunction) Register(registry HandlerRegistry) {
 	registry.Register("githubTODO", f.githubTODO)
+	registry.Register("githubTeamQueue", f.githubTeamQueue)
 }
 
 func (f *TODOFunction) githubTODO(
@@ -39,162 +68,275 @@ func (f *TODOFunction) githubTODO(
 	params json.RawMessage,
 	fnCtx appstore.Context,
 ) error {
-	if fnCtx.Caller.Type != appstore.ManagerCallerType {
-		f.logger.Errorw("caller type %s is not manager ", fnCtx.Caller.Type)
-		return errors.Errorf("caller type %s is not manager ", fnCtx.Caller.Type)
-	}
-
-	var fnParams appstore.CommandParams
-	if err := json.Unmarshal(params, &fnParams); err != nil {
-		return err
-	}
-	todoParams := TODOParams{}
-	if err := json.Unmarshal(fnParams.Input, &todoParams); err != nil {
+	req, err := f.parseRequest(ctx, params, fnCtx)
+	if err != nil {
 		return err
 	}
 
-	manager, err := f.channelSvc.FetchManagerByManagerID(ctx, fnCtx.Channel.ID, fnCtx.Caller.ID)
+	// 1. root message 전송
+	messageID, err := f.writeRootMessage(ctx, req, template.TODORoot)
 	if err != nil {
 		return err
 	}
-	if manager.GithubUsername == nil {
-		return errors.New("github-username property not found.")
-	}
 
-	gitHubOrg := f.fetchGitHubOrg(todoParams, manager)
-	if gitHubOrg == nil {
-		return errors.New("function parameter is required when github-organization property is not set.")
+	// 2. 목록 별로 thread message 전송
+	written := false
+	for _, section := range todoSections {
+		issues, err := f.searchIssues(ctx, req.installCtxs, fmt.Sprintf(section.query, req.username))
+		if err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			continue
+		}
+		message, err := f.buildSectionMessage(req.group.ChannelID, section.name, template.TODOData{}, issues)
+		if err != nil {
+			return err
+		}
+		if err := f.channelSvc.WriteThreadMessage(ctx, req.group, messageID, message, false); err != nil {
+			return err
+		}
+		written = true
 	}
 
-	// 1. root message 전송
-	rootMessage := f.buildRootMessage(manager)
-	messageID, err := f.channelSvc.WriteMessage(ctx, model.Group{
-		ChannelID: fnCtx.Channel.ID,
-		ID:        fnParams.Chat.ID,
-	}, rootMessage)
+	// 3. manager 가 속한 team 에 요청된 review 전송
+	teams, err := f.listTeams(ctx, req)
 	if err != nil {
 		return err
 	}
-
-	// 2. thread message 전송
-	assignedMessage, err := f.BuildAssignedPRMessage(ctx, fnCtx, *gitHubOrg, manager)
+	teamWritten, err := f.writeTeamSections(ctx, req, messageID, teams)
 	if err != nil {
 		return err
 	}
-	err = f.channelSvc.WriteThreadMessage(ctx, model.Group{
-		ChannelID: fnCtx.Channel.ID,
-		ID:        fnParams.Chat.ID,
-	}, messageID, assignedMessage, false)
+	if written || teamWritten {
+		return nil
+	}
+	return f.writeNotice(ctx, req, messageID, template.TODOEmpty)
+}
+
+// githubTeamQueue 는 manager 가 속한 github team 별로 review 를 기다리는 pull request 를 작성합니다.
+func (f *TODOFunction) githubTeamQueue(
+	ctx context.Context,
+	params json.RawMessage,
+	fnCtx appstore.Context,
+) error {
+	req, err := f.parseRequest(ctx, params, fnCtx)
 	if err != nil {
 		return err
 	}
 
-	requestedReviewsMessage, err := f.BuildReviewRequestedPRMessage(ctx, fnCtx, *gitHubOrg, manager)
+	messageID, err := f.writeRootMessage(ctx, req, template.TODOTeamQueueRoot)
 	if err != nil {
 		return err
 	}
-
-	err = f.channelSvc.WriteThreadMessage(ctx, model.Group{
-		ChannelID: fnCtx.Channel.ID,
-		ID:        fnParams.Chat.ID,
-	}, messageID, requestedReviewsMessage, false)
+	teams, err := f.listTeams(ctx, req)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	if len(teams) == 0 {
+		return f.writeNotice(ctx, req, messageID, template.TODONoTeams)
+	}
+	written, err := f.writeTeamSections(ctx, req, messageID, teams)
+	if err != nil || written {
+		return err
+	}
+	return f.writeNotice(ctx, req, messageID, template.TODOEmpty)
 }
 
-type TODOParams struct {
-	GitHubOrganization string `json:"gitHubOrganization"`
+// todoRequest 는 function 을 호출한 manager 와 검색할 organization, message 를 작성할 group 입니다.
+type todoRequest struct {
+	manager     model.Manager
+	username    string
+	installCtxs []github.InstallationContext
+	group       model.Group
 }
 
-func (f *TODOFunction) buildRootMessage(manager model.Manager) *model.Message {
-	content := fmt.Sprintf(":four_leaf_clover: Hello %s. Here's your TODO\r\n", model.Mention(model.MentionTypeManager, manager.ID, manager.Name))
-	return model.NewMessage(model.NewTextBlock(content))
-}
+func (f *TODOFunction) parseRequest(ctx context.Context, params json.RawMessage, fnCtx appstore.Context) (*todoRequest, error) {
+	if fnCtx.Caller.Type != appstore.ManagerCallerType {
+		f.logger.Errorw("caller type %s is not manager ", fnCtx.Caller.Type)
+		return nil, errors.Errorf("caller type %s is not manager ", fnCtx.Caller.Type)
+	}
 
-func (f *TODOFunction) BuildReviewRequestedPRMessage(ctx context.Context, fnCtx appstore.Context, gitHubOrg string, manager model.Manager) (*model.Message, error) {
-	installationID, err := f.githubSvc.FindAppInstallationID(ctx, gitHubOrg)
-	if err != nil {
+	var fnParams appstore.CommandParams
+	if err := json.Unmarshal(params, &fnParams); err != nil {
 		return nil, err
 	}
-	if installationID == nil {
-		return nil, nil
+	todoParams := TODOParams{}
+	if err := json.Unmarshal(fnParams.Input, &todoParams); err != nil {
+		return nil, err
 	}
-	installationContext := github.NewInstallationContext(*installationID, gitHubOrg)
 
-	issues, err := f.githubSvc.ListReviewRequestedPullRequest(ctx, installationContext, *manager.GithubUsername)
+	manager, err := f.channelSvc.FetchManagerByManagerID(ctx, fnCtx.Channel.ID, fnCtx.Caller.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	var mdContent bytes.Buffer
-	if len(issues) > 0 {
-		mdContent.WriteString("## Here's pull requests waiting for your review.\r\n")
-		for _, issue := range issues {
-			if !issue.GetDraft() {
-				// NOTE : search 결과에는 repository 정보가 함께 오지 않습니다.
-				// 따라서, Repository URL 로부터 repo name 을 암묵적으로 파싱합니다.
-				split := strings.Split(issue.GetRepositoryURL(), "/")
-				repoName := split[len(split)-1]
-				mdContent.WriteString(fmt.Sprintf("* [%s] [%s]( %s)\r\n", repoName, issue.GetTitle(), issue.GetHTMLURL()))
-			}
-		}
-	} else {
-		mdContent.WriteString("No Pull Requests review requested to you yet.")
+	if manager.GithubUsername == nil {
+		return nil, errors.New("github-username property not found.")
 	}
 
-	messageBlocks, err := f.channelSvc.BuildMessageBlocksFromMarkdown(ctx, fnCtx.Channel.ID, mdContent.Bytes())
+	installCtxs, err := f.findInstallations(ctx, todoParams, manager)
 	if err != nil {
 		return nil, err
 	}
+	return &todoRequest{
+		manager:     manager,
+		username:    *manager.GithubUsername,
+		installCtxs: installCtxs,
+		group: model.Group{
+			ChannelID: fnCtx.Channel.ID,
+			ID:        fnParams.Chat.ID,
+		},
+	}, nil
+}
 
-	return model.NewMessage(messageBlocks...), nil
+// TODOParams 의 GitHubOrganization 을 지정하면 해당 organization 만 검색합니다.
+type TODOParams struct {
+	GitHubOrganization string `json:"gitHubOrganization"`
 }
 
-func (f *TODOFunction) BuildAssignedPRMessage(ctx context.Context, fnCtx appstore.Context, gitHubOrg string, manager model.Manager) (*model.Message, error) {
-	installationID, err := f.githubSvc.FindAppInstallationID(ctx, gitHubOrg)
+// findInstallations 는 검색할 organization 을 다음 순서로 찾습니다.
+//  1. function parameter 의 organization
+//  2. manager 의 github-organization property
+//  3. app 이 설치된 모든 organization
+func (f *TODOFunction) findInstallations(ctx context.Context, todoParams TODOParams, manager model.Manager) ([]github.InstallationContext, error) {
+	org := todoParams.GitHubOrganization
+	if org == "" {
+		org = lo.FromPtr(manager.GithubOrganization)
+	}
+	if org == "" {
+		return f.githubSvc.ListOrgInstallations(ctx)
+	}
+
+	installationID, err := f.githubSvc.FindAppInstallationID(ctx, org)
 	if err != nil {
 		return nil, err
 	}
 	if installationID == nil {
-		return nil, nil
+		return nil, errors.Errorf("github app is not installed in Org(%s)", org)
+	}
+	return []github.InstallationContext{github.NewInstallationContext(*installationID, org)}, nil
+}
+
+// searchIssues 는 모든 organization 에서 query 로 검색한 issue 를 오래된 순서로 반환합니다.
+func (f *TODOFunction) searchIssues(ctx context.Context, installCtxs []github.InstallationContext, query string) ([]*libgithub.Issue, error) {
+	var issues []*libgithub.Issue
+	for _, installCtx := range installCtxs {
+		found, err := f.githubSvc.SearchIssues(ctx, installCtx, fmt.Sprintf("%s org:%s", query, installCtx.OrgLogin))
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
 	}
-	installationContext := github.NewInstallationContext(*installationID, gitHubOrg)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].GetCreatedAt().Before(issues[j].GetCreatedAt().Time)
+	})
+	return issues, nil
+}
 
-	issues, err := f.githubSvc.ListAssignedPullRequest(ctx, installationContext, *manager.GithubUsername)
+func (f *TODOFunction) writeRootMessage(ctx context.Context, req *todoRequest, name template.Name) (string, error) {
+	content, err := f.templateEngine.RenderForChannel(req.group.ChannelID, name, template.TODOData{
+		Manager: model.Mention(model.MentionTypeManager, req.manager.ID, req.manager.Name),
+	})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return f.channelSvc.WriteMessage(ctx, req.group, model.NewMessage(model.NewTextBlock(content)))
+}
 
-	var mdContent bytes.Buffer
-	if len(issues) > 0 {
-		mdContent.WriteString("## Here's your pull requests.\r\n")
-		for _, issue := range issues {
-			if !issue.GetDraft() && issue.GetState() == "open" {
-				// NOTE : search 결과에는 repository 정보가 함께 오지 않습니다.
-				// 따라서, Repository URL 로부터 repo name 을 암묵적으로 파싱합니다.
-				split := strings.Split(issue.GetRepositoryURL(), "/")
-				repoName := split[len(split)-1]
-				mdContent.WriteString(fmt.Sprintf("* [%s] [%s]( %s)\r\n", repoName, issue.GetTitle(), issue.GetHTMLURL()))
-			}
+func (f *TODOFunction) writeNotice(ctx context.Context, req *todoRequest, messageID string, name template.Name) error {
+	content, err := f.templateEngine.RenderForChannel(req.group.ChannelID, name, template.TODOData{})
+	if err != nil {
+		return err
+	}
+	return f.channelSvc.WriteThreadMessage(ctx, req.group, messageID, model.NewMessage(model.NewTextBlock(content)), false)
+}
+
+// userTeam 은 manager 가 속한 github team 과 team 의 organization 입니다.
+type userTeam struct {
+	installCtx github.InstallationContext
+	team       *libgithub.Team
+}
+
+func (t userTeam) name() string {
+	return fmt.Sprintf("%s/%s", t.installCtx.OrgLogin, t.team.GetSlug())
+}
+
+func (f *TODOFunction) listTeams(ctx context.Context, req *todoRequest) ([]userTeam, error) {
+	var teams []userTeam
+	for _, installCtx := range req.installCtxs {
+		found, err := f.githubSvc.ListUserTeams(ctx, installCtx, req.username)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range found {
+			teams = append(teams, userTeam{installCtx: installCtx, team: team})
 		}
-	} else {
-		mdContent.WriteString("No Pull Requests assigned to you yet.")
 	}
+	return teams, nil
+}
 
-	messageBlocks, err := f.channelSvc.BuildMessageBlocksFromMarkdown(ctx, fnCtx.Channel.ID, mdContent.Bytes())
-	if err != nil {
-		return nil, err
+// writeTeamSections 는 team 별로 review 를 요청받은 pull request 를 작성합니다. 작성한 목록이 있으면 true 를 반환합니다.
+func (f *TODOFunction) writeTeamSections(ctx context.Context, req *todoRequest, messageID string, teams []userTeam) (bool, error) {
+	written := false
+	for _, team := range teams {
+		issues, err := f.searchIssues(ctx, []github.InstallationContext{team.installCtx}, fmt.Sprintf(teamReviewRequestedQuery, team.name()))
+		if err != nil {
+			return false, err
+		}
+		if len(issues) == 0 {
+			continue
+		}
+		mention, err := f.buildTeamMention(ctx, req.group.ChannelID, team.name())
+		if err != nil {
+			return false, err
+		}
+		message, err := f.buildSectionMessage(req.group.ChannelID, template.TODOTeamReviewRequested, template.TODOData{Team: mention}, issues)
+		if err != nil {
+			return false, err
+		}
+		if err := f.channelSvc.WriteThreadMessage(ctx, req.group, messageID, message, false); err != nil {
+			return false, err
+		}
+		written = true
 	}
+	return written, nil
+}
 
-	return model.NewMessage(messageBlocks...), nil
+// buildTeamMention 은 github team 과 연결한 channel 의 team 을 멘션합니다. 연결한 team 이 없으면 github team 이름을 작성합니다.
+func (f *TODOFunction) buildTeamMention(ctx context.Context, channelID, githubTeam string) (string, error) {
+	team, err := f.channelSvc.FindTeamByGithubTeam(ctx, channelID, githubTeam)
+	if err != nil {
+		return "", err
+	}
+	if team == nil {
+		return model.EscapedString("@" + githubTeam), nil
+	}
+	return model.Mention(model.MentionTypeTeam, team.ID, team.Name), nil
 }
 
-func (f *TODOFunction) fetchGitHubOrg(todoParam TODOParams, manager model.Manager) *string {
-	if todoParam.GitHubOrganization != "" {
-		return &todoParam.GitHubOrganization
+// buildSectionMessage 는 목록 제목과 issue 목록입니다. draft 도 함께 작성합니다.
+func (f *TODOFunction) buildSectionMessage(channelID string, name template.Name, data template.TODOData, issues []*libgithub.Issue) (*model.Message, error) {
+	data.Count = len(issues)
+	title, err := f.templateEngine.RenderForChannel(channelID, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := f.now()
+	items := make([]model.MessageBlock, 0, len(issues))
+	for _, issue := range issues {
+		org, repository := github.SearchResultRepository(issue)
+		item, err := f.templateEngine.RenderForChannel(channelID, template.TODOItem, template.TODOItemData{
+			Repository: fmt.Sprintf("%s/%s", org, repository),
+			Title:      issue.GetTitle(),
+			URL:        issue.GetHTMLURL(),
+			Draft:      issue.GetDraft(),
+			Age:        template.FormatAge(now.Sub(issue.GetCreatedAt().Time)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, model.NewTextBlock(item))
 	}
-	return manager.GithubOrganization
+	return model.NewMessage(model.NewTextBlock(title), model.NewBulletsBlock(items)), nil
 }
@@ -0,0 +1,88 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	libgithub "github.com/google/go-github/v60/github"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel"
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/template"
+)
+
+func TestTODOFunction_BuildSectionMessage(t *testing.T) {
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+	conf := new(config.Config)
+	f := NewTODOFunction(nil, nil, nil, template.NewEngine(conf, nil, nil, nil))
+	f.now = func() time.Time { return now }
+
+	issues := []*libgithub.Issue{
+		{
+			Title:         libgithub.String("Add feature"),
+			HTMLURL:       libgithub.String("https://github.com/channel-io/ch-api/pull/1"),
+			RepositoryURL: libgithub.String("https://api.github.com/repos/channel-io/ch-api"),
+			CreatedAt:     &libgithub.Timestamp{Time: now.Add(-72 * time.Hour)},
+		},
+		{
+			Title:         libgithub.String("WIP"),
+			HTMLURL:       libgithub.String("https://github.com/other-org/web/pull/2"),
+			RepositoryURL: libgithub.String("https://api.github.com/repos/other-org/web"),
+			CreatedAt:     &libgithub.Timestamp{Time: now.Add(-5 * time.Hour)},
+			Draft:         libgithub.Bool(true),
+		},
+	}
+
+	message, err := f.buildSectionMessage("1", template.TODOReviewRequested, template.TODOData{}, issues)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.MessageBlock{
+		model.NewTextBlock(":eyes: " + model.Bold("Waiting for your review") + " (2)"),
+		model.NewBulletsBlock([]model.MessageBlock{
+			model.NewTextBlock(fmt.Sprintf("[channel-io/ch-api] %s · 3d", model.InlineLink(issues[0].GetHTMLURL(), "Add feature"))),
+			model.NewTextBlock(fmt.Sprintf("[other-org/web] %s (draft) · 5h", model.InlineLink(issues[1].GetHTMLURL(), "WIP"))),
+		}),
+	}, message.Blocks)
+}
+
+func TestTODOFunction_BuildTeamMention(t *testing.T) {
+	conf := new(config.Config)
+	conf.ChannelTalk.Teams = []config.TeamMapping{
+		{GithubTeam: "channel-io/backend", ChannelID: "1", TeamID: "10", Name: "Backend"},
+	}
+	engine := template.NewEngine(conf, nil, nil, nil)
+	f := NewTODOFunction(nil, nil, channel.NewServiceImpl(nil, nil, conf, engine), engine)
+
+	mention, err := f.buildTeamMention(context.TODO(), "1", "channel-io/backend")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Mention(model.MentionTypeTeam, "10", "Backend"), mention)
+
+	mention, err = f.buildTeamMention(context.TODO(), "1", "channel-io/frontend")
+	assert.NoError(t, err)
+	assert.Equal(t, "@channel-io/frontend", mention)
+}
+
+// function parameter, manager 의 github-organization property 순서로 organization 을 찾고, 둘 다 없으면 모든 organization 을 검색합니다.
+func TestTODOFunction_FindInstallations(t *testing.T) {
+	f := NewTODOFunction(nil, &fakeGithubSvc{}, nil, nil)
+	orgs := func(installCtxs []github.InstallationContext) []string {
+		return lo.Map(installCtxs, func(installCtx github.InstallationContext, _ int) string { return installCtx.OrgLogin })
+	}
+
+	installCtxs, err := f.findInstallations(context.TODO(), TODOParams{GitHubOrganization: "channel-io"}, model.Manager{GithubOrganization: lo.ToPtr("other-org")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"channel-io"}, orgs(installCtxs))
+
+	installCtxs, err = f.findInstallations(context.TODO(), TODOParams{}, model.Manager{GithubOrganization: lo.ToPtr("other-org")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other-org"}, orgs(installCtxs))
+
+	installCtxs, err = f.findInstallations(context.TODO(), TODOParams{}, model.Manager{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"channel-io", "other-org"}, orgs(installCtxs))
+}
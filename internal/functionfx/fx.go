@@ -11,6 +11,8 @@ func Module() fx.Option {
 		"function",
 		fx.Provide(
 			function.NewTODOFunction,
+			function.NewCommandFunction,
+			function.NewIssueFunction,
 			function.NewJsonFunctionDelegator,
 		),
 	)
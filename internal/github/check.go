@@ -0,0 +1,33 @@
+package github
+
+import "github.com/google/go-github/v60/github"
+
+const CommitCheckPending = "pending"
+
+// CommitCheck 는 commit 에 대한 check run 혹은 commit status 의 결과입니다.
+// Result 는 끝난 check run 의 conclusion 혹은 commit status 의 state 이며, 진행중인 경우 "pending" 입니다.
+type CommitCheck struct {
+	Name   string
+	URL    string
+	Result string
+}
+
+func newCommitCheckFromCheckRun(checkRun *github.CheckRun) CommitCheck {
+	result := CommitCheckPending
+	if checkRun.GetStatus() == "completed" {
+		result = checkRun.GetConclusion()
+	}
+	return CommitCheck{
+		Name:   checkRun.GetName(),
+		URL:    checkRun.GetHTMLURL(),
+		Result: result,
+	}
+}
+
+func newCommitCheckFromStatus(status *github.RepoStatus) CommitCheck {
+	return CommitCheck{
+		Name:   status.GetContext(),
+		URL:    status.GetTargetURL(),
+		Result: status.GetState(),
+	}
+}
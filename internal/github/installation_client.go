@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
 
@@ -71,6 +70,146 @@ func (c *InstallationClient) FindCustomProperty(ctx context.Context, repository,
 	return "", errors.Errorf("%s custom property required in Org(%s) Repository(%s)", key, c.installationContext.OrgLogin, repository)
 }
 
+func (c *InstallationClient) ListCustomProperties(ctx context.Context, repository string) (map[string]string, error) {
+	values, res, err := c.Repositories.GetAllCustomPropertyValues(ctx, c.installationContext.OrgLogin, repository)
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.onResponse(c.installationContext, "repo.get_all_custom_property_values", res, err)
+
+	properties := make(map[string]string, len(values))
+	for _, value := range values {
+		if value.Value != nil {
+			properties[value.PropertyName] = *value.Value
+		}
+	}
+	return properties, nil
+}
+
+// FindFileContent 는 repository 의 기본 브랜치에서 파일 내용을 읽습니다. 파일이 없으면 nil 을 반환합니다.
+func (c *InstallationClient) FindFileContent(ctx context.Context, repository, path string) ([]byte, error) {
+	file, _, res, err := c.Repositories.GetContents(ctx, c.installationContext.OrgLogin, repository, path, nil)
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.onResponse(c.installationContext, "repo.get_contents", res, err)
+
+	if file == nil {
+		return nil, errors.Errorf("%s is not a file in Org(%s) Repository(%s)", path, c.installationContext.OrgLogin, repository)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+func (c *InstallationClient) ListPullRequestFiles(ctx context.Context, repository string, number int) ([]string, error) {
+	var results []string
+	nextPage := 1
+	for {
+		files, res, err := c.PullRequests.ListFiles(ctx, c.installationContext.OrgLogin, repository, number, &github.ListOptions{
+			Page:    nextPage,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.onResponse(c.installationContext, "pull_request.list_files", res, err)
+
+		for _, file := range files {
+			results = append(results, file.GetFilename())
+		}
+
+		nextPage = res.NextPage
+		if nextPage == 0 {
+			break
+		}
+	}
+	return results, nil
+}
+
+// ListWorkflowJobs 는 workflow run 의 마지막 시도에서 실행된 job 들을 조회합니다.
+func (c *InstallationClient) ListWorkflowJobs(ctx context.Context, repository string, runID int64) ([]*github.WorkflowJob, error) {
+	var results []*github.WorkflowJob
+	nextPage := 1
+	for {
+		jobs, res, err := c.Actions.ListWorkflowJobs(ctx, c.installationContext.OrgLogin, repository, runID, &github.ListWorkflowJobsOptions{
+			Filter: "latest",
+			ListOptions: github.ListOptions{
+				Page:    nextPage,
+				PerPage: 100,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.onResponse(c.installationContext, "actions.list_workflow_jobs", res, err)
+
+		results = append(results, jobs.Jobs...)
+
+		nextPage = res.NextPage
+		if nextPage == 0 {
+			break
+		}
+	}
+	return results, nil
+}
+
+// ListCheckRunsForRef 는 commit 의 check run 들 중 check 이름마다 가장 최근의 것을 조회합니다.
+func (c *InstallationClient) ListCheckRunsForRef(ctx context.Context, repository, ref string) ([]*github.CheckRun, error) {
+	var results []*github.CheckRun
+	nextPage := 1
+	for {
+		checkRuns, res, err := c.Checks.ListCheckRunsForRef(ctx, c.installationContext.OrgLogin, repository, ref, &github.ListCheckRunsOptions{
+			Filter: github.String("latest"),
+			ListOptions: github.ListOptions{
+				Page:    nextPage,
+				PerPage: 100,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.onResponse(c.installationContext, "checks.list_check_runs_for_ref", res, err)
+
+		results = append(results, checkRuns.CheckRuns...)
+
+		nextPage = res.NextPage
+		if nextPage == 0 {
+			break
+		}
+	}
+	return results, nil
+}
+
+// ListStatusesForRef 는 commit 의 status 들 중 context 마다 가장 최근의 것을 조회합니다.
+func (c *InstallationClient) ListStatusesForRef(ctx context.Context, repository, ref string) ([]*github.RepoStatus, error) {
+	var results []*github.RepoStatus
+	nextPage := 1
+	for {
+		combined, res, err := c.Repositories.GetCombinedStatus(ctx, c.installationContext.OrgLogin, repository, ref, &github.ListOptions{
+			Page:    nextPage,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.onResponse(c.installationContext, "repository.get_combined_status", res, err)
+
+		results = append(results, combined.Statuses...)
+
+		nextPage = res.NextPage
+		if nextPage == 0 {
+			break
+		}
+	}
+	return results, nil
+}
+
 // TODO @Dylan : list order 재확인 필요.
 func (c *InstallationClient) FindAllCommentsOnIssue(ctx context.Context, repository string, number int) ([]*github.IssueComment, error) {
 	comments, res, err := c.Issues.ListComments(ctx, c.installationContext.OrgLogin, repository, number, nil)
@@ -106,22 +245,225 @@ func (c *InstallationClient) AddAssigneeToIssue(ctx context.Context, repository
 	return nil
 }
 
-func (c *InstallationClient) ListReviewRequestedPullRequests(ctx context.Context, user string) ([]*github.Issue, error) {
-	query := fmt.Sprintf("type:pr state:open org:%s review-requested:%s", c.installationContext.OrgLogin, user)
-	result, response, err := c.Search.Issues(ctx, query, nil)
+func (c *InstallationClient) CreateIssue(ctx context.Context, repository, title, body string, labels, assignees []string) (*github.Issue, error) {
+	request := &github.IssueRequest{
+		Title: &title,
+		Body:  &body,
+	}
+	if len(labels) > 0 {
+		request.Labels = &labels
+	}
+	if len(assignees) > 0 {
+		request.Assignees = &assignees
+	}
+	issue, res, err := c.Issues.Create(ctx, c.installationContext.OrgLogin, repository, request)
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.onResponse(c.installationContext, "issue.create", res, err)
+	return issue, nil
+}
+
+func (c *InstallationClient) FindIssue(ctx context.Context, repository string, number int) (*github.Issue, error) {
+	issue, res, err := c.Issues.Get(ctx, c.installationContext.OrgLogin, repository, number)
 	if err != nil {
 		return nil, err
 	}
-	c.metrics.onResponse(c.installationContext, "org.search_pull_requests", response, err)
-	return result.Issues, nil
+	c.metrics.onResponse(c.installationContext, "issue.get", res, err)
+	return issue, nil
+}
+
+// FindPermissionLevel 은 user 의 repository 권한(admin, write, read, none)을 조회합니다.
+func (c *InstallationClient) FindPermissionLevel(ctx context.Context, repository, user string) (string, error) {
+	level, res, err := c.Repositories.GetPermissionLevel(ctx, c.installationContext.OrgLogin, repository, user)
+	if err != nil {
+		return "", err
+	}
+	c.metrics.onResponse(c.installationContext, "repository.get_permission_level", res, err)
+	return level.GetPermission(), nil
 }
 
-func (c *InstallationClient) ListOpenedPullRequests(ctx context.Context, user string) ([]*github.Issue, error) {
-	query := fmt.Sprintf("type:pr state:open org:%s assignee:%s", c.installationContext.OrgLogin, user)
-	result, response, err := c.Search.Issues(ctx, query, nil)
+// CreateReview 는 event(APPROVE, REQUEST_CHANGES, COMMENT)로 pull request 에 review 를 작성합니다.
+func (c *InstallationClient) CreateReview(ctx context.Context, repository string, number int, event, body string) error {
+	review := &github.PullRequestReviewRequest{Event: &event}
+	if body != "" {
+		review.Body = &body
+	}
+	_, res, err := c.PullRequests.CreateReview(ctx, c.installationContext.OrgLogin, repository, number, review)
+	if err != nil {
+		return err
+	}
+	c.metrics.onResponse(c.installationContext, "pull_request.create_review", res, err)
+	return nil
+}
+
+func (c *InstallationClient) AddLabelsToIssue(ctx context.Context, repository string, number int, labels []string) error {
+	_, res, err := c.Issues.AddLabelsToIssue(ctx, c.installationContext.OrgLogin, repository, number, labels)
+	if err != nil {
+		return err
+	}
+	c.metrics.onResponse(c.installationContext, "issue.add_labels", res, err)
+	return nil
+}
+
+func (c *InstallationClient) RemoveLabelFromIssue(ctx context.Context, repository string, number int, label string) error {
+	res, err := c.Issues.RemoveLabelForIssue(ctx, c.installationContext.OrgLogin, repository, number, label)
+	if err != nil {
+		return err
+	}
+	c.metrics.onResponse(c.installationContext, "issue.remove_label", res, err)
+	return nil
+}
+
+// EditIssueState 는 issue(pull request)의 state 를 open 혹은 closed 로 변경합니다.
+func (c *InstallationClient) EditIssueState(ctx context.Context, repository string, number int, state string) error {
+	_, res, err := c.Issues.Edit(ctx, c.installationContext.OrgLogin, repository, number, &github.IssueRequest{State: &state})
+	if err != nil {
+		return err
+	}
+	c.metrics.onResponse(c.installationContext, "issue.edit", res, err)
+	return nil
+}
+
+// MergePullRequest 는 method(merge, squash, rebase)로 pull request 를 merge 합니다.
+func (c *InstallationClient) MergePullRequest(ctx context.Context, repository string, number int, method string) error {
+	result, res, err := c.PullRequests.Merge(ctx, c.installationContext.OrgLogin, repository, number, "", &github.PullRequestOptions{MergeMethod: method})
+	if err != nil {
+		return err
+	}
+	c.metrics.onResponse(c.installationContext, "pull_request.merge", res, err)
+	if !result.GetMerged() {
+		return errors.Errorf("failed to merge pull request #%d in Repository(%s): %s", number, repository, result.GetMessage())
+	}
+	return nil
+}
+
+// SearchIssues 는 query 로 검색한 issue(pull request)를 모든 page 에서 읽습니다. github 은 최대 1000개까지 검색합니다.
+func (c *InstallationClient) SearchIssues(ctx context.Context, query string) ([]*github.Issue, error) {
+	opts := &github.SearchOptions{
+		Sort:        "created",
+		Order:       "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var issues []*github.Issue
+	for {
+		result, response, err := c.Search.Issues(ctx, query, opts)
+		c.metrics.onResponse(c.installationContext, "org.search_issues", response, err)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, result.Issues...)
+		if response.NextPage == 0 {
+			return issues, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// ListUserTeams 는 organization 에서 user 가 member 인 team 을 읽습니다.
+// NOTE : REST api 로는 team 마다 membership 을 확인해야 해서, userLogins 로 team 을 거르는 graphql api 를 사용합니다.
+func (c *InstallationClient) ListUserTeams(ctx context.Context, user string) ([]*github.Team, error) {
+	var (
+		teams  []*github.Team
+		cursor *string
+	)
+	for {
+		req, err := c.NewRequest(http.MethodPost, "graphql", map[string]any{
+			"query": `query($org: String!, $login: String!, $cursor: String) {
+  organization(login: $org) {
+    teams(first: 100, userLogins: [$login], after: $cursor) {
+      nodes { slug name }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`,
+			"variables": map[string]any{
+				"org":    c.installationContext.OrgLogin,
+				"login":  user,
+				"cursor": cursor,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Data struct {
+				Organization *struct {
+					Teams struct {
+						Nodes []struct {
+							Slug string `json:"slug"`
+							Name string `json:"name"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"teams"`
+				} `json:"organization"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		res, err := c.Do(ctx, req, &result)
+		c.metrics.onResponse(c.installationContext, "graphql.organization_teams", res, err)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Errors) > 0 {
+			return nil, errors.Errorf("failed to list teams of %s in Org(%s): %s", user, c.installationContext.OrgLogin, result.Errors[0].Message)
+		}
+		if result.Data.Organization == nil {
+			return nil, nil
+		}
+
+		for _, node := range result.Data.Organization.Teams.Nodes {
+			teams = append(teams, &github.Team{Slug: github.String(node.Slug), Name: github.String(node.Name)})
+		}
+		pageInfo := result.Data.Organization.Teams.PageInfo
+		if !pageInfo.HasNextPage {
+			return teams, nil
+		}
+		cursor = github.String(pageInfo.EndCursor)
+	}
+}
+
+// ListVerifiedDomainEmails 는 organization 의 verified domain 에 속한 user 의 email 을 조회합니다.
+// organization 이 domain 을 인증하지 않았거나 user 가 member 가 아니면 비어있습니다.
+// NOTE : REST api 로는 다른 user 의 비공개 email 을 조회할 수 없어 graphql api 를 사용합니다.
+func (c *InstallationClient) ListVerifiedDomainEmails(ctx context.Context, user string) ([]string, error) {
+	req, err := c.NewRequest(http.MethodPost, "graphql", map[string]any{
+		"query": `query($login: String!, $org: String!) { user(login: $login) { organizationVerifiedDomainEmails(login: $org) } }`,
+		"variables": map[string]string{
+			"login": user,
+			"org":   c.installationContext.OrgLogin,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data struct {
+			User *struct {
+				OrganizationVerifiedDomainEmails []string `json:"organizationVerifiedDomainEmails"`
+			} `json:"user"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	res, err := c.Do(ctx, req, &result)
+	c.metrics.onResponse(c.installationContext, "graphql.organization_verified_domain_emails", res, err)
 	if err != nil {
 		return nil, err
 	}
-	c.metrics.onResponse(c.installationContext, "org.search_pull_requests", response, err)
-	return result.Issues, nil
+	if len(result.Errors) > 0 {
+		return nil, errors.Errorf("failed to find verified domain emails of %s in Org(%s): %s", user, c.installationContext.OrgLogin, result.Errors[0].Message)
+	}
+	if result.Data.User == nil {
+		return nil, nil
+	}
+	return result.Data.User.OrganizationVerifiedDomainEmails, nil
 }
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
 
 	"github.com/channel-io/cht-app-github/internal/channel/model"
 	"github.com/channel-io/cht-app-github/internal/config"
@@ -17,22 +20,39 @@ import (
 )
 
 const (
-	rootMessageIdCacheKey = "RootMessageID"
+	rootMessageIdCacheKey    = "RootMessageID"
+	customPropertiesCacheKey = "CustomProperties"
 )
 
 type Service interface {
 	// channel talk integration
-	FindRootMessageID(ctx context.Context, installCtx InstallationContext, repository string, issueNumber int, retry int) (*string, error)
+	FindRootMessageID(ctx context.Context, installCtx InstallationContext, repository string, issueNumber int) (*string, error)
 	FindGroup(ctx context.Context, ghContext InstallationContext, repository string) (model.Group, error)
 	FindReleaseGroup(ctx context.Context, ghContext InstallationContext, repository string) (model.Group, error)
+	FindCustomProperties(ctx context.Context, installCtx InstallationContext, repository string) (map[string]string, error)
+	FindTeamMapping(ctx context.Context, installCtx InstallationContext, repository string) (map[string]string, error)
+	FindRepositoryFile(ctx context.Context, installCtx InstallationContext, repository, path string) ([]byte, error)
 
 	CreateComment(ctx context.Context, installCtx InstallationContext, repository string, number int, body string) error
 	ListPullRequestNumberByCommitSHA(ctx context.Context, installCtx InstallationContext, repoName, sha string, predicates ...FilterPullRequestPredicate) ([]*github.PullRequest, error)
 	FetchPullRequest(ctx context.Context, installCtx InstallationContext, repository string, number int) (*github.PullRequest, error)
+	ListPullRequestFiles(ctx context.Context, installCtx InstallationContext, repository string, number int) ([]string, error)
+	ListWorkflowJobs(ctx context.Context, installCtx InstallationContext, repository string, runID int64) ([]*github.WorkflowJob, error)
+	ListCommitChecks(ctx context.Context, installCtx InstallationContext, repository, sha string) ([]CommitCheck, error)
 	AddAssigneeToIssue(ctx context.Context, installCtx InstallationContext, repository string, number int, assignees []string) error
+	CreateIssue(ctx context.Context, installCtx InstallationContext, repository, title, body string, labels, assignees []string) (*github.Issue, error)
+	FetchIssue(ctx context.Context, installCtx InstallationContext, repository string, number int) (*github.Issue, error)
+	FindPermissionLevel(ctx context.Context, installCtx InstallationContext, repository, user string) (string, error)
+	CreateReview(ctx context.Context, installCtx InstallationContext, repository string, number int, event, body string) error
+	AddLabelsToIssue(ctx context.Context, installCtx InstallationContext, repository string, number int, labels []string) error
+	RemoveLabelFromIssue(ctx context.Context, installCtx InstallationContext, repository string, number int, label string) error
+	EditIssueState(ctx context.Context, installCtx InstallationContext, repository string, number int, state string) error
+	MergePullRequest(ctx context.Context, installCtx InstallationContext, repository string, number int, method string) error
 	FindAppInstallationID(ctx context.Context, org string) (*int64, error)
-	ListReviewRequestedPullRequest(ctx context.Context, installCtx InstallationContext, user string) ([]*github.Issue, error)
-	ListAssignedPullRequest(ctx context.Context, installCtx InstallationContext, user string) ([]*github.Issue, error)
+	ListOrgInstallations(ctx context.Context) ([]InstallationContext, error)
+	SearchIssues(ctx context.Context, installCtx InstallationContext, query string) ([]*github.Issue, error)
+	ListUserTeams(ctx context.Context, installCtx InstallationContext, username string) ([]*github.Team, error)
+	ListVerifiedDomainEmails(ctx context.Context, installCtx InstallationContext, username string) ([]string, error)
 }
 
 type ServiceImpl struct {
@@ -40,11 +60,13 @@ type ServiceImpl struct {
 	channelIDKey      string
 	groupIDKey        string
 	releaseGroupIDKey string
+	teamsKey          string
 	privateKey        []byte
 
 	installationClientPool map[InstallationContext]*InstallationClient
 	appClient              *AppClient
 	customPropertyCache    Cache[string]
+	customPropertiesCache  Cache[map[string]string]
 	installationIDCache    Cache[int64]
 	metrics                *ClientMetrics
 }
@@ -68,32 +90,21 @@ func NewServiceImpl(conf *config.Config, metrics *ClientMetrics) *ServiceImpl {
 		channelIDKey:           conf.Github.Properties.ChannelIdKey,
 		groupIDKey:             conf.Github.Properties.GroupIdKey,
 		releaseGroupIDKey:      conf.Github.Properties.ReleaseGroupIdKey,
+		teamsKey:               conf.Github.Properties.TeamsKey,
 		privateKey:             privateKey,
 		installationClientPool: make(map[InstallationContext]*InstallationClient),
 		//appClient:              appClient,
-		customPropertyCache: cache.NewLocalCache[string](),
-		installationIDCache: cache.NewLocalCache[int64](),
-		metrics:             metrics,
+		customPropertyCache:   cache.NewLocalCache[string](),
+		customPropertiesCache: cache.NewLocalCache[map[string]string](),
+		installationIDCache:   cache.NewLocalCache[int64](),
+		metrics:               metrics,
 	}
 }
 
-func (s *ServiceImpl) FindRootMessageID(ctx context.Context, installCtx InstallationContext, repository string, issueNumber int, tryCount int) (*string, error) {
-	for {
-		if tryCount <= 0 {
-			break
-		}
-		messageId, err := s.findMessageIdFromComments(ctx, installCtx, repository, issueNumber)
-		if err != nil {
-			return nil, err
-		}
-		if messageId != nil {
-			return messageId, nil
-		}
-
-		tryCount--
-		time.Sleep(1 * time.Second)
-	}
-	return nil, nil
+// FindRootMessageID 는 app 이 issue 에 작성한 comment 의 desk url 에서 root message id 를 찾습니다.
+// thread store 도입 이전에 생성된 thread 를 찾을 때만 사용합니다.
+func (s *ServiceImpl) FindRootMessageID(ctx context.Context, installCtx InstallationContext, repository string, issueNumber int) (*string, error) {
+	return s.findMessageIdFromComments(ctx, installCtx, repository, issueNumber)
 }
 
 func (s *ServiceImpl) findMessageIdFromComments(ctx context.Context, installCtx InstallationContext, repository string, number int) (*string, error) {
@@ -175,6 +186,105 @@ func (s *ServiceImpl) findCustomProperty(ctx context.Context, installCtx Install
 	return value, nil
 }
 
+func (s *ServiceImpl) FindCustomProperties(ctx context.Context, installCtx InstallationContext, repository string) (map[string]string, error) {
+	cached, err := s.customPropertiesCache.Get(ctx, s.cacheKeyForRepository(installCtx, repository, customPropertiesCacheKey))
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return *cached, nil
+	}
+
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := client.ListCustomProperties(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.customPropertiesCache.Set(ctx, s.cacheKeyForRepository(installCtx, repository, customPropertiesCacheKey), properties, 60*time.Minute)
+	return properties, nil
+}
+
+// FindTeamMapping 은 repository 의 teamsKey custom property 에 작성한 github team slug 별 channel team(id 혹은 이름)입니다.
+// 값은 YAML map 입니다. ex) {backend: Backend, frontend: "678"}
+func (s *ServiceImpl) FindTeamMapping(ctx context.Context, installCtx InstallationContext, repository string) (map[string]string, error) {
+	if s.teamsKey == "" {
+		return nil, nil
+	}
+	properties, err := s.FindCustomProperties(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := parseTeamMapping(properties[s.teamsKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse custom property %s in Org(%s) Repository(%s)", s.teamsKey, installCtx.OrgLogin, repository)
+	}
+	return mapping, nil
+}
+
+// parseTeamMapping 은 team slug 를 소문자로 바꿔서 반환합니다.
+func parseTeamMapping(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var mapping map[string]string
+	if err := yaml.Unmarshal([]byte(value), &mapping); err != nil {
+		return nil, err
+	}
+	return lo.MapKeys(mapping, func(_ string, slug string) string { return strings.ToLower(slug) }), nil
+}
+
+func (s *ServiceImpl) FindRepositoryFile(ctx context.Context, installCtx InstallationContext, repository, path string) ([]byte, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FindFileContent(ctx, repository, path)
+}
+
+func (s *ServiceImpl) ListPullRequestFiles(ctx context.Context, installCtx InstallationContext, repository string, number int) ([]string, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListPullRequestFiles(ctx, repository, number)
+}
+
+func (s *ServiceImpl) ListWorkflowJobs(ctx context.Context, installCtx InstallationContext, repository string, runID int64) ([]*github.WorkflowJob, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListWorkflowJobs(ctx, repository, runID)
+}
+
+// ListCommitChecks 는 commit 의 check run 과 commit status 를 함께 조회합니다.
+func (s *ServiceImpl) ListCommitChecks(ctx context.Context, installCtx InstallationContext, repository, sha string) ([]CommitCheck, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	checkRuns, err := client.ListCheckRunsForRef(ctx, repository, sha)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := client.ListStatusesForRef(ctx, repository, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]CommitCheck, 0, len(checkRuns)+len(statuses))
+	for _, checkRun := range checkRuns {
+		checks = append(checks, newCommitCheckFromCheckRun(checkRun))
+	}
+	for _, status := range statuses {
+		checks = append(checks, newCommitCheckFromStatus(status))
+	}
+	return checks, nil
+}
+
 func (s *ServiceImpl) CreateComment(ctx context.Context, installCtx InstallationContext, repository string, number int, body string) error {
 	client, err := s.getInstallationClient(installCtx)
 	if err != nil {
@@ -231,6 +341,70 @@ func (s *ServiceImpl) AddAssigneeToIssue(ctx context.Context, installCtx Install
 	return client.AddAssigneeToIssue(ctx, repository, number, assignees)
 }
 
+func (s *ServiceImpl) CreateIssue(ctx context.Context, installCtx InstallationContext, repository, title, body string, labels, assignees []string) (*github.Issue, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateIssue(ctx, repository, title, body, labels, assignees)
+}
+
+func (s *ServiceImpl) FetchIssue(ctx context.Context, installCtx InstallationContext, repository string, number int) (*github.Issue, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FindIssue(ctx, repository, number)
+}
+
+func (s *ServiceImpl) FindPermissionLevel(ctx context.Context, installCtx InstallationContext, repository, user string) (string, error) {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return "", err
+	}
+	return client.FindPermissionLevel(ctx, repository, user)
+}
+
+func (s *ServiceImpl) CreateReview(ctx context.Context, installCtx InstallationContext, repository string, number int, event, body string) error {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return err
+	}
+	return client.CreateReview(ctx, repository, number, event, body)
+}
+
+func (s *ServiceImpl) AddLabelsToIssue(ctx context.Context, installCtx InstallationContext, repository string, number int, labels []string) error {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return err
+	}
+	return client.AddLabelsToIssue(ctx, repository, number, labels)
+}
+
+func (s *ServiceImpl) RemoveLabelFromIssue(ctx context.Context, installCtx InstallationContext, repository string, number int, label string) error {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return err
+	}
+	return client.RemoveLabelFromIssue(ctx, repository, number, label)
+}
+
+func (s *ServiceImpl) EditIssueState(ctx context.Context, installCtx InstallationContext, repository string, number int, state string) error {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return err
+	}
+	return client.EditIssueState(ctx, repository, number, state)
+}
+
+func (s *ServiceImpl) MergePullRequest(ctx context.Context, installCtx InstallationContext, repository string, number int, method string) error {
+	client, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return err
+	}
+	return client.MergePullRequest(ctx, repository, number, method)
+}
+
 func (s *ServiceImpl) getInstallationClient(installCtx InstallationContext) (*InstallationClient, error) {
 	client, exists := s.installationClientPool[installCtx]
 	if !exists {
@@ -269,8 +443,9 @@ func (s *ServiceImpl) cacheKeyForRepository(installCtx InstallationContext, repo
 	return fmt.Sprintf("%s:%s:%s", installCtx.OrgLogin, repository, key)
 }
 
+// cacheKeyForInstallationID 는 대소문자를 구분하지 않는 organization login 으로 installation id 를 찾습니다.
 func (s *ServiceImpl) cacheKeyForInstallationID(org string) string {
-	return fmt.Sprintf("installationid:%s", org)
+	return fmt.Sprintf("installationid:%s", strings.ToLower(org))
 }
 
 func (s *ServiceImpl) FindAppInstallationID(ctx context.Context, org string) (*int64, error) {
@@ -282,6 +457,14 @@ func (s *ServiceImpl) FindAppInstallationID(ctx context.Context, org string) (*i
 		return cached, nil
 	}
 
+	if _, err := s.ListOrgInstallations(ctx); err != nil {
+		return nil, err
+	}
+	return s.installationIDCache.Get(ctx, s.cacheKeyForInstallationID(org))
+}
+
+// ListOrgInstallations 는 app 이 설치된 모든 organization 입니다. 각 organization 의 installation id 를 함께 기록합니다.
+func (s *ServiceImpl) ListOrgInstallations(ctx context.Context) ([]InstallationContext, error) {
 	// NOTE : private key 가 없는 경우에 대해서, early fail 처리를 위에서 하고 있지 않고 있기에, 이곳에서 fail 함.
 	if s.appClient == nil {
 		appClient, err := newAppClient(s.githubAppID, s.privateKey, s.metrics)
@@ -295,31 +478,56 @@ func (s *ServiceImpl) FindAppInstallationID(ctx context.Context, org string) (*i
 	if err != nil {
 		return nil, err
 	}
+	var installCtxs []InstallationContext
 	for i := range installations {
-		if installations[i].Account.GetType() == "Organization" {
-			err := s.installationIDCache.Set(ctx, s.cacheKeyForInstallationID(org), installations[i].GetID(), -1)
-			if err != nil {
-				return nil, err
-			}
+		if installations[i].Account.GetType() != "Organization" {
+			continue
 		}
+		org := installations[i].Account.GetLogin()
+		err := s.installationIDCache.Set(ctx, s.cacheKeyForInstallationID(org), installations[i].GetID(), -1)
+		if err != nil {
+			return nil, err
+		}
+		installCtxs = append(installCtxs, NewInstallationContext(installations[i].GetID(), org))
 	}
-	return s.installationIDCache.Get(ctx, s.cacheKeyForInstallationID(org))
+	return installCtxs, nil
 }
 
-func (s *ServiceImpl) ListReviewRequestedPullRequest(ctx context.Context, installCtx InstallationContext, user string) ([]*github.Issue, error) {
+// SearchIssues 는 query 로 검색한 issue(pull request)를 모두 반환합니다. query 에 org 나 repo 조건을 포함해야 합니다.
+func (s *ServiceImpl) SearchIssues(ctx context.Context, installCtx InstallationContext, query string) ([]*github.Issue, error) {
 	installationClient, err := s.getInstallationClient(installCtx)
 	if err != nil {
 		return nil, err
 	}
 
-	return installationClient.ListReviewRequestedPullRequests(ctx, user)
+	return installationClient.SearchIssues(ctx, query)
 }
 
-func (s *ServiceImpl) ListAssignedPullRequest(ctx context.Context, installCtx InstallationContext, user string) ([]*github.Issue, error) {
+// ListUserTeams 는 organization 에서 user 가 속한 team 을 반환합니다.
+func (s *ServiceImpl) ListUserTeams(ctx context.Context, installCtx InstallationContext, username string) ([]*github.Team, error) {
 	installationClient, err := s.getInstallationClient(installCtx)
 	if err != nil {
 		return nil, err
 	}
 
-	return installationClient.ListOpenedPullRequests(ctx, user)
+	return installationClient.ListUserTeams(ctx, username)
+}
+
+// ListVerifiedDomainEmails 는 organization 의 verified domain 에 속한 user 의 email 을 반환합니다.
+func (s *ServiceImpl) ListVerifiedDomainEmails(ctx context.Context, installCtx InstallationContext, username string) ([]string, error) {
+	installationClient, err := s.getInstallationClient(installCtx)
+	if err != nil {
+		return nil, err
+	}
+	return installationClient.ListVerifiedDomainEmails(ctx, username)
+}
+
+// SearchResultRepository 는 검색 결과의 repository url(https://api.github.com/repos/{org}/{repo})에서 organization 과 repository 를 읽습니다.
+// 검색 결과에는 repository 정보가 함께 오지 않습니다.
+func SearchResultRepository(issue *github.Issue) (org, repository string) {
+	split := strings.Split(issue.GetRepositoryURL(), "/")
+	if len(split) < 2 {
+		return "", issue.GetRepositoryURL()
+	}
+	return split[len(split)-2], split[len(split)-1]
 }
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"strings"
+)
+
+// Locale 은 BCP 47 형식의 언어 태그입니다. (ex. ko, en, ja-JP)
+type Locale string
+
+const (
+	English  Locale = "en"
+	Korean   Locale = "ko"
+	Japanese Locale = "ja"
+)
+
+// Supported 는 message catalog 를 제공하는 locale 입니다.
+var Supported = []Locale{English, Korean, Japanese}
+
+// Chain 은 preferred 순서대로 찾을 locale 목록을 반환합니다.
+// 지역이 포함된 locale 은 언어만으로도 찾고, 마지막에는 항상 English 를 찾습니다.
+//
+//	Chain("ja-JP", "ko") == []Locale{"ja-JP", "ja", "ko", "en"}
+func Chain(preferred ...string) []Locale {
+	var chain []Locale
+	add := func(l Locale) {
+		for _, c := range chain {
+			if c == l {
+				return
+			}
+		}
+		chain = append(chain, l)
+	}
+
+	for _, p := range preferred {
+		p = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(p), "_", "-"))
+		if p == "" {
+			continue
+		}
+		add(Locale(p))
+		if language, _, ok := strings.Cut(p, "-"); ok {
+			add(Locale(language))
+		}
+	}
+	add(English)
+	return chain
+}
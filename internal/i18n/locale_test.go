@@ -0,0 +1,13 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	assert.Equal(t, []Locale{English}, Chain())
+	assert.Equal(t, []Locale{Korean, English}, Chain("ko", "", "en"))
+	assert.Equal(t, []Locale{"ja-jp", Japanese, Korean, English}, Chain("ja_JP", "ko", "ja"))
+}
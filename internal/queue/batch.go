@@ -0,0 +1,212 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const batchJobPrefix = "batch."
+
+// ErrBatchLeased 는 다른 job 이 batch 를 flush 하는 중인 경우입니다. job 을 재시도하여 그 사이 추가된 item 을 보냅니다.
+var ErrBatchLeased = errors.New("batch is being flushed")
+
+// Batch 는 flush 하기 전까지 모아둔 item 입니다.
+type Batch struct {
+	Items   [][]byte  `json:"items"`
+	FirstAt time.Time `json:"firstAt"`
+	// FlushAt 은 마지막 item 으로부터 wait, 처음 item 으로부터 maxWait 중 빠른 시각입니다.
+	FlushAt     time.Time `json:"flushAt"`
+	LeasedAt    time.Time `json:"leasedAt"`
+	LeasedUntil time.Time `json:"leasedUntil"`
+}
+
+func (b *Batch) append(item []byte, now time.Time, wait, maxWait time.Duration) {
+	// NOTE : flush 하는 중에 추가된 item 은 flush 가 끝난 뒤 남는 다음 batch 이므로 maxWait 을 추가된 시각부터 계산합니다.
+	if len(b.Items) == 0 || b.FirstAt.Before(b.LeasedAt) {
+		b.FirstAt = now
+	}
+	b.Items = append(b.Items, item)
+	b.FlushAt = now.Add(wait)
+	if deadline := b.FirstAt.Add(maxWait); deadline.Before(b.FlushAt) {
+		b.FlushAt = deadline
+	}
+}
+
+// lease 는 flush 할 시각이 지나지 않았으면 false 를 반환합니다.
+func (b *Batch) lease(now time.Time, lease time.Duration) (bool, error) {
+	if now.Before(b.FlushAt) {
+		return false, nil
+	}
+	if now.Before(b.LeasedUntil) {
+		return false, ErrBatchLeased
+	}
+	b.LeasedAt = now
+	b.LeasedUntil = now.Add(lease)
+	return true, nil
+}
+
+// complete 는 flush 한 앞쪽 item 을 지우고 lease 를 해제합니다. 남은 item 이 없으면 false 를 반환합니다.
+func (b *Batch) complete(flushed int) bool {
+	b.Items = b.Items[min(flushed, len(b.Items)):]
+	b.LeasedUntil = time.Time{}
+	return len(b.Items) > 0
+}
+
+// BatchStore 는 flush 하기 전까지 batch 를 보관합니다.
+type BatchStore interface {
+	// Append 는 key 의 batch 에 item 을 추가하고 batch 를 flush 할 시각을 반환합니다.
+	Append(ctx context.Context, key string, item []byte, wait, maxWait time.Duration) (time.Time, error)
+	// FlushNow 는 batch 를 기다리지 않고 flush 하도록 flush 할 시각을 지금으로 바꿉니다.
+	FlushNow(ctx context.Context, key string) error
+	// Lease 는 flush 할 시각이 지난 batch 를 lease 하여 반환합니다. batch 가 없거나 아직 flush 할 시각이 아니면 nil 을 반환합니다.
+	// 다른 job 이 lease 중이면 ErrBatchLeased 를 반환합니다.
+	Lease(ctx context.Context, key string, lease time.Duration) (*Batch, error)
+	// Complete 는 flush 한 item 을 지우고 lease 를 해제합니다. flush 하는 동안 추가된 item 은 남겨둡니다.
+	Complete(ctx context.Context, key string, flushed int) error
+	// Release 는 flush 에 실패한 batch 의 lease 를 해제합니다.
+	Release(ctx context.Context, key string) error
+}
+
+type BatchConfig struct {
+	Wait    time.Duration
+	MaxWait time.Duration
+}
+
+// Batches 는 Batcher 의 flush job 을 실행합니다. flush job 은 github event 와 같은 queue 와 worker 로 처리합니다.
+type Batches struct {
+	queue  Queue
+	store  BatchStore
+	config BatchConfig
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewBatches(queue Queue, store BatchStore, config BatchConfig) *Batches {
+	if config.MaxWait < config.Wait {
+		config.MaxWait = config.Wait
+	}
+	return &Batches{
+		queue:    queue,
+		store:    store,
+		config:   config,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handler 는 flush job 을 실행하고, 나머지 job 은 next 로 실행합니다.
+func (b *Batches) Handler(next Handler) Handler {
+	return func(ctx context.Context, job Job) error {
+		b.mu.RLock()
+		handler, ok := b.handlers[job.Name]
+		b.mu.RUnlock()
+		if ok {
+			return handler(ctx, job)
+		}
+		return next(ctx, job)
+	}
+}
+
+func (b *Batches) register(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = handler
+}
+
+// Batcher 는 같은 key 로 들어온 item 을 BatchStore 에 모아 두었다가 queue job 으로 한 번에 flush 합니다.
+// item 과 flush job 이 모두 영속적으로 저장되므로 재시작해도 유실되지 않고, flush 에 실패하면 job 과 함께 재시도합니다.
+//
+// item 을 추가할 때마다 batch 를 flush 할 시각에 job 을 등록합니다. flush 할 시각 전에 실행된 job 은 그 뒤의 job 이 있으므로 아무것도 하지 않고,
+// batch 를 lease 한 job 만 flush 하므로 같은 item 을 두 번 보내지 않습니다.
+// NOTE : flush job 이 dead letter 로 옮겨지면 batch 는 같은 key 로 다음 item 이 들어올 때 함께 보냅니다.
+type Batcher[T any] struct {
+	name    string
+	batches *Batches
+	flush   func(ctx context.Context, key string, items []T) error
+}
+
+func NewBatcher[T any](batches *Batches, name string, flush func(ctx context.Context, key string, items []T) error) *Batcher[T] {
+	b := &Batcher[T]{
+		name:    batchJobPrefix + name,
+		batches: batches,
+		flush:   flush,
+	}
+	batches.register(b.name, b.handle)
+	return b
+}
+
+func (b *Batcher[T]) Add(ctx context.Context, key string, item T) error {
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	conf := b.batches.config
+	flushAt, err := b.batches.store.Append(ctx, b.storeKey(key), encoded, conf.Wait, conf.MaxWait)
+	if err != nil {
+		return err
+	}
+	return b.enqueue(ctx, key, flushAt)
+}
+
+// AddAfter 는 item 을 추가하고 delay 뒤에 flush 합니다. flush 중에 호출하면 다음 batch 로 delay 뒤에 다시 flush 하므로,
+// 아직 끝나지 않은 작업을 나중에 다시 확인할 때 사용합니다.
+func (b *Batcher[T]) AddAfter(ctx context.Context, key string, item T, delay time.Duration) error {
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	flushAt, err := b.batches.store.Append(ctx, b.storeKey(key), encoded, delay, delay)
+	if err != nil {
+		return err
+	}
+	return b.enqueue(ctx, key, flushAt)
+}
+
+// Flush 는 key 로 모아둔 item 을 기다리지 않고 flush 합니다.
+func (b *Batcher[T]) Flush(ctx context.Context, key string) error {
+	if err := b.batches.store.FlushNow(ctx, b.storeKey(key)); err != nil {
+		return err
+	}
+	return b.enqueue(ctx, key, time.Now())
+}
+
+func (b *Batcher[T]) enqueue(ctx context.Context, key string, at time.Time) error {
+	job := NewJob(fmt.Sprintf("%s:%s", b.name, key), b.name, []byte(key))
+	job.NextRunAt = at
+	return b.batches.queue.Enqueue(ctx, job)
+}
+
+func (b *Batcher[T]) handle(ctx context.Context, job Job) error {
+	key := string(job.Payload)
+	batch, err := b.batches.store.Lease(ctx, b.storeKey(key), DefaultLease)
+	if err != nil || batch == nil {
+		return err
+	}
+
+	items := make([]T, 0, len(batch.Items))
+	for _, encoded := range batch.Items {
+		var item T
+		if err := json.Unmarshal(encoded, &item); err != nil {
+			_ = b.batches.store.Release(ctx, b.storeKey(key))
+			return errors.Wrapf(err, "failed to decode %s item", b.name)
+		}
+		items = append(items, item)
+	}
+
+	if err := b.flush(ctx, key, items); err != nil {
+		if releaseErr := b.batches.store.Release(ctx, b.storeKey(key)); releaseErr != nil {
+			return errors.Wrap(err, releaseErr.Error())
+		}
+		return err
+	}
+	return b.batches.store.Complete(ctx, b.storeKey(key), len(batch.Items))
+}
+
+func (b *Batcher[T]) storeKey(key string) string {
+	return fmt.Sprintf("%s/%s", b.name, key)
+}
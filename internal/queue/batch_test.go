@@ -0,0 +1,200 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+type flushed struct {
+	key   string
+	items []int
+}
+
+func newTestBatchStores(t *testing.T) map[string]BatchStore {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "batch.db"), 0o600, nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	bolt, err := NewBoltBatchStore(db)
+	assert.NoError(t, err)
+	return map[string]BatchStore{
+		"memory": NewMemoryBatchStore(),
+		"bolt":   bolt,
+	}
+}
+
+// runJobs 는 실행 가능한 job 을 모두 실행하고 job 별 결과를 반환합니다.
+func runJobs(t *testing.T, q Queue, handler Handler) []error {
+	ctx := context.TODO()
+	var results []error
+	for {
+		job, err := q.Dequeue(ctx, time.Minute)
+		assert.NoError(t, err)
+		if job == nil {
+			return results
+		}
+		results = append(results, handler(ctx, *job))
+		assert.NoError(t, q.Ack(ctx, *job))
+	}
+}
+
+func TestBatcher_FlushOnce(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range newTestBatchStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			q := NewMemoryQueue()
+			batches := NewBatches(q, store, BatchConfig{})
+
+			var results []flushed
+			b := NewBatcher(batches, "test", func(_ context.Context, key string, items []int) error {
+				results = append(results, flushed{key: key, items: items})
+				return nil
+			})
+			assert.NoError(t, b.Add(ctx, "a", 1))
+			assert.NoError(t, b.Add(ctx, "b", 10))
+			assert.NoError(t, b.Add(ctx, "a", 2))
+
+			handler := batches.Handler(func(context.Context, Job) error {
+				t.Fatal("batch job must not be passed to next handler")
+				return nil
+			})
+			for _, err := range runJobs(t, q, handler) {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, []flushed{{key: "a", items: []int{1, 2}}, {key: "b", items: []int{10}}}, results)
+		})
+	}
+}
+
+func TestBatcher_NotDue(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range newTestBatchStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			q := NewMemoryQueue()
+			batches := NewBatches(q, store, BatchConfig{Wait: time.Hour})
+
+			count := 0
+			b := NewBatcher(batches, "test", func(context.Context, string, []int) error {
+				count++
+				return nil
+			})
+			assert.NoError(t, b.Add(ctx, "a", 1))
+
+			// job is run before flush time
+			assert.NoError(t, b.handle(ctx, NewJob("test:a", "batch.test", []byte("a"))))
+			assert.Equal(t, 0, count)
+
+			assert.NoError(t, b.Flush(ctx, "a"))
+			for _, err := range runJobs(t, q, batches.Handler(nil)) {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+func TestBatcher_RetryKeepsItems(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range newTestBatchStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			q := NewMemoryQueue()
+			batches := NewBatches(q, store, BatchConfig{})
+
+			var results [][]int
+			fail := true
+			b := NewBatcher(batches, "test", func(_ context.Context, _ string, items []int) error {
+				if fail {
+					return assert.AnError
+				}
+				results = append(results, items)
+				return nil
+			})
+			assert.NoError(t, b.Add(ctx, "a", 1))
+			job := NewJob("test:a", "batch.test", []byte("a"))
+			assert.ErrorIs(t, b.handle(ctx, job), assert.AnError)
+
+			// item added while flushing is kept for the next flush
+			batch, err := store.Lease(ctx, "batch.test/a", time.Minute)
+			assert.NoError(t, err)
+			assert.Len(t, batch.Items, 1)
+			assert.ErrorIs(t, b.handle(ctx, job), ErrBatchLeased)
+			assert.NoError(t, b.Add(ctx, "a", 2))
+			assert.NoError(t, store.Complete(ctx, "batch.test/a", len(batch.Items)))
+
+			fail = false
+			assert.NoError(t, b.handle(ctx, job))
+			assert.Equal(t, [][]int{{2}}, results)
+
+			// completed batch is removed
+			assert.NoError(t, b.handle(ctx, job))
+			assert.Len(t, results, 1)
+		})
+	}
+}
+
+// flush 중에 AddAfter 로 추가한 item 은 delay 가 지난 뒤 다음 batch 로 flush 합니다.
+func TestBatcher_AddAfterWhileFlushing(t *testing.T) {
+	t.Parallel()
+
+	for name, store := range newTestBatchStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			q := NewMemoryQueue()
+			batches := NewBatches(q, store, BatchConfig{})
+
+			var b *Batcher[int]
+			var results [][]int
+			b = NewBatcher(batches, "test", func(ctx context.Context, key string, items []int) error {
+				results = append(results, items)
+				return b.AddAfter(ctx, key, items[len(items)-1]+1, time.Hour)
+			})
+			assert.NoError(t, b.Add(ctx, "a", 1))
+			for _, err := range runJobs(t, q, batches.Handler(nil)) {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, [][]int{{1}}, results)
+
+			batch, err := store.Lease(ctx, "batch.test/a", time.Minute)
+			assert.NoError(t, err)
+			assert.Nil(t, batch)
+
+			assert.NoError(t, b.Flush(ctx, "a"))
+			for _, err := range runJobs(t, q, batches.Handler(nil)) {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, [][]int{{1}, {2}}, results)
+		})
+	}
+}
+
+// flush 하는 중에 추가된 item 은 이전 batch 의 maxWait 과 관계없이 추가된 시각부터 기다립니다.
+func TestBatch_AppendWhileLeased(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &Batch{}
+	b.append([]byte("1"), first, time.Second, time.Minute)
+
+	leasedAt := first.Add(2 * time.Minute)
+	leased, err := b.lease(leasedAt, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+
+	b.append([]byte("2"), leasedAt, 10*time.Minute, 10*time.Minute)
+	assert.Equal(t, leasedAt.Add(10*time.Minute), b.FlushAt)
+	b.append([]byte("3"), leasedAt.Add(time.Second), time.Second, 10*time.Minute)
+	assert.Equal(t, leasedAt.Add(2*time.Second), b.FlushAt)
+
+	assert.True(t, b.complete(1))
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, b.Items)
+	assert.Equal(t, leasedAt, b.FirstAt)
+}
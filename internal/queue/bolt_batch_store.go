@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var batchBucket = []byte("batches")
+
+type BoltBatchStore struct {
+	db *bbolt.DB
+}
+
+func NewBoltBatchStore(db *bbolt.DB) (*BoltBatchStore, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(batchBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create batch bucket")
+	}
+	return &BoltBatchStore{db: db}, nil
+}
+
+func (s *BoltBatchStore) Append(_ context.Context, key string, item []byte, wait, maxWait time.Duration) (time.Time, error) {
+	var flushAt time.Time
+	err := s.update(key, func(batch *Batch) (bool, error) {
+		batch.append(item, time.Now(), wait, maxWait)
+		flushAt = batch.FlushAt
+		return true, nil
+	})
+	return flushAt, err
+}
+
+func (s *BoltBatchStore) FlushNow(_ context.Context, key string) error {
+	return s.update(key, func(batch *Batch) (bool, error) {
+		if len(batch.Items) > 0 {
+			batch.FlushAt = time.Now()
+		}
+		return len(batch.Items) > 0, nil
+	})
+}
+
+func (s *BoltBatchStore) Lease(_ context.Context, key string, lease time.Duration) (*Batch, error) {
+	var leased *Batch
+	err := s.update(key, func(batch *Batch) (bool, error) {
+		if len(batch.Items) == 0 {
+			return false, nil
+		}
+		ok, err := batch.lease(time.Now(), lease)
+		if err != nil || !ok {
+			return false, err
+		}
+		leased = batch
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return leased, nil
+}
+
+func (s *BoltBatchStore) Complete(_ context.Context, key string, flushed int) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		batch, err := getBatch(tx, key)
+		if err != nil || batch == nil {
+			return err
+		}
+		if !batch.complete(flushed) {
+			return tx.Bucket(batchBucket).Delete([]byte(key))
+		}
+		return putBatch(tx, key, batch)
+	})
+}
+
+func (s *BoltBatchStore) Release(_ context.Context, key string) error {
+	return s.update(key, func(batch *Batch) (bool, error) {
+		if len(batch.Items) == 0 {
+			return false, nil
+		}
+		batch.LeasedUntil = time.Time{}
+		return true, nil
+	})
+}
+
+// update 는 key 의 batch 를 하나의 transaction 안에서 바꿉니다. batch 가 없으면 빈 batch 를 넘기고, fn 이 false 를 반환하면 저장하지 않습니다.
+func (s *BoltBatchStore) update(key string, fn func(batch *Batch) (bool, error)) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		batch, err := getBatch(tx, key)
+		if err != nil {
+			return err
+		}
+		if batch == nil {
+			batch = &Batch{}
+		}
+		changed, err := fn(batch)
+		if err != nil || !changed {
+			return err
+		}
+		return putBatch(tx, key, batch)
+	})
+}
+
+func getBatch(tx *bbolt.Tx, key string) (*Batch, error) {
+	value := tx.Bucket(batchBucket).Get([]byte(key))
+	if value == nil {
+		return nil, nil
+	}
+	var batch Batch
+	if err := json.Unmarshal(value, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func putBatch(tx *bbolt.Tx, key string, batch *Batch) error {
+	encoded, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(batchBucket).Put([]byte(key), encoded)
+}
@@ -0,0 +1,202 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	jobBucket        = []byte("jobs")
+	deadLetterBucket = []byte("dead_letters")
+	// scheduleBucket 은 job 을 실행 가능한 시각 순서로 찾기 위한 index 입니다. key 는 실행 가능한 시각과 job key, 값은 job key 입니다.
+	scheduleBucket = []byte("job_schedule")
+)
+
+type BoltQueue struct {
+	db *bbolt.DB
+}
+
+func NewBoltQueue(db *bbolt.DB) (*BoltQueue, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{jobBucket, deadLetterBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return rebuildSchedule(tx)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create queue bucket")
+	}
+	return &BoltQueue{db: db}, nil
+}
+
+func (q *BoltQueue) Enqueue(_ context.Context, job Job) error {
+	return q.db.Update(func(tx *bbolt.Tx) error {
+		return putJob(tx, job)
+	})
+}
+
+// Dequeue 는 schedule index 의 첫 job 만 확인합니다. 첫 job 이 아직 실행 가능하지 않으면 나머지 job 도 실행 가능하지 않습니다.
+func (q *BoltQueue) Dequeue(_ context.Context, lease time.Duration) (*Job, error) {
+	var dequeued *Job
+	err := q.db.Update(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		k, jobKey := tx.Bucket(scheduleBucket).Cursor().First()
+		if k == nil || bytes.Compare(k[:scheduleTimeLength], scheduleKey(now, nil)[:scheduleTimeLength]) > 0 {
+			return nil
+		}
+
+		value := tx.Bucket(jobBucket).Get(jobKey)
+		if value == nil {
+			return errors.Errorf("scheduled job %s not found", jobKey)
+		}
+		var job Job
+		if err := json.Unmarshal(value, &job); err != nil {
+			return err
+		}
+		job.LeasedUntil = now.Add(lease)
+		dequeued = &job
+		return putJob(tx, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dequeued, nil
+}
+
+func (q *BoltQueue) Ack(_ context.Context, job Job) error {
+	return q.db.Update(func(tx *bbolt.Tx) error {
+		return deleteJob(tx, job.key())
+	})
+}
+
+func (q *BoltQueue) Retry(_ context.Context, job Job, nextRunAt time.Time, cause error) error {
+	job.Attempts++
+	job.NextRunAt = nextRunAt
+	job.LeasedUntil = time.Time{}
+	job.LastError = cause.Error()
+	return q.db.Update(func(tx *bbolt.Tx) error {
+		return putJob(tx, job)
+	})
+}
+
+func (q *BoltQueue) DeadLetter(_ context.Context, job Job, cause error) error {
+	job.Attempts++
+	job.LeasedUntil = time.Time{}
+	job.LastError = cause.Error()
+	return q.db.Update(func(tx *bbolt.Tx) error {
+		if err := deleteJob(tx, job.key()); err != nil {
+			return err
+		}
+		value, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(deadLetterBucket).Put(job.key(), value)
+	})
+}
+
+func (q *BoltQueue) ListDeadLetters(_ context.Context) ([]Job, error) {
+	var jobs []Job
+	err := q.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(deadLetterBucket).ForEach(func(_, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (q *BoltQueue) Depth(_ context.Context) (int, error) {
+	return q.count(jobBucket)
+}
+
+func (q *BoltQueue) DeadLetterDepth(_ context.Context) (int, error) {
+	return q.count(deadLetterBucket)
+}
+
+func (q *BoltQueue) count(bucket []byte) (int, error) {
+	var count int
+	err := q.db.View(func(tx *bbolt.Tx) error {
+		count = tx.Bucket(bucket).Stats().KeyN
+		return nil
+	})
+	return count, err
+}
+
+// putJob 은 job 을 저장하고 schedule index 를 job 의 실행 가능한 시각으로 옮깁니다.
+func putJob(tx *bbolt.Tx, job Job) error {
+	jobs := tx.Bucket(jobBucket)
+	if err := unschedule(tx, jobs.Get(job.key())); err != nil {
+		return err
+	}
+	value, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if err := jobs.Put(job.key(), value); err != nil {
+		return err
+	}
+	return tx.Bucket(scheduleBucket).Put(scheduleKey(job.readyAt(), job.key()), job.key())
+}
+
+func deleteJob(tx *bbolt.Tx, key []byte) error {
+	jobs := tx.Bucket(jobBucket)
+	if err := unschedule(tx, jobs.Get(key)); err != nil {
+		return err
+	}
+	return jobs.Delete(key)
+}
+
+// unschedule 은 저장되어 있던 job 의 schedule index 를 지웁니다.
+func unschedule(tx *bbolt.Tx, stored []byte) error {
+	if stored == nil {
+		return nil
+	}
+	var job Job
+	if err := json.Unmarshal(stored, &job); err != nil {
+		return err
+	}
+	return tx.Bucket(scheduleBucket).Delete(scheduleKey(job.readyAt(), job.key()))
+}
+
+// rebuildSchedule 은 schedule index 를 jobs bucket 으로부터 다시 만듭니다. index 이전에 저장된 job 도 실행할 수 있도록 open 할 때 실행합니다.
+func rebuildSchedule(tx *bbolt.Tx) error {
+	if tx.Bucket(scheduleBucket) != nil {
+		if err := tx.DeleteBucket(scheduleBucket); err != nil {
+			return err
+		}
+	}
+	schedule, err := tx.CreateBucket(scheduleBucket)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(jobBucket).ForEach(func(k, v []byte) error {
+		var job Job
+		if err := json.Unmarshal(v, &job); err != nil {
+			return err
+		}
+		return schedule.Put(scheduleKey(job.readyAt(), k), k)
+	})
+}
+
+// scheduleTimeLength 는 schedule key 에서 시각 부분의 길이입니다.
+const scheduleTimeLength = 20
+
+func scheduleKey(at time.Time, jobKey []byte) []byte {
+	return append([]byte(fmt.Sprintf("%020d:", at.UnixNano())), jobKey...)
+}
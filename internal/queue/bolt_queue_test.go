@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func newTestBoltQueue(t *testing.T) *BoltQueue {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "queue.db"), 0o600, nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	q, err := NewBoltQueue(db)
+	assert.NoError(t, err)
+	return q
+}
+
+func TestBoltQueue_DequeueInOrder(t *testing.T) {
+	t.Parallel()
+	q := newTestBoltQueue(t)
+	ctx := context.TODO()
+
+	first := NewJob("1", "issues", []byte(`{}`))
+	second := NewJob("2", "issues", []byte(`{}`))
+	second.EnqueuedAt = first.EnqueuedAt.Add(time.Millisecond)
+	assert.NoError(t, q.Enqueue(ctx, second))
+	assert.NoError(t, q.Enqueue(ctx, first))
+
+	depth, err := q.Depth(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, depth)
+
+	job, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", job.ID)
+
+	// leased job is not dequeued again
+	job, err = q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", job.ID)
+
+	job, err = q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestBoltQueue_Retry(t *testing.T) {
+	t.Parallel()
+	q := newTestBoltQueue(t)
+	ctx := context.TODO()
+
+	assert.NoError(t, q.Enqueue(ctx, NewJob("1", "issues", []byte(`{}`))))
+	job, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+
+	assert.NoError(t, q.Retry(ctx, *job, time.Now().Add(time.Hour), assert.AnError))
+
+	// not ready until nextRunAt
+	next, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+
+	depth, err := q.Depth(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, depth)
+}
+
+func TestBoltQueue_AckAndDeadLetter(t *testing.T) {
+	t.Parallel()
+	q := newTestBoltQueue(t)
+	ctx := context.TODO()
+
+	assert.NoError(t, q.Enqueue(ctx, NewJob("1", "issues", []byte(`{}`))))
+	assert.NoError(t, q.Enqueue(ctx, NewJob("2", "issues", []byte(`{}`))))
+
+	job, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, q.Ack(ctx, *job))
+
+	job, err = q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, q.DeadLetter(ctx, *job, assert.AnError))
+
+	depth, err := q.Depth(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, depth)
+
+	deadLetters, err := q.ListDeadLetters(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, job.ID, deadLetters[0].ID)
+	assert.Equal(t, 1, deadLetters[0].Attempts)
+	assert.Equal(t, assert.AnError.Error(), deadLetters[0].LastError)
+}
+
+func TestBoltQueue_DequeueByRunAt(t *testing.T) {
+	t.Parallel()
+	q := newTestBoltQueue(t)
+	ctx := context.TODO()
+
+	delayed := NewJob("1", "issues", []byte(`{}`))
+	delayed.NextRunAt = delayed.NextRunAt.Add(time.Hour)
+	assert.NoError(t, q.Enqueue(ctx, delayed))
+	ready := NewJob("2", "issues", []byte(`{}`))
+	ready.EnqueuedAt = delayed.EnqueuedAt.Add(time.Millisecond)
+	ready.NextRunAt = delayed.EnqueuedAt
+	assert.NoError(t, q.Enqueue(ctx, ready))
+
+	// job enqueued later but ready earlier is dequeued first
+	job, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", job.ID)
+
+	job, err = q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestBoltQueue_RebuildSchedule(t *testing.T) {
+	t.Parallel()
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "queue.db"), 0o600, nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.TODO()
+
+	q, err := NewBoltQueue(db)
+	assert.NoError(t, err)
+	assert.NoError(t, q.Enqueue(ctx, NewJob("1", "issues", []byte(`{}`))))
+
+	// jobs saved before the schedule index existed are dequeued after reopening
+	assert.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return tx.DeleteBucket(scheduleBucket)
+	}))
+	q, err = NewBoltQueue(db)
+	assert.NoError(t, err)
+
+	job, err := q.Dequeue(ctx, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", job.ID)
+}
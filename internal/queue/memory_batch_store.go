@@ -0,0 +1,80 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryBatchStore 는 프로세스 메모리에만 batch 를 저장합니다. 로컬 개발 및 테스트 용도로 사용합니다.
+type MemoryBatchStore struct {
+	mu      sync.Mutex
+	batches map[string]*Batch
+}
+
+func NewMemoryBatchStore() *MemoryBatchStore {
+	return &MemoryBatchStore{
+		batches: make(map[string]*Batch),
+	}
+}
+
+func (s *MemoryBatchStore) Append(_ context.Context, key string, item []byte, wait, maxWait time.Duration) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[key]
+	if !ok {
+		batch = &Batch{}
+		s.batches[key] = batch
+	}
+	batch.append(item, time.Now(), wait, maxWait)
+	return batch.FlushAt, nil
+}
+
+func (s *MemoryBatchStore) FlushNow(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if batch, ok := s.batches[key]; ok {
+		batch.FlushAt = time.Now()
+	}
+	return nil
+}
+
+func (s *MemoryBatchStore) Lease(_ context.Context, key string, lease time.Duration) (*Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[key]
+	if !ok {
+		return nil, nil
+	}
+	leased, err := batch.lease(time.Now(), lease)
+	if err != nil || !leased {
+		return nil, err
+	}
+	copied := *batch
+	copied.Items = append([][]byte(nil), batch.Items...)
+	return &copied, nil
+}
+
+func (s *MemoryBatchStore) Complete(_ context.Context, key string, flushed int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[key]
+	if ok && !batch.complete(flushed) {
+		delete(s.batches, key)
+	}
+	return nil
+}
+
+func (s *MemoryBatchStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if batch, ok := s.batches[key]; ok {
+		batch.LeasedUntil = time.Time{}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryQueue 는 프로세스 메모리에만 job 을 저장합니다. 로컬 개발 및 테스트 용도로 사용합니다.
+type MemoryQueue struct {
+	mu          sync.Mutex
+	jobs        map[string]Job
+	deadLetters []Job
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{
+		jobs: make(map[string]Job),
+	}
+}
+
+func (q *MemoryQueue) Enqueue(_ context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.jobs[string(job.key())] = job
+	return nil
+}
+
+func (q *MemoryQueue) Dequeue(_ context.Context, lease time.Duration) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	keys := make([]string, 0, len(q.jobs))
+	for k := range q.jobs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	now := time.Now()
+	for _, k := range keys {
+		job := q.jobs[k]
+		if !job.ready(now) {
+			continue
+		}
+		job.LeasedUntil = now.Add(lease)
+		q.jobs[k] = job
+		return &job, nil
+	}
+	return nil, nil
+}
+
+func (q *MemoryQueue) Ack(_ context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.jobs, string(job.key()))
+	return nil
+}
+
+func (q *MemoryQueue) Retry(_ context.Context, job Job, nextRunAt time.Time, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job.Attempts++
+	job.NextRunAt = nextRunAt
+	job.LeasedUntil = time.Time{}
+	job.LastError = cause.Error()
+	q.jobs[string(job.key())] = job
+	return nil
+}
+
+func (q *MemoryQueue) DeadLetter(_ context.Context, job Job, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.jobs, string(job.key()))
+	job.Attempts++
+	job.LeasedUntil = time.Time{}
+	job.LastError = cause.Error()
+	q.deadLetters = append(q.deadLetters, job)
+	return nil
+}
+
+func (q *MemoryQueue) ListDeadLetters(_ context.Context) ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]Job(nil), q.deadLetters...), nil
+}
+
+func (q *MemoryQueue) Depth(_ context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.jobs), nil
+}
+
+func (q *MemoryQueue) DeadLetterDepth(_ context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.deadLetters), nil
+}
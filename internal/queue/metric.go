@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Metrics struct {
+	depth           prometheus.Gauge
+	deadLetterDepth prometheus.Gauge
+
+	jobCount   *prometheus.CounterVec
+	jobLatency *prometheus.HistogramVec
+}
+
+const (
+	labelJobName = "job_name"
+	labelResult  = "result"
+
+	resultSuccess    = "success"
+	resultRetry      = "retry"
+	resultDeadLetter = "dead_letter"
+)
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		depth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "queue",
+				Name:      "depth",
+				Help:      "The number of jobs waiting in the queue",
+			},
+		),
+		deadLetterDepth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "queue",
+				Name:      "dead_letter_depth",
+				Help:      "The number of jobs moved to the dead letter list",
+			},
+		),
+		jobCount: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "queue",
+				Name:      "jobs_total",
+				Help:      "Total number of processed jobs by result",
+			},
+			[]string{labelJobName, labelResult},
+		),
+		jobLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "queue",
+				Name:      "job_latency_seconds",
+				Help:      "Seconds from enqueue to the end of the last attempt",
+				Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+			},
+			[]string{labelJobName, labelResult},
+		),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.depth.Describe(ch)
+	m.deadLetterDepth.Describe(ch)
+	m.jobCount.Describe(ch)
+	m.jobLatency.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.depth.Collect(ch)
+	m.deadLetterDepth.Collect(ch)
+	m.jobCount.Collect(ch)
+	m.jobLatency.Collect(ch)
+}
+
+func (m *Metrics) onDepth(depth, deadLetterDepth int) {
+	m.depth.Set(float64(depth))
+	m.deadLetterDepth.Set(float64(deadLetterDepth))
+}
+
+func (m *Metrics) onProcessed(job Job, result string) {
+	m.jobCount.
+		WithLabelValues(job.Name, result).
+		Inc()
+
+	m.jobLatency.
+		WithLabelValues(job.Name, result).
+		Observe(time.Since(job.EnqueuedAt).Seconds())
+}
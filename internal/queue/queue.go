@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Job 은 queue 에 영속적으로 저장되는 작업 단위입니다.
+type Job struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Payload []byte `json:"payload"`
+	// Attributes 는 job 을 처리할 때 필요한 부가 정보입니다.
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Attempts    int               `json:"attempts"`
+	LastError   string            `json:"lastError,omitempty"`
+	EnqueuedAt  time.Time         `json:"enqueuedAt"`
+	NextRunAt   time.Time         `json:"nextRunAt"`
+	LeasedUntil time.Time         `json:"leasedUntil"`
+}
+
+func NewJob(id, name string, payload []byte) Job {
+	now := time.Now()
+	return Job{
+		ID:         id,
+		Name:       name,
+		Payload:    payload,
+		EnqueuedAt: now,
+		NextRunAt:  now,
+	}
+}
+
+// key 는 enqueue 순서대로 정렬되도록 enqueue 시각을 prefix 로 사용합니다.
+func (j Job) key() []byte {
+	return []byte(fmt.Sprintf("%020d:%s", j.EnqueuedAt.UnixNano(), j.ID))
+}
+
+func (j Job) ready(now time.Time) bool {
+	return !now.Before(j.readyAt())
+}
+
+// readyAt 은 job 을 다시 실행할 수 있는 시각입니다. lease 중인 job 은 lease 가 끝난 뒤 실행할 수 있습니다.
+func (j Job) readyAt() time.Time {
+	if j.LeasedUntil.After(j.NextRunAt) {
+		return j.LeasedUntil
+	}
+	return j.NextRunAt
+}
+
+type Queue interface {
+	Enqueue(ctx context.Context, job Job) error
+	// Dequeue 는 실행 가능한 job 을 lease 하여 반환합니다. 실행 가능한 job 이 없으면 nil 을 반환합니다.
+	// lease 가 만료될 때까지 Ack, Retry, DeadLetter 되지 않은 job 은 다시 dequeue 됩니다.
+	Dequeue(ctx context.Context, lease time.Duration) (*Job, error)
+	Ack(ctx context.Context, job Job) error
+	Retry(ctx context.Context, job Job, nextRunAt time.Time, cause error) error
+	DeadLetter(ctx context.Context, job Job, cause error) error
+	ListDeadLetters(ctx context.Context) ([]Job, error)
+	Depth(ctx context.Context) (int, error)
+	DeadLetterDepth(ctx context.Context) (int, error)
+}
@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/channel-io/cht-app-github/internal/logger"
+)
+
+// DefaultLease 는 worker 가 꺼낸 job 을 다른 worker 가 꺼내지 못하는 시간입니다.
+const DefaultLease = 5 * time.Minute
+
+const defaultPollInterval = 500 * time.Millisecond
+
+type Handler func(ctx context.Context, job Job) error
+
+type WorkerConfig struct {
+	Concurrency int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Worker 는 queue 에서 job 을 꺼내 handler 를 실행합니다.
+// 실패한 job 은 exponential backoff 로 재시도하고, MaxAttempts 를 넘기면 dead letter 로 옮깁니다.
+type Worker struct {
+	queue   Queue
+	handler Handler
+	metrics *Metrics
+	logger  logger.Logger
+	config  WorkerConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWorker(queue Queue, handler Handler, metrics *Metrics, logger logger.Logger, config WorkerConfig) *Worker {
+	return &Worker{
+		queue:   queue,
+		handler: handler,
+		metrics: metrics,
+		logger:  logger,
+		config:  config,
+	}
+}
+
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	for i := 0; i < w.config.Concurrency; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.run(ctx)
+		}()
+	}
+}
+
+// Stop 은 진행중인 job 이 끝날 때까지 기다립니다. 끝나지 않은 job 은 lease 만료 후 다시 실행됩니다.
+func (w *Worker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	ticker := time.NewTicker(defaultPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// NOTE : job 이 남아있으면 다음 poll 을 기다리지 않고 바로 처리합니다.
+		for w.processNext(ctx) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) processNext(ctx context.Context) bool {
+	job, err := w.queue.Dequeue(ctx, DefaultLease)
+	if err != nil {
+		w.logger.Errorw("failed to dequeue job", "error", err)
+		return false
+	}
+	w.updateDepth(ctx)
+	if job == nil {
+		return false
+	}
+
+	// NOTE : 종료 시점에 handler 가 중단되지 않도록 worker 의 context 를 넘기지 않습니다.
+	if err := w.handler(context.Background(), *job); err != nil {
+		w.onFailure(ctx, *job, err)
+		return true
+	}
+
+	if err := w.queue.Ack(ctx, *job); err != nil {
+		w.logger.Errorw("failed to ack job", "id", job.ID, "error", err)
+	}
+	w.metrics.onProcessed(*job, resultSuccess)
+	return true
+}
+
+func (w *Worker) onFailure(ctx context.Context, job Job, cause error) {
+	if job.Attempts+1 >= w.config.MaxAttempts {
+		w.logger.Errorw("job moved to dead letter", "id", job.ID, "name", job.Name, "attempts", job.Attempts+1, "error", cause)
+		if err := w.queue.DeadLetter(ctx, job, cause); err != nil {
+			w.logger.Errorw("failed to move job to dead letter", "id", job.ID, "error", err)
+		}
+		w.metrics.onProcessed(job, resultDeadLetter)
+		return
+	}
+
+	nextRunAt := time.Now().Add(w.backoff(job.Attempts))
+	w.logger.Warnw("job failed, will be retried", "id", job.ID, "name", job.Name, "attempts", job.Attempts+1, "nextRunAt", nextRunAt, "error", cause)
+	if err := w.queue.Retry(ctx, job, nextRunAt, cause); err != nil {
+		w.logger.Errorw("failed to retry job", "id", job.ID, "error", err)
+	}
+	w.metrics.onProcessed(job, resultRetry)
+}
+
+// backoff 는 attempts 번 실패한 job 이 다음 실행까지 기다릴 시간입니다.
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := float64(w.config.BaseBackoff) * math.Pow(2, float64(attempts))
+	if delay > float64(w.config.MaxBackoff) {
+		return w.config.MaxBackoff
+	}
+	return time.Duration(delay)
+}
+
+func (w *Worker) updateDepth(ctx context.Context) {
+	depth, err := w.queue.Depth(ctx)
+	if err != nil {
+		return
+	}
+	deadLetterDepth, err := w.queue.DeadLetterDepth(ctx)
+	if err != nil {
+		return
+	}
+	w.metrics.onDepth(depth, deadLetterDepth)
+}
@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker_Backoff(t *testing.T) {
+	t.Parallel()
+	w := &Worker{
+		config: WorkerConfig{
+			BaseBackoff: time.Second,
+			MaxBackoff:  10 * time.Second,
+		},
+	}
+
+	assert.Equal(t, time.Second, w.backoff(0))
+	assert.Equal(t, 2*time.Second, w.backoff(1))
+	assert.Equal(t, 8*time.Second, w.backoff(3))
+	assert.Equal(t, 10*time.Second, w.backoff(4))
+}
+
+func TestWorker_ProcessNext(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	q := NewMemoryQueue()
+
+	var handled []string
+	w := NewWorker(q, func(_ context.Context, job Job) error {
+		handled = append(handled, job.ID)
+		if job.ID == "fail" {
+			return assert.AnError
+		}
+		return nil
+	}, NewMetrics(), nopLogger{}, WorkerConfig{
+		MaxAttempts: 2,
+		BaseBackoff: 0,
+		MaxBackoff:  0,
+	})
+
+	assert.NoError(t, q.Enqueue(ctx, NewJob("ok", "issues", nil)))
+	assert.True(t, w.processNext(ctx))
+	depth, _ := q.Depth(ctx)
+	assert.Equal(t, 0, depth)
+
+	// first failure is retried, second failure is moved to dead letter
+	assert.NoError(t, q.Enqueue(ctx, NewJob("fail", "issues", nil)))
+	assert.True(t, w.processNext(ctx))
+	depth, _ = q.Depth(ctx)
+	assert.Equal(t, 1, depth)
+
+	assert.True(t, w.processNext(ctx))
+	depth, _ = q.Depth(ctx)
+	assert.Equal(t, 0, depth)
+	deadLetterDepth, _ := q.DeadLetterDepth(ctx)
+	assert.Equal(t, 1, deadLetterDepth)
+
+	assert.False(t, w.processNext(ctx))
+	assert.Equal(t, []string{"ok", "fail", "fail"}, handled)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Error(...interface{})          {}
+func (nopLogger) Errorw(string, ...interface{}) {}
+func (nopLogger) Warn(...interface{})           {}
+func (nopLogger) Warnw(string, ...interface{})  {}
+func (nopLogger) Info(...interface{})           {}
+func (nopLogger) Infow(string, ...interface{})  {}
+func (nopLogger) Debug(...interface{})          {}
+func (nopLogger) Debugw(string, ...interface{}) {}
@@ -0,0 +1,77 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+var requestBucket = []byte("review_requests")
+
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+func NewBoltStore(db *bbolt.DB) (*BoltStore, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(requestBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create review request bucket")
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(_ context.Context, request Request) error {
+	value, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(requestBucket).Put([]byte(request.ID()), value)
+	})
+}
+
+func (s *BoltStore) Delete(_ context.Context, key thread.Key, reviewer string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(requestBucket).Delete([]byte(requestID(key, reviewer)))
+	})
+}
+
+func (s *BoltStore) DeletePullRequest(_ context.Context, key thread.Key) error {
+	prefix := []byte(pullRequestPrefix(key))
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(requestBucket).Cursor()
+		// NOTE : cursor 의 Delete 는 다음 key 로 이동하지 않으므로 삭제 후 다시 Seek 합니다.
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) List(_ context.Context) ([]Request, error) {
+	var requests []Request
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(requestBucket).ForEach(func(_, value []byte) error {
+			var request Request
+			if err := json.Unmarshal(value, &request); err != nil {
+				return err
+			}
+			requests = append(requests, request)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
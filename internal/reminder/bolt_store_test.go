@@ -0,0 +1,56 @@
+package reminder
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+func TestBoltStore(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "reminder.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewBoltStore(db)
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	first := thread.NewKey("channel-io", "cht-app-github", 1)
+	// NOTE : #12 는 #1 의 요청을 삭제할 때 함께 삭제되지 않아야 합니다.
+	other := thread.NewKey("channel-io", "cht-app-github", 12)
+	requestedAt := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+
+	for _, request := range []Request{
+		{PullRequest: first, Reviewer: "Alice", RequestedAt: requestedAt},
+		{PullRequest: first, Reviewer: "bob", RequestedAt: requestedAt},
+		{PullRequest: other, Reviewer: "alice", RequestedAt: requestedAt},
+	} {
+		assert.NoError(t, s.Save(ctx, request))
+	}
+
+	requests, err := s.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 3)
+	assert.Equal(t, "Alice", requests[0].Reviewer)
+	assert.True(t, requestedAt.Equal(requests[0].RequestedAt))
+
+	// github username 은 대소문자를 구분하지 않음
+	assert.NoError(t, s.Delete(ctx, first, "alice"))
+	requests, err = s.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+
+	assert.NoError(t, s.DeletePullRequest(ctx, first))
+	requests, err = s.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, other, requests[0].PullRequest)
+}
@@ -0,0 +1,66 @@
+package reminder
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+// MemoryStore 는 프로세스 메모리에만 요청을 저장합니다. 로컬 개발 및 테스트 용도로 사용합니다.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	requests map[string]Request
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		requests: make(map[string]Request),
+	}
+}
+
+func (s *MemoryStore) Save(_ context.Context, request Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[request.ID()] = request
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key thread.Key, reviewer string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.requests, requestID(key, reviewer))
+	return nil
+}
+
+func (s *MemoryStore) DeletePullRequest(_ context.Context, key thread.Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := pullRequestPrefix(key)
+	for id := range s.requests {
+		if strings.HasPrefix(id, prefix) {
+			delete(s.requests, id)
+		}
+	}
+	return nil
+}
+
+// List 는 BoltStore 와 같이 id 순서로 반환합니다.
+func (s *MemoryStore) List(_ context.Context) ([]Request, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := make([]Request, 0, len(s.requests))
+	for _, request := range s.requests {
+		requests = append(requests, request)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID() < requests[j].ID()
+	})
+	return requests, nil
+}
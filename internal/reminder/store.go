@@ -0,0 +1,47 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/channel-io/cht-app-github/internal/thread"
+)
+
+// Request 는 pull request 에서 한 reviewer 에게 요청한 review 입니다.
+// review 를 작성하거나, 요청이 취소되거나, pull request 가 닫히면 삭제합니다.
+type Request struct {
+	PullRequest    thread.Key `json:"pullRequest"`
+	Reviewer       string     `json:"reviewer"`
+	InstallationID int64      `json:"installationId"`
+	Title          string     `json:"title"`
+	URL            string     `json:"url"`
+	RequestedAt    time.Time  `json:"requestedAt"`
+	// Reminded 는 지금까지 보낸 reminder 의 수입니다.
+	Reminded  int  `json:"reminded"`
+	Escalated bool `json:"escalated"`
+}
+
+func (r Request) ID() string {
+	return requestID(r.PullRequest, r.Reviewer)
+}
+
+// requestID 는 pull request 의 요청들이 같은 prefix 를 갖도록 pull request 뒤에 reviewer 를 붙입니다. github username 은 대소문자를 구분하지 않습니다.
+func requestID(key thread.Key, reviewer string) string {
+	return pullRequestPrefix(key) + strings.ToLower(reviewer)
+}
+
+func pullRequestPrefix(key thread.Key) string {
+	return fmt.Sprintf("%s/", key)
+}
+
+// Store 는 아직 review 를 받지 못한 요청을 영속적으로 저장합니다.
+type Store interface {
+	// Save 는 같은 reviewer 의 요청을 덮어씁니다.
+	Save(ctx context.Context, request Request) error
+	Delete(ctx context.Context, key thread.Key, reviewer string) error
+	// DeletePullRequest 는 pull request 의 모든 요청을 삭제합니다.
+	DeletePullRequest(ctx context.Context, key thread.Key) error
+	List(ctx context.Context) ([]Request, error)
+}
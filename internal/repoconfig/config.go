@@ -0,0 +1,252 @@
+package repoconfig
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// Config 는 repository 별 channeltalk.yml 설정입니다.
+//
+//	routes:
+//	  - name: ci-failures
+//	    events: [ci]
+//	    actions: [failure, error]
+//	    groupId: "123"
+type Config struct {
+	Routes []Route `yaml:"routes" json:"routes"`
+	Filter *Filter `yaml:"filter" json:"filter"`
+	// Locale 은 기본 message template 의 언어입니다. (ex. ko, en, ja)
+	Locale string `yaml:"locale" json:"locale"`
+	// Templates 는 message template 이름 별로 기본 template 을 덮어씁니다.
+	Templates map[string]string `yaml:"templates" json:"templates"`
+	CI        *CI               `yaml:"ci" json:"ci"`
+	Sync      *Sync             `yaml:"sync" json:"sync"`
+	// PullRequest 는 pull request 를 열었을 때 작성하는 요약 설정입니다.
+	PullRequest *PullRequest `yaml:"pullRequest" json:"pullRequest"`
+	Reminder    *Reminder    `yaml:"reminder" json:"reminder"`
+}
+
+// CI 는 commit 의 ci 결과 요약을 보낼 pull request 설정입니다.
+// 기본적으로 merge 된 pull request 의 결과만 보냅니다.
+//
+//	ci:
+//	  openPullRequests: true
+//	  notifyFixed: true
+type CI struct {
+	// OpenPullRequests 가 true 이면 열려있는 pull request 의 ci 실패를 작성자를 멘션해서 pull request thread 에 보냅니다.
+	OpenPullRequests *bool `yaml:"openPullRequests" json:"openPullRequests"`
+	// NotifyFixed 가 true 이면 실패했던 pull request 의 ci 가 다시 성공했을 때 알립니다.
+	NotifyFixed *bool `yaml:"notifyFixed" json:"notifyFixed"`
+}
+
+// Sync 는 팀챗에서 github 으로 동기화할 항목입니다. 기본적으로 github 에서 팀챗으로만 동기화합니다.
+//
+//	sync:
+//	  replies: true
+type Sync struct {
+	// Replies 가 true 이면 issue, pull request thread 에 manager 가 작성한 답글을 github comment 로 작성합니다.
+	Replies *bool `yaml:"replies" json:"replies"`
+}
+
+// pull request 요약에 작성할 수 있는 항목입니다.
+const (
+	SummaryStats     = "stats"
+	SummaryLabels    = "labels"
+	SummaryMilestone = "milestone"
+	SummaryReviewers = "reviewers"
+	SummaryIssues    = "issues"
+	SummaryBody      = "body"
+)
+
+// DefaultSummary 는 summary 를 설정하지 않은 경우 작성하는 항목입니다.
+var DefaultSummary = []string{SummaryStats, SummaryLabels, SummaryMilestone, SummaryReviewers, SummaryIssues}
+
+// DefaultBodyMaxLines 는 bodyMaxLines 를 설정하지 않은 경우 요약에 작성하는 pull request 본문의 줄 수입니다.
+const DefaultBodyMaxLines = 10
+
+// PullRequest 는 pull request 를 열었을 때 작성하는 요약 설정입니다.
+//
+//	pullRequest:
+//	  summary: [stats, reviewers, issues, body]
+//	  bodyMaxLines: 20
+type PullRequest struct {
+	// Summary 는 요약에 작성할 항목입니다. 설정하지 않으면 DefaultSummary 를 작성하고, 빈 목록이면 요약을 작성하지 않습니다.
+	Summary []string `yaml:"summary" json:"summary"`
+	// BodyMaxLines 는 요약에 작성할 pull request 본문의 최대 줄 수입니다.
+	BodyMaxLines *int `yaml:"bodyMaxLines" json:"bodyMaxLines"`
+}
+
+// HasSummary 는 요약에 field 를 작성하는지 확인합니다.
+func (p *PullRequest) HasSummary(field string) bool {
+	summary := DefaultSummary
+	if p != nil && p.Summary != nil {
+		summary = p.Summary
+	}
+	return lo.Contains(summary, field)
+}
+
+// MaxBodyLines 는 요약에 작성할 pull request 본문의 최대 줄 수입니다.
+func (p *PullRequest) MaxBodyLines() int {
+	if p == nil || p.BodyMaxLines == nil {
+		return DefaultBodyMaxLines
+	}
+	return *p.BodyMaxLines
+}
+
+// Reminder 는 review 를 요청한 뒤 review 가 없는 pull request 를 thread 에 다시 알리는 설정입니다. 설정하지 않으면 알리지 않습니다.
+//
+//	reminder:
+//	  after: [24h, 72h]
+//	  escalateAfter: 120h
+//	  escalateTo: [octocat]
+type Reminder struct {
+	// After 는 review 요청 후 reviewer 를 다시 멘션할 시간입니다.
+	After []time.Duration `yaml:"after" json:"after"`
+	// EscalateAfter 가 지나면 EscalateTo 의 github 사용자를 멘션합니다. EscalateTo 가 비어있으면 group 에 broadcast 합니다.
+	EscalateAfter *time.Duration `yaml:"escalateAfter" json:"escalateAfter"`
+	EscalateTo    []string       `yaml:"escalateTo" json:"escalateTo"`
+}
+
+// Enabled 는 reminder 혹은 escalation 을 보내는지 확인합니다.
+func (r *Reminder) Enabled() bool {
+	return r != nil && (len(r.After) > 0 || r.EscalateAfter != nil)
+}
+
+// Route 는 조건에 맞는 event 를 보낼 팀챗 group 입니다.
+// 비어있는 조건은 항상 만족하는 것으로 취급합니다.
+type Route struct {
+	Name         string   `yaml:"name" json:"name"`
+	Events       []string `yaml:"events" json:"events"`
+	Actions      []string `yaml:"actions" json:"actions"`
+	Labels       []string `yaml:"labels" json:"labels"`
+	Paths        []string `yaml:"paths" json:"paths"`
+	BaseBranches []string `yaml:"baseBranches" json:"baseBranches"`
+	ChannelID    string   `yaml:"channelId" json:"channelId"`
+	GroupID      string   `yaml:"groupId" json:"groupId"`
+}
+
+func Parse(data []byte) (*Config, error) {
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Merge 는 repository 설정을 organization 설정 위에 덮어씁니다.
+// route 는 repository 의 것을 먼저 평가합니다.
+func Merge(org, repo *Config) *Config {
+	merged := &Config{}
+	for _, c := range []*Config{repo, org} {
+		if c == nil {
+			continue
+		}
+		merged.Routes = append(merged.Routes, c.Routes...)
+	}
+	if org != nil {
+		merged.Filter = org.Filter
+	}
+	if repo != nil {
+		merged.Filter = mergeFilter(merged.Filter, repo.Filter)
+	}
+	for _, c := range []*Config{org, repo} {
+		if c == nil {
+			continue
+		}
+		merged.CI = mergeCI(merged.CI, c.CI)
+		merged.Sync = mergeSync(merged.Sync, c.Sync)
+		merged.PullRequest = mergePullRequest(merged.PullRequest, c.PullRequest)
+		merged.Reminder = mergeReminder(merged.Reminder, c.Reminder)
+		if c.Locale != "" {
+			merged.Locale = c.Locale
+		}
+		if len(c.Templates) == 0 {
+			continue
+		}
+		if merged.Templates == nil {
+			merged.Templates = make(map[string]string)
+		}
+		for name, text := range c.Templates {
+			merged.Templates[name] = text
+		}
+	}
+	return merged
+}
+
+// mergeCI 는 override 에 지정된 값으로 base 를 덮어씁니다.
+func mergeCI(base, override *CI) *CI {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if override.OpenPullRequests != nil {
+		merged.OpenPullRequests = override.OpenPullRequests
+	}
+	if override.NotifyFixed != nil {
+		merged.NotifyFixed = override.NotifyFixed
+	}
+	return &merged
+}
+
+// mergeSync 는 override 에 지정된 값으로 base 를 덮어씁니다.
+func mergeSync(base, override *Sync) *Sync {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if override.Replies != nil {
+		merged.Replies = override.Replies
+	}
+	return &merged
+}
+
+// mergePullRequest 는 override 에 지정된 값으로 base 를 덮어씁니다. summary 는 목록 전체를 덮어씁니다.
+func mergePullRequest(base, override *PullRequest) *PullRequest {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if override.Summary != nil {
+		merged.Summary = override.Summary
+	}
+	if override.BodyMaxLines != nil {
+		merged.BodyMaxLines = override.BodyMaxLines
+	}
+	return &merged
+}
+
+// mergeReminder 는 override 에 지정된 값으로 base 를 덮어씁니다. after, escalateTo 는 목록 전체를 덮어씁니다.
+func mergeReminder(base, override *Reminder) *Reminder {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if override.After != nil {
+		merged.After = override.After
+	}
+	if override.EscalateAfter != nil {
+		merged.EscalateAfter = override.EscalateAfter
+	}
+	if override.EscalateTo != nil {
+		merged.EscalateTo = override.EscalateTo
+	}
+	return &merged
+}
@@ -0,0 +1,105 @@
+package repoconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`
+routes:
+  - name: ci-failures
+    events: [ci]
+    actions: [failure]
+    groupId: "100"
+  - events: [pull_request]
+    paths: ["deploy/**"]
+    channelId: "1"
+    groupId: "200"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Route{
+		{Name: "ci-failures", Events: []string{"ci"}, Actions: []string{"failure"}, GroupID: "100"},
+		{Events: []string{"pull_request"}, Paths: []string{"deploy/**"}, ChannelID: "1", GroupID: "200"},
+	}, c.Routes)
+}
+
+func TestMerge(t *testing.T) {
+	org := &Config{Routes: []Route{{Name: "org"}}}
+	repo := &Config{Routes: []Route{{Name: "repo"}}}
+
+	merged := Merge(org, repo)
+	assert.Equal(t, []Route{{Name: "repo"}, {Name: "org"}}, merged.Routes)
+	assert.Equal(t, []Route{{Name: "org"}}, Merge(org, nil).Routes)
+}
+
+func TestMerge_CI(t *testing.T) {
+	org := &Config{CI: &CI{OpenPullRequests: lo.ToPtr(true), NotifyFixed: lo.ToPtr(true)}}
+	repo := &Config{CI: &CI{NotifyFixed: lo.ToPtr(false)}}
+
+	merged := Merge(org, repo).CI
+	assert.True(t, *merged.OpenPullRequests)
+	assert.False(t, *merged.NotifyFixed)
+	assert.Nil(t, Merge(nil, nil).CI)
+	// org 설정은 변경되지 않아야 합니다.
+	assert.True(t, *org.CI.NotifyFixed)
+}
+
+func TestMerge_Sync(t *testing.T) {
+	org := &Config{Sync: &Sync{Replies: lo.ToPtr(true)}}
+
+	assert.True(t, *Merge(org, &Config{}).Sync.Replies)
+	assert.False(t, *Merge(org, &Config{Sync: &Sync{Replies: lo.ToPtr(false)}}).Sync.Replies)
+	assert.Nil(t, Merge(nil, nil).Sync)
+}
+
+func TestMerge_PullRequest(t *testing.T) {
+	org := &Config{PullRequest: &PullRequest{Summary: []string{SummaryStats, SummaryBody}, BodyMaxLines: lo.ToPtr(5)}}
+
+	merged := Merge(org, &Config{PullRequest: &PullRequest{Summary: []string{}}}).PullRequest
+	assert.Equal(t, []string{}, merged.Summary)
+	assert.Equal(t, 5, merged.MaxBodyLines())
+	assert.False(t, merged.HasSummary(SummaryStats))
+
+	merged = Merge(org, nil).PullRequest
+	assert.True(t, merged.HasSummary(SummaryBody))
+	assert.False(t, merged.HasSummary(SummaryLabels))
+
+	var empty *PullRequest
+	assert.True(t, empty.HasSummary(SummaryStats))
+	assert.False(t, empty.HasSummary(SummaryBody))
+	assert.Equal(t, DefaultBodyMaxLines, empty.MaxBodyLines())
+}
+
+func TestMerge_Reminder(t *testing.T) {
+	org := &Config{Reminder: &Reminder{After: []time.Duration{24 * time.Hour}, EscalateTo: []string{"lead"}}}
+
+	merged := Merge(org, &Config{Reminder: &Reminder{EscalateAfter: lo.ToPtr(72 * time.Hour)}}).Reminder
+	assert.Equal(t, []time.Duration{24 * time.Hour}, merged.After)
+	assert.Equal(t, 72*time.Hour, *merged.EscalateAfter)
+	assert.Equal(t, []string{"lead"}, merged.EscalateTo)
+
+	merged = Merge(org, &Config{Reminder: &Reminder{After: []time.Duration{}}}).Reminder
+	assert.False(t, merged.Enabled())
+
+	var empty *Reminder
+	assert.False(t, empty.Enabled())
+}
+
+func TestParse_Reminder(t *testing.T) {
+	c, err := Parse([]byte("reminder:\n  after: [24h, 72h]\n  escalateAfter: 120h\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour, 72 * time.Hour}, c.Reminder.After)
+	assert.Equal(t, 120*time.Hour, *c.Reminder.EscalateAfter)
+	assert.True(t, c.Reminder.Enabled())
+}
+
+func TestParse_PullRequest(t *testing.T) {
+	c, err := Parse([]byte("pullRequest:\n  summary: []\n"))
+	assert.NoError(t, err)
+	assert.NotNil(t, c.PullRequest.Summary)
+	assert.False(t, c.PullRequest.HasSummary(SummaryStats))
+}
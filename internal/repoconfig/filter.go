@@ -0,0 +1,141 @@
+package repoconfig
+
+import (
+	"github.com/samber/lo"
+)
+
+// Filter 는 channel talk 으로 보낼 event 를 거르는 설정입니다.
+//
+//	filter:
+//	  ignoreBots: true
+//	  ignoreUsers: [renovate]
+//	  events:
+//	    pull_request:
+//	      ignoreActions: [synchronize]
+//	    status:
+//	      enabled: false
+type Filter struct {
+	// Events 는 webhook event 이름(ex. pull_request, issue_comment) 별 설정입니다.
+	Events       map[string]EventFilter `yaml:"events" json:"events"`
+	IgnoreBots   *bool                  `yaml:"ignoreBots" json:"ignoreBots"`
+	IgnoreUsers  []string               `yaml:"ignoreUsers" json:"ignoreUsers"`
+	IgnoreDrafts *bool                  `yaml:"ignoreDrafts" json:"ignoreDrafts"`
+	BaseBranches []string               `yaml:"baseBranches" json:"baseBranches"`
+	Labels       []string               `yaml:"labels" json:"labels"`
+	IgnoreLabels []string               `yaml:"ignoreLabels" json:"ignoreLabels"`
+}
+
+type EventFilter struct {
+	Enabled *bool `yaml:"enabled" json:"enabled"`
+	// Actions 가 비어있지 않으면 해당 action 만 보냅니다.
+	Actions       []string `yaml:"actions" json:"actions"`
+	IgnoreActions []string `yaml:"ignoreActions" json:"ignoreActions"`
+	// IgnoreBots 는 해당 event 에 대해 filter 의 ignoreBots 를 덮어씁니다.
+	IgnoreBots *bool `yaml:"ignoreBots" json:"ignoreBots"`
+	// IgnoreBotActions 의 action 은 ignoreBots 와 관계없이 bot 이 보낸 경우 보내지 않습니다.
+	IgnoreBotActions []string `yaml:"ignoreBotActions" json:"ignoreBotActions"`
+}
+
+// FilterSubject 는 filter 를 적용할 event 의 정보입니다.
+// 해당하지 않는 값(ex. status event 의 BaseBranch)은 비워두면 조건을 검사하지 않습니다.
+type FilterSubject struct {
+	Event       string
+	Action      string
+	SenderLogin string
+	SenderIsBot bool
+	Draft       bool
+	BaseBranch  string
+	Labels      []string
+	// HasLabels 는 labels 조건을 검사할 수 있는 event 인지 나타냅니다.
+	HasLabels bool
+}
+
+// Allows 는 subject 가 filter 를 통과하는지 확인합니다. nil filter 는 모든 event 를 통과시킵니다.
+func (f *Filter) Allows(subject FilterSubject) bool {
+	if f == nil {
+		return true
+	}
+
+	ignoreBots := lo.FromPtr(f.IgnoreBots)
+	if event, ok := f.Events[subject.Event]; ok {
+		if event.IgnoreBots != nil {
+			ignoreBots = *event.IgnoreBots
+		}
+		if subject.Action != "" && lo.Contains(event.IgnoreBotActions, subject.Action) {
+			ignoreBots = true
+		}
+		if event.Enabled != nil && !*event.Enabled {
+			return false
+		}
+		if subject.Action != "" {
+			if len(event.Actions) > 0 && !lo.Contains(event.Actions, subject.Action) {
+				return false
+			}
+			if lo.Contains(event.IgnoreActions, subject.Action) {
+				return false
+			}
+		}
+	}
+
+	if subject.SenderIsBot && ignoreBots {
+		return false
+	}
+	if subject.SenderLogin != "" && lo.Contains(f.IgnoreUsers, subject.SenderLogin) {
+		return false
+	}
+	if subject.Draft && lo.FromPtr(f.IgnoreDrafts) {
+		return false
+	}
+	if subject.BaseBranch != "" && len(f.BaseBranches) > 0 && !lo.Contains(f.BaseBranches, subject.BaseBranch) {
+		return false
+	}
+	if subject.HasLabels {
+		if len(f.Labels) > 0 && !lo.Some(f.Labels, subject.Labels) {
+			return false
+		}
+		if lo.Some(f.IgnoreLabels, subject.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeFilter 는 override 에 지정된 값으로 base 를 덮어씁니다. events 는 event 단위로 덮어씁니다.
+func mergeFilter(base, override *Filter) *Filter {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if len(override.Events) > 0 {
+		merged.Events = make(map[string]EventFilter, len(base.Events)+len(override.Events))
+		for name, event := range base.Events {
+			merged.Events[name] = event
+		}
+		for name, event := range override.Events {
+			merged.Events[name] = event
+		}
+	}
+	if override.IgnoreBots != nil {
+		merged.IgnoreBots = override.IgnoreBots
+	}
+	if override.IgnoreUsers != nil {
+		merged.IgnoreUsers = override.IgnoreUsers
+	}
+	if override.IgnoreDrafts != nil {
+		merged.IgnoreDrafts = override.IgnoreDrafts
+	}
+	if override.BaseBranches != nil {
+		merged.BaseBranches = override.BaseBranches
+	}
+	if override.Labels != nil {
+		merged.Labels = override.Labels
+	}
+	if override.IgnoreLabels != nil {
+		merged.IgnoreLabels = override.IgnoreLabels
+	}
+	return &merged
+}
@@ -0,0 +1,162 @@
+package repoconfig
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/github"
+)
+
+func TestFilter_Allows(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *Filter
+		subject  FilterSubject
+		expected bool
+	}{
+		{
+			name:     "nil filter",
+			filter:   nil,
+			subject:  FilterSubject{Event: "pull_request", Action: "opened", SenderIsBot: true},
+			expected: true,
+		},
+		{
+			name:     "disabled event",
+			filter:   &Filter{Events: map[string]EventFilter{"status": {Enabled: lo.ToPtr(false)}}},
+			subject:  FilterSubject{Event: "status"},
+			expected: false,
+		},
+		{
+			name:     "allowed actions",
+			filter:   &Filter{Events: map[string]EventFilter{"pull_request": {Actions: []string{"opened", "closed"}}}},
+			subject:  FilterSubject{Event: "pull_request", Action: "synchronize"},
+			expected: false,
+		},
+		{
+			name:     "ignored action",
+			filter:   &Filter{Events: map[string]EventFilter{"pull_request": {IgnoreActions: []string{"synchronize"}}}},
+			subject:  FilterSubject{Event: "pull_request", Action: "opened"},
+			expected: true,
+		},
+		{
+			name:     "bot sender",
+			filter:   &Filter{IgnoreBots: lo.ToPtr(true)},
+			subject:  FilterSubject{Event: "pull_request", SenderLogin: "dependabot[bot]", SenderIsBot: true},
+			expected: false,
+		},
+		{
+			name:     "bot sender allowed for event",
+			filter:   &Filter{IgnoreBots: lo.ToPtr(true), Events: map[string]EventFilter{"release": {IgnoreBots: lo.ToPtr(false)}}},
+			subject:  FilterSubject{Event: "release", Action: "published", SenderIsBot: true},
+			expected: true,
+		},
+		{
+			name:     "bot sender ignored for event",
+			filter:   &Filter{Events: map[string]EventFilter{"issues": {IgnoreBots: lo.ToPtr(true)}}},
+			subject:  FilterSubject{Event: "issues", Action: "opened", SenderIsBot: true},
+			expected: false,
+		},
+		{
+			name:     "bot sender ignored for action",
+			filter:   &Filter{Events: map[string]EventFilter{"pull_request": {IgnoreBotActions: []string{"review_requested"}}}},
+			subject:  FilterSubject{Event: "pull_request", Action: "review_requested", SenderIsBot: true},
+			expected: false,
+		},
+		{
+			name:     "bot sender allowed for other action",
+			filter:   &Filter{Events: map[string]EventFilter{"pull_request": {IgnoreBotActions: []string{"review_requested"}}}},
+			subject:  FilterSubject{Event: "pull_request", Action: "opened", SenderIsBot: true},
+			expected: true,
+		},
+		{
+			name:     "user sender allowed for bot action",
+			filter:   &Filter{Events: map[string]EventFilter{"pull_request": {IgnoreBotActions: []string{"review_requested"}}}},
+			subject:  FilterSubject{Event: "pull_request", Action: "review_requested", SenderLogin: "octocat"},
+			expected: true,
+		},
+		{
+			name:     "ignored user",
+			filter:   &Filter{IgnoreUsers: []string{"renovate"}},
+			subject:  FilterSubject{Event: "issues", SenderLogin: "renovate"},
+			expected: false,
+		},
+		{
+			name:     "draft",
+			filter:   &Filter{IgnoreDrafts: lo.ToPtr(true)},
+			subject:  FilterSubject{Event: "pull_request", Draft: true},
+			expected: false,
+		},
+		{
+			name:     "base branch",
+			filter:   &Filter{BaseBranches: []string{"main"}},
+			subject:  FilterSubject{Event: "pull_request", BaseBranch: "feature"},
+			expected: false,
+		},
+		{
+			name:     "base branch is not checked without branch",
+			filter:   &Filter{BaseBranches: []string{"main"}},
+			subject:  FilterSubject{Event: "release"},
+			expected: true,
+		},
+		{
+			name:     "required label",
+			filter:   &Filter{Labels: []string{"notify"}},
+			subject:  FilterSubject{Event: "issues", HasLabels: true, Labels: []string{"bug"}},
+			expected: false,
+		},
+		{
+			name:     "ignored label",
+			filter:   &Filter{IgnoreLabels: []string{"no-notify"}},
+			subject:  FilterSubject{Event: "issues", HasLabels: true, Labels: []string{"bug", "no-notify"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Allows(tt.subject))
+		})
+	}
+}
+
+func TestMerge_Filter(t *testing.T) {
+	org := &Config{Filter: &Filter{
+		IgnoreBots: lo.ToPtr(true),
+		Events: map[string]EventFilter{
+			"status":       {Enabled: lo.ToPtr(false)},
+			"pull_request": {IgnoreActions: []string{"synchronize"}},
+		},
+	}}
+	repo := &Config{Filter: &Filter{
+		IgnoreBots: lo.ToPtr(false),
+		Events: map[string]EventFilter{
+			"status": {Enabled: lo.ToPtr(true)},
+		},
+	}}
+
+	merged := Merge(org, repo).Filter
+	assert.False(t, *merged.IgnoreBots)
+	assert.True(t, *merged.Events["status"].Enabled)
+	assert.Equal(t, []string{"synchronize"}, merged.Events["pull_request"].IgnoreActions)
+	// org 설정은 변경되지 않아야 합니다.
+	assert.False(t, *org.Filter.Events["status"].Enabled)
+}
+
+func TestDefaultConfig(t *testing.T) {
+	k8s := defaultConfig(github.NewInstallationContext(1, "Channel-IO"), "k8s")
+	assert.Nil(t, defaultConfig(github.NewInstallationContext(1, "channel-io"), "ch-api"))
+
+	// only review requests sent by bots are ignored
+	bot := FilterSubject{Event: "pull_request", Action: "review_requested", SenderLogin: "renovate[bot]", SenderIsBot: true}
+	assert.False(t, k8s.Filter.Allows(bot))
+	assert.True(t, k8s.Filter.Allows(FilterSubject{Event: "pull_request", Action: "opened", SenderLogin: "renovate[bot]", SenderIsBot: true}))
+	assert.True(t, k8s.Filter.Allows(FilterSubject{Event: "issue_comment", Action: "created", SenderLogin: "renovate[bot]", SenderIsBot: true}))
+	assert.True(t, k8s.Filter.Allows(FilterSubject{Event: "pull_request", Action: "review_requested", SenderLogin: "octocat"}))
+
+	// default can be overridden by the repository
+	merged := Merge(Merge(k8s, nil), &Config{Filter: &Filter{Events: map[string]EventFilter{"pull_request": {}}}})
+	assert.True(t, merged.Filter.Allows(bot))
+	assert.Equal(t, []string{"review_requested"}, defaultConfigs["channel-io/k8s"].Filter.Events["pull_request"].IgnoreBotActions)
+}
@@ -0,0 +1,184 @@
+package repoconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+// orgRepository 는 organization 공통 설정을 두는 repository 입니다.
+const orgRepository = ".github"
+
+// defaultConfigs 는 설정 파일 도입 이전에 코드로 정해져 있던 repository 별 동작입니다. ("org/repository" 별)
+// organization 파일, custom property, repository 파일로 덮어쓸 수 있습니다.
+// NOTE : channel-io/k8s 는 bot 이 보낸 review 요청만 보내지 않았습니다. events.pull_request 를 설정하면 함께 덮어써집니다.
+var defaultConfigs = map[string]Config{
+	"channel-io/k8s": {Filter: &Filter{Events: map[string]EventFilter{
+		"pull_request": {IgnoreBotActions: []string{"review_requested"}},
+	}}},
+}
+
+type Service struct {
+	githubSvc github.Service
+	cache     github.Cache[Config]
+	path      string
+	orgPath   string
+	filterKey string
+	ttl       time.Duration
+}
+
+func NewService(conf *config.Config, githubSvc github.Service) *Service {
+	return &Service{
+		githubSvc: githubSvc,
+		cache:     cache.NewLocalCache[Config](),
+		path:      conf.Github.RepoConfig.Path,
+		orgPath:   conf.Github.RepoConfig.OrgPath,
+		filterKey: conf.Github.Properties.FilterKey,
+		ttl:       conf.Github.RepoConfig.TTL,
+	}
+}
+
+// Find 는 organization 과 repository 의 설정을 합쳐 반환합니다. 설정 파일이 없으면 빈 설정을 반환합니다.
+// 설정은 organization 파일, repository custom property, repository 파일 순서로 덮어씁니다.
+func (s *Service) Find(ctx context.Context, installCtx github.InstallationContext, repository string) (*Config, error) {
+	org, err := s.find(ctx, installCtx, orgRepository, s.orgPath)
+	if err != nil {
+		return nil, err
+	}
+	if repository == orgRepository {
+		return org, nil
+	}
+
+	property, err := s.findFilterProperty(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.find(ctx, installCtx, repository, s.path)
+	if err != nil {
+		return nil, err
+	}
+	return Merge(Merge(Merge(defaultConfig(installCtx, repository), org), property), repo), nil
+}
+
+func defaultConfig(installCtx github.InstallationContext, repository string) *Config {
+	c, ok := defaultConfigs[strings.ToLower(fmt.Sprintf("%s/%s", installCtx.OrgLogin, repository))]
+	if !ok {
+		return nil
+	}
+	return &c
+}
+
+// FindFilter 는 repository 에 적용할 filter 를 반환합니다.
+func (s *Service) FindFilter(ctx context.Context, installCtx github.InstallationContext, repository string) (*Filter, error) {
+	c, err := s.Find(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	return c.Filter, nil
+}
+
+// FindCI 는 repository 에 적용할 ci 설정을 반환합니다. 설정이 없으면 빈 설정을 반환합니다.
+func (s *Service) FindCI(ctx context.Context, installCtx github.InstallationContext, repository string) (*CI, error) {
+	c, err := s.Find(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if c.CI == nil {
+		return &CI{}, nil
+	}
+	return c.CI, nil
+}
+
+// FindSync 는 repository 에 적용할 동기화 설정을 반환합니다. 설정이 없으면 빈 설정을 반환합니다.
+func (s *Service) FindSync(ctx context.Context, installCtx github.InstallationContext, repository string) (*Sync, error) {
+	c, err := s.Find(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if c.Sync == nil {
+		return &Sync{}, nil
+	}
+	return c.Sync, nil
+}
+
+// FindPullRequest 는 repository 에 적용할 pull request 요약 설정을 반환합니다. 설정이 없으면 빈 설정을 반환합니다.
+func (s *Service) FindPullRequest(ctx context.Context, installCtx github.InstallationContext, repository string) (*PullRequest, error) {
+	c, err := s.Find(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if c.PullRequest == nil {
+		return &PullRequest{}, nil
+	}
+	return c.PullRequest, nil
+}
+
+// FindReminder 는 repository 에 적용할 review reminder 설정을 반환합니다. 설정이 없으면 빈 설정을 반환합니다.
+func (s *Service) FindReminder(ctx context.Context, installCtx github.InstallationContext, repository string) (*Reminder, error) {
+	c, err := s.Find(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if c.Reminder == nil {
+		return &Reminder{}, nil
+	}
+	return c.Reminder, nil
+}
+
+// findFilterProperty 는 filter custom property 를 읽습니다. 값은 channeltalk.yml 의 filter 와 같은 형식의 YAML 입니다.
+// ex) {ignoreBots: true, events: {status: {enabled: false}}}
+func (s *Service) findFilterProperty(ctx context.Context, installCtx github.InstallationContext, repository string) (*Config, error) {
+	if s.filterKey == "" {
+		return nil, nil
+	}
+	properties, err := s.githubSvc.FindCustomProperties(ctx, installCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := properties[s.filterKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	var filter Filter
+	if err := yaml.Unmarshal([]byte(value), &filter); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse custom property %s in Org(%s) Repository(%s)", s.filterKey, installCtx.OrgLogin, repository)
+	}
+	return &Config{Filter: &filter}, nil
+}
+
+func (s *Service) find(ctx context.Context, installCtx github.InstallationContext, repository, path string) (*Config, error) {
+	key := fmt.Sprintf("%s:%s:%s", installCtx.OrgLogin, repository, path)
+	cached, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	content, err := s.githubSvc.FindRepositoryFile(ctx, installCtx, repository, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE : 파일이 없는 경우에도 빈 설정을 cache 하여 매 event 마다 조회하지 않도록 합니다.
+	c := &Config{}
+	if content != nil {
+		c, err = Parse(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s in Org(%s) Repository(%s)", path, installCtx.OrgLogin, repository)
+		}
+	}
+	_ = s.cache.Set(ctx, key, *c, s.ttl)
+	return c, nil
+}
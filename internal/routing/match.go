@@ -0,0 +1,56 @@
+package routing
+
+import (
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+)
+
+// matches 는 route 의 조건을 target 이 모두 만족하는지 확인합니다.
+// files 는 pull request 에 paths 조건이 있을 때만 호출됩니다.
+func matches(route repoconfig.Route, target Target, files func() ([]string, error)) (bool, error) {
+	if len(route.Events) > 0 && !lo.Contains(route.Events, string(target.Family)) {
+		return false, nil
+	}
+	if len(route.Actions) > 0 && !lo.Contains(route.Actions, target.Action) {
+		return false, nil
+	}
+	if len(route.BaseBranches) > 0 && !lo.Contains(route.BaseBranches, target.BaseBranch) {
+		return false, nil
+	}
+	if len(route.Labels) > 0 && !lo.Some(route.Labels, target.Labels) {
+		return false, nil
+	}
+	if len(route.Paths) == 0 {
+		return true, nil
+	}
+	// NOTE: 변경 파일은 pull request 에만 있으므로 다른 이벤트는 paths 조건을 만족하지 않습니다.
+	if target.Family != FamilyPullRequest {
+		return false, nil
+	}
+
+	changed, err := files()
+	if err != nil {
+		return false, err
+	}
+	for _, pattern := range route.Paths {
+		for _, file := range changed {
+			if matchPath(pattern, file) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// matchPath 는 path.Match 에 더해 "dir/**" 형태로 하위 경로 전체를 지정할 수 있게 합니다.
+func matchPath(pattern, file string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return strings.HasPrefix(file, prefix+"/")
+	}
+	matched, err := path.Match(pattern, file)
+	return err == nil && matched
+}
@@ -0,0 +1,115 @@
+package routing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+)
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    repoconfig.Route
+		target   Target
+		files    []string
+		expected bool
+	}{
+		{
+			name:     "empty route matches everything",
+			route:    repoconfig.Route{},
+			target:   Target{Family: FamilyIssue},
+			expected: true,
+		},
+		{
+			name:     "event mismatch",
+			route:    repoconfig.Route{Events: []string{"ci"}},
+			target:   Target{Family: FamilyIssue},
+			expected: false,
+		},
+		{
+			name:     "event and action",
+			route:    repoconfig.Route{Events: []string{"ci"}, Actions: []string{"failure", "error"}},
+			target:   Target{Family: FamilyCI, Action: "failure"},
+			expected: true,
+		},
+		{
+			name:     "action mismatch",
+			route:    repoconfig.Route{Events: []string{"ci"}, Actions: []string{"failure"}},
+			target:   Target{Family: FamilyCI, Action: "success"},
+			expected: false,
+		},
+		{
+			name:     "any label",
+			route:    repoconfig.Route{Labels: []string{"security", "infra"}},
+			target:   Target{Family: FamilyPullRequest, Labels: []string{"infra", "wip"}},
+			expected: true,
+		},
+		{
+			name:     "no label",
+			route:    repoconfig.Route{Labels: []string{"security"}},
+			target:   Target{Family: FamilyPullRequest},
+			expected: false,
+		},
+		{
+			name:     "base branch",
+			route:    repoconfig.Route{BaseBranches: []string{"main"}},
+			target:   Target{Family: FamilyPullRequest, BaseBranch: "develop"},
+			expected: false,
+		},
+		{
+			name:     "recursive path",
+			route:    repoconfig.Route{Paths: []string{"deploy/**"}},
+			target:   Target{Family: FamilyPullRequest, Number: 1},
+			files:    []string{"README.md", "deploy/k8s/values.yaml"},
+			expected: true,
+		},
+		{
+			name:     "glob path",
+			route:    repoconfig.Route{Paths: []string{"*.md"}},
+			target:   Target{Family: FamilyPullRequest, Number: 1},
+			files:    []string{"internal/main.go"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := matches(tt.route, tt.target, func() ([]string, error) {
+				return tt.files, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestMatches_FilesAreFetchedOnlyForPaths(t *testing.T) {
+	fetchErr := errors.New("must not be called")
+	files := func() ([]string, error) {
+		return nil, fetchErr
+	}
+
+	matched, err := matches(repoconfig.Route{Events: []string{"ci"}}, Target{Family: FamilyPullRequest}, files)
+	assert.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = matches(repoconfig.Route{Paths: []string{"**"}}, Target{Family: FamilyPullRequest}, files)
+	assert.ErrorIs(t, err, fetchErr)
+}
+
+func TestMatches_PathsOnlyMatchPullRequests(t *testing.T) {
+	fetchErr := errors.New("must not be called")
+	files := func() ([]string, error) {
+		return nil, fetchErr
+	}
+	route := repoconfig.Route{Paths: []string{"**"}}
+
+	for _, family := range []Family{FamilyIssue, FamilyDiscussion} {
+		matched, err := matches(route, Target{Family: family, Number: 1}, files)
+		assert.NoError(t, err)
+		assert.False(t, matched)
+	}
+}
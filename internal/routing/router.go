@@ -0,0 +1,179 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+)
+
+// Destination 은 routing 결과입니다.
+// Explicit 은 channeltalk.yml 이나 family 별 custom property 로 지정된 경우 true 입니다.
+type Destination struct {
+	Group    model.Group
+	Explicit bool
+}
+
+type Router struct {
+	githubSvc     github.Service
+	repoConfigSvc *repoconfig.Service
+	channelIDKey  string
+	groupIDKey    string
+}
+
+func NewRouter(conf *config.Config, githubSvc github.Service, repoConfigSvc *repoconfig.Service) *Router {
+	return &Router{
+		githubSvc:     githubSvc,
+		repoConfigSvc: repoConfigSvc,
+		channelIDKey:  conf.Github.Properties.ChannelIdKey,
+		groupIDKey:    conf.Github.Properties.GroupIdKey,
+	}
+}
+
+// Route 는 다음 순서로 target 을 보낼 group 을 찾습니다.
+//  1. channeltalk.yml 의 routes 중 처음으로 조건을 만족하는 route
+//  2. "{groupIdKey}_{family}" custom property (ex. cht_group_id_ci)
+//  3. 기존 group (release 는 release group)
+//
+// NOTE : channeltalk.yml 로만 routing 하는 repository 는 기존 group custom property 가 없을 수 있으므로, 기존 group 은 필요할 때만 조회합니다.
+func (r *Router) Route(ctx context.Context, installCtx github.InstallationContext, repository string, target Target) (Destination, error) {
+	fallback := r.lazyFallbackGroup(ctx, installCtx, repository, target.Family)
+
+	conf, err := r.repoConfigSvc.Find(ctx, installCtx, repository)
+	if err != nil {
+		return Destination{}, err
+	}
+
+	var changedFiles []string
+	files := func() ([]string, error) {
+		if changedFiles != nil || target.Family != FamilyPullRequest || target.Number == 0 {
+			return changedFiles, nil
+		}
+		changedFiles, err = r.githubSvc.ListPullRequestFiles(ctx, installCtx, repository, target.Number)
+		return changedFiles, err
+	}
+
+	for _, route := range conf.Routes {
+		matched, err := matches(route, target, files)
+		if err != nil {
+			return Destination{}, err
+		}
+		if !matched {
+			continue
+		}
+		group, err := routeGroup(route, fallback)
+		if err != nil {
+			return Destination{}, err
+		}
+		return Destination{Group: group, Explicit: true}, nil
+	}
+
+	properties, err := r.githubSvc.FindCustomProperties(ctx, installCtx, repository)
+	if err != nil {
+		return Destination{}, err
+	}
+	if groupID := properties[fmt.Sprintf("%s_%s", r.groupIDKey, target.Family)]; groupID != "" {
+		channelID := properties[r.channelIDKey]
+		if channelID == "" {
+			group, err := fallback()
+			if err != nil {
+				return Destination{}, err
+			}
+			channelID = group.ChannelID
+		}
+		return Destination{
+			Group:    model.Group{ChannelID: channelID, ID: groupID},
+			Explicit: true,
+		}, nil
+	}
+
+	group, err := fallback()
+	if err != nil {
+		return Destination{}, err
+	}
+	return Destination{Group: group}, nil
+}
+
+// FindChannelID 는 family 의 message 를 보내는 channel 입니다. message 에 멘션할 manager 나 team 을 찾을 때 사용합니다.
+// label, path 처럼 event 마다 다른 조건은 확인하지 않고 events 가 family 를 포함하는 route 중 channel 을 지정한 첫 route 를 사용합니다.
+// family 가 비어있으면 events 와 관계없이 channel 을 지정한 첫 route 를 사용합니다.
+// 그런 route 가 없으면 repository 의 channel custom property 를 사용합니다.
+func (r *Router) FindChannelID(ctx context.Context, installCtx github.InstallationContext, repository string, family Family) (string, error) {
+	conf, err := r.repoConfigSvc.Find(ctx, installCtx, repository)
+	if err != nil {
+		return "", err
+	}
+	if channelID := routedChannelID(conf.Routes, family); channelID != "" {
+		return channelID, nil
+	}
+
+	properties, err := r.githubSvc.FindCustomProperties(ctx, installCtx, repository)
+	if err != nil {
+		return "", err
+	}
+	if channelID := properties[r.channelIDKey]; channelID != "" {
+		return channelID, nil
+	}
+	return "", errors.Errorf("%s custom property or channelId of a route required in Org(%s) Repository(%s)", r.channelIDKey, installCtx.OrgLogin, repository)
+}
+
+// routeGroup 은 route 에 channel 이나 group 이 비어있을 때만 기존 group 으로 채웁니다.
+func routeGroup(route repoconfig.Route, fallback func() (model.Group, error)) (model.Group, error) {
+	group := model.Group{ChannelID: route.ChannelID, ID: route.GroupID}
+	if group.ChannelID != "" && group.ID != "" {
+		return group, nil
+	}
+
+	fallbackGroup, err := fallback()
+	if err != nil {
+		return model.Group{}, err
+	}
+	if group.ChannelID == "" {
+		group.ChannelID = fallbackGroup.ChannelID
+	}
+	if group.ID == "" {
+		group.ID = fallbackGroup.ID
+	}
+	return group, nil
+}
+
+func routedChannelID(routes []repoconfig.Route, family Family) string {
+	for _, route := range routes {
+		if route.ChannelID == "" {
+			continue
+		}
+		if family == "" || len(route.Events) == 0 || lo.Contains(route.Events, string(family)) {
+			return route.ChannelID
+		}
+	}
+	return ""
+}
+
+// lazyFallbackGroup 은 기존 group 을 처음 호출할 때 한 번만 조회합니다.
+func (r *Router) lazyFallbackGroup(ctx context.Context, installCtx github.InstallationContext, repository string, family Family) func() (model.Group, error) {
+	var (
+		group  model.Group
+		err    error
+		loaded bool
+	)
+	return func() (model.Group, error) {
+		if !loaded {
+			group, err = r.fallbackGroup(ctx, installCtx, repository, family)
+			loaded = true
+		}
+		return group, err
+	}
+}
+
+func (r *Router) fallbackGroup(ctx context.Context, installCtx github.InstallationContext, repository string, family Family) (model.Group, error) {
+	if family == FamilyRelease {
+		return r.githubSvc.FindReleaseGroup(ctx, installCtx, repository)
+	}
+	return r.githubSvc.FindGroup(ctx, installCtx, repository)
+}
@@ -0,0 +1,49 @@
+package routing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+)
+
+func TestRouteGroup(t *testing.T) {
+	fallbackErr := errors.New("group custom property required")
+	missing := func() (model.Group, error) {
+		return model.Group{}, fallbackErr
+	}
+	fallback := func() (model.Group, error) {
+		return model.Group{ChannelID: "1", ID: "10"}, nil
+	}
+
+	// fallback is not required when the route has both channel and group
+	group, err := routeGroup(repoconfig.Route{ChannelID: "2", GroupID: "20"}, missing)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Group{ChannelID: "2", ID: "20"}, group)
+
+	group, err = routeGroup(repoconfig.Route{GroupID: "20"}, fallback)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Group{ChannelID: "1", ID: "20"}, group)
+
+	_, err = routeGroup(repoconfig.Route{GroupID: "20"}, missing)
+	assert.ErrorIs(t, err, fallbackErr)
+}
+
+func TestRoutedChannelID(t *testing.T) {
+	routes := []repoconfig.Route{
+		{Events: []string{"ci"}, GroupID: "10"},
+		{Events: []string{"ci"}, ChannelID: "1", GroupID: "11"},
+		{Events: []string{"issue", "pull_request"}, ChannelID: "2", GroupID: "20"},
+		{ChannelID: "3", GroupID: "30"},
+	}
+
+	assert.Equal(t, "1", routedChannelID(routes, FamilyCI))
+	assert.Equal(t, "2", routedChannelID(routes, FamilyPullRequest))
+	assert.Equal(t, "3", routedChannelID(routes, FamilyRelease))
+	assert.Equal(t, "", routedChannelID(routes[:1], FamilyCI))
+	// any family
+	assert.Equal(t, "1", routedChannelID(routes, ""))
+}
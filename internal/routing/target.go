@@ -0,0 +1,23 @@
+package routing
+
+// Family 는 routing 규칙에서 event 를 묶는 단위입니다.
+type Family string
+
+const (
+	FamilyIssue       Family = "issue"
+	FamilyPullRequest Family = "pull_request"
+	FamilyRelease     Family = "release"
+	FamilyCI          Family = "ci"
+	FamilyDiscussion  Family = "discussion"
+	FamilyDeployment  Family = "deployment"
+)
+
+// Target 은 routing 할 event 의 정보입니다.
+// Number 가 있는 pull request 는 규칙에 paths 조건이 있을 때만 변경된 파일을 조회합니다.
+type Target struct {
+	Family     Family
+	Action     string
+	Labels     []string
+	BaseBranch string
+	Number     int
+}
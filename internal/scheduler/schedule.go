@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule 은 job 을 실행할 시각을 정합니다.
+type Schedule interface {
+	// Next 는 after 이후에 job 을 실행할 가장 빠른 시각입니다.
+	Next(after time.Time) time.Time
+}
+
+// Clock 은 location 의 정해진 시각에 실행하는 schedule 입니다. Weekdays 가 비어있으면 매일 실행합니다.
+type Clock struct {
+	Hour     int
+	Minute   int
+	Location *time.Location
+	Weekdays []time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseClock 은 "09:30" 형식의 시각, IANA timezone, 요일(mon, tue, ...)로 Clock 을 만듭니다.
+// timezone 이 비어있으면 UTC 입니다.
+func ParseClock(clock, timezone string, days []string) (Clock, error) {
+	at, err := time.Parse("15:04", clock)
+	if err != nil {
+		return Clock{}, errors.Wrapf(err, "invalid time %q", clock)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Clock{}, errors.Wrapf(err, "invalid timezone %q", timezone)
+	}
+
+	c := Clock{Hour: at.Hour(), Minute: at.Minute(), Location: location}
+	for _, day := range days {
+		weekday, ok := weekdays[strings.ToLower(day)[:min(3, len(day))]]
+		if !ok {
+			return Clock{}, errors.Errorf("invalid weekday %q", day)
+		}
+		c.Weekdays = append(c.Weekdays, weekday)
+	}
+	return c, nil
+}
+
+func (c Clock) Next(after time.Time) time.Time {
+	local := after.In(c.Location)
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		next := time.Date(day.Year(), day.Month(), day.Day(), c.Hour, c.Minute, 0, 0, c.Location)
+		if next.After(after) && c.runsOn(next.Weekday()) {
+			return next
+		}
+	}
+	// NOTE : Weekdays 에 없는 요일만 있을 수는 없으므로 도달하지 않습니다.
+	return after.Add(24 * time.Hour)
+}
+
+func (c Clock) runsOn(weekday time.Weekday) bool {
+	if len(c.Weekdays) == 0 {
+		return true
+	}
+	for _, w := range c.Weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Every 는 일정한 간격으로 실행하는 schedule 입니다.
+type Every time.Duration
+
+func (e Every) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock_Next(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	assert.NoError(t, err)
+
+	daily, err := ParseClock("09:30", "Asia/Seoul", nil)
+	assert.NoError(t, err)
+	weekly, err := ParseClock("09:30", "Asia/Seoul", []string{"Monday"})
+	assert.NoError(t, err)
+
+	// 2024-06-05 is Wednesday
+	before := time.Date(2024, 6, 5, 9, 0, 0, 0, seoul)
+	after := time.Date(2024, 6, 5, 9, 30, 0, 0, seoul)
+	assert.Equal(t, time.Date(2024, 6, 5, 9, 30, 0, 0, seoul), daily.Next(before))
+	assert.Equal(t, time.Date(2024, 6, 6, 9, 30, 0, 0, seoul), daily.Next(after))
+	assert.Equal(t, time.Date(2024, 6, 10, 9, 30, 0, 0, seoul), weekly.Next(before))
+	assert.Equal(t, time.Date(2024, 6, 5, 9, 30, 0, 0, seoul), daily.Next(before.UTC()).In(seoul))
+}
+
+func TestParseClock_Invalid(t *testing.T) {
+	_, err := ParseClock("9:30pm", "", nil)
+	assert.Error(t, err)
+	_, err = ParseClock("09:30", "Mars/Base", nil)
+	assert.Error(t, err)
+	_, err = ParseClock("09:30", "UTC", []string{"someday"})
+	assert.Error(t, err)
+}
+
+func TestEvery_Next(t *testing.T) {
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, now.Add(time.Hour), Every(time.Hour).Next(now))
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/channel-io/cht-app-github/internal/logger"
+)
+
+// Job 은 Schedule 마다 실행하는 작업입니다.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler 는 등록된 job 을 각자의 schedule 에 따라 실행합니다.
+// 실패한 job 은 재시도하지 않고 다음 시각에 다시 실행합니다.
+type Scheduler struct {
+	jobs   []Job
+	logger logger.Logger
+	now    func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(logger logger.Logger, jobs []Job) *Scheduler {
+	return &Scheduler{
+		jobs:   jobs,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		job := job
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.run(ctx, job)
+		}()
+	}
+}
+
+// Stop 은 실행중인 job 이 끝날 때까지 기다립니다.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	for {
+		next := job.Schedule.Next(s.now())
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// NOTE : 종료 시점에 job 이 중단되지 않도록 scheduler 의 context 를 넘기지 않습니다.
+		if err := job.Run(context.Background()); err != nil {
+			s.logger.Errorw("scheduled job failed", "job", job.Name, "error", err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	t.Parallel()
+
+	var succeeded, failed atomic.Int32
+	s := NewScheduler(nopLogger{}, []Job{
+		{
+			Name:     "success",
+			Schedule: Every(10 * time.Millisecond),
+			Run: func(context.Context) error {
+				succeeded.Add(1)
+				return nil
+			},
+		},
+		{
+			Name:     "failure",
+			Schedule: Every(10 * time.Millisecond),
+			Run: func(context.Context) error {
+				failed.Add(1)
+				return assert.AnError
+			},
+		},
+	})
+	s.Start()
+
+	assert.Eventually(t, func() bool {
+		return succeeded.Load() >= 2 && failed.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, s.Stop(context.Background()))
+
+	stopped := succeeded.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, succeeded.Load())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Error(...interface{})          {}
+func (nopLogger) Errorw(string, ...interface{}) {}
+func (nopLogger) Warn(...interface{})           {}
+func (nopLogger) Warnw(string, ...interface{})  {}
+func (nopLogger) Info(...interface{})           {}
+func (nopLogger) Infow(string, ...interface{})  {}
+func (nopLogger) Debug(...interface{})          {}
+func (nopLogger) Debugw(string, ...interface{}) {}
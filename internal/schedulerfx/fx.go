@@ -0,0 +1,72 @@
+package schedulerfx
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/digest"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/scheduler"
+)
+
+// JobsResult 는 scheduler 에 등록할 job 들입니다. 다른 module 도 같은 group 으로 job 을 등록할 수 있습니다.
+type JobsResult struct {
+	fx.Out
+
+	Jobs []scheduler.Job `group:"scheduler.jobs,flatten"`
+}
+
+func NewDigestJobs(conf *config.Config, digestSvc *digest.Service) (JobsResult, error) {
+	jobs, err := digestSvc.Jobs(conf.Digest.Schedules)
+	if err != nil {
+		return JobsResult{}, err
+	}
+	return JobsResult{Jobs: jobs}, nil
+}
+
+// NewReminderJob 은 interval 이 0 이하이면 review reminder 를 보내지 않습니다.
+func NewReminderJob(conf *config.Config, reminderSvc *svc.ReminderSvc) JobsResult {
+	if conf.Reminder.Interval <= 0 {
+		return JobsResult{}
+	}
+	return JobsResult{Jobs: []scheduler.Job{reminderSvc.Job()}}
+}
+
+type SchedulerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *config.Config
+	Logger    logger.Logger
+	Jobs      []scheduler.Job `group:"scheduler.jobs"`
+}
+
+// NewScheduler 는 scheduler.enabled 가 꺼져있으면 job 을 실행하지 않습니다.
+func NewScheduler(p SchedulerParams) *scheduler.Scheduler {
+	s := scheduler.NewScheduler(p.Logger, p.Jobs)
+	if !p.Config.Scheduler.Enabled {
+		p.Logger.Infow("scheduler is disabled on this instance", "jobs", len(p.Jobs))
+		return s
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			s.Start()
+			return nil
+		},
+		OnStop: s.Stop,
+	})
+	return s
+}
+
+var Option = fx.Options(
+	fx.Provide(
+		digest.NewService,
+		NewDigestJobs,
+		NewReminderJob,
+		NewScheduler,
+	),
+	fx.Invoke(func(*scheduler.Scheduler) {}),
+)
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+)
+
+type Driver = string
+
+const (
+	DriverMemory Driver = "memory"
+	DriverBolt   Driver = "bolt"
+)
+
+// BoltDB 는 여러 store 가 하나의 bbolt 파일을 공유할 수 있도록 db 를 lazy 하게 open 합니다.
+// NOTE : bbolt 는 하나의 파일에 대해 프로세스 단위 lock 을 잡기 때문에, 같은 파일을 두 번 open 할 수 없습니다.
+// 따라서 bolt driver 는 replica 하나에서만 사용할 수 있습니다. 여러 replica 는 store interface 를 구현한 공유 저장소가 필요합니다.
+type BoltDB struct {
+	path string
+
+	once sync.Once
+	db   *bbolt.DB
+	err  error
+}
+
+func NewBoltDB(conf *config.Config) *BoltDB {
+	return &BoltDB{
+		path: conf.Storage.Bolt.Path,
+	}
+}
+
+func (b *BoltDB) Open() (*bbolt.DB, error) {
+	b.once.Do(func() {
+		if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+			b.err = errors.Wrapf(err, "failed to create directory for %s", b.path)
+			return
+		}
+		b.db, b.err = bbolt.Open(b.path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+		if b.err != nil {
+			b.err = errors.Wrapf(b.err, "failed to open bolt db %s", b.path)
+		}
+	})
+	return b.db, b.err
+}
+
+func (b *BoltDB) Close() error {
+	if b.db == nil {
+		return nil
+	}
+	return b.db.Close()
+}
@@ -0,0 +1,166 @@
+package storagefx
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+	"go.uber.org/fx"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/event"
+	"github.com/channel-io/cht-app-github/internal/event/svc"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/queue"
+	"github.com/channel-io/cht-app-github/internal/reminder"
+	"github.com/channel-io/cht-app-github/internal/scheduler"
+	"github.com/channel-io/cht-app-github/internal/storage"
+	"github.com/channel-io/cht-app-github/internal/thread"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+const (
+	deliveriesBucket       = "deliveries"
+	pullRequestCIBucket    = "pull_request_ci"
+	mirroredMessagesBucket = "mirrored_messages"
+	commitStatesBucket     = "commit_states"
+
+	cacheSweepInterval = time.Hour
+)
+
+// cacheBuckets 는 BoltCache 로 사용하는 bucket 들입니다. 조회되지 않는 값은 주기적으로 정리합니다.
+var cacheBuckets = []string{deliveriesBucket, pullRequestCIBucket, mirroredMessagesBucket, commitStatesBucket}
+
+func NewBoltDB(lc fx.Lifecycle, conf *config.Config) *storage.BoltDB {
+	db := storage.NewBoltDB(conf)
+	lc.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			return db.Close()
+		},
+	})
+	return db
+}
+
+// newStore 는 storage driver 에 맞는 저장소를 만듭니다. 저장소를 추가할 때는 driver 별 생성 함수만 넘깁니다.
+func newStore[T any](conf *config.Config, db *storage.BoltDB, memory func() T, bolt func(db *bbolt.DB) (T, error)) (T, error) {
+	var zero T
+	switch conf.Storage.Driver {
+	case storage.DriverBolt:
+		opened, err := db.Open()
+		if err != nil {
+			return zero, err
+		}
+		return bolt(opened)
+	case storage.DriverMemory:
+		return memory(), nil
+	default:
+		return zero, errors.Errorf("invalid storage driver: %s", conf.Storage.Driver)
+	}
+}
+
+// newCache 는 bucket 에 값을 저장하는 cache 입니다. bucket 은 cacheBuckets 에도 추가해서 만료된 값을 정리합니다.
+func newCache[T any](conf *config.Config, db *storage.BoltDB, bucket string) (cache.AtomicCache[T], error) {
+	return newStore(conf, db,
+		func() cache.AtomicCache[T] { return cache.NewLocalCache[T]() },
+		func(db *bbolt.DB) (cache.AtomicCache[T], error) { return cache.NewBoltCache[T](db, bucket) },
+	)
+}
+
+func NewThreadStore(conf *config.Config, db *storage.BoltDB) (thread.Store, error) {
+	return newStore(conf, db,
+		func() thread.Store { return thread.NewMemoryStore() },
+		func(db *bbolt.DB) (thread.Store, error) { return thread.NewBoltStore(db) },
+	)
+}
+
+func NewJobQueue(conf *config.Config, db *storage.BoltDB) (queue.Queue, error) {
+	return newStore(conf, db,
+		func() queue.Queue { return queue.NewMemoryQueue() },
+		func(db *bbolt.DB) (queue.Queue, error) { return queue.NewBoltQueue(db) },
+	)
+}
+
+func NewBatchStore(conf *config.Config, db *storage.BoltDB) (queue.BatchStore, error) {
+	return newStore(conf, db,
+		func() queue.BatchStore { return queue.NewMemoryBatchStore() },
+		func(db *bbolt.DB) (queue.BatchStore, error) { return queue.NewBoltBatchStore(db) },
+	)
+}
+
+func NewReminderStore(conf *config.Config, db *storage.BoltDB) (reminder.Store, error) {
+	return newStore(conf, db,
+		func() reminder.Store { return reminder.NewMemoryStore() },
+		func(db *bbolt.DB) (reminder.Store, error) { return reminder.NewBoltStore(db) },
+	)
+}
+
+func NewDeliveryCache(conf *config.Config, db *storage.BoltDB) (event.DeliveryCache, error) {
+	return newCache[time.Time](conf, db, deliveriesBucket)
+}
+
+func NewPullRequestCICache(conf *config.Config, db *storage.BoltDB) (svc.PullRequestCICache, error) {
+	return newCache[svc.PullRequestCI](conf, db, pullRequestCIBucket)
+}
+
+func NewCommitStateCache(conf *config.Config, db *storage.BoltDB) (svc.CommitStateCache, error) {
+	return newCache[svc.CommitState](conf, db, commitStatesBucket)
+}
+
+func NewMirroredMessageCache(conf *config.Config, db *storage.BoltDB) (svc.MirroredMessageCache, error) {
+	return newCache[time.Time](conf, db, mirroredMessagesBucket)
+}
+
+// SweepJobResult 는 scheduler 에 등록할 cache 정리 job 입니다.
+type SweepJobResult struct {
+	fx.Out
+
+	Jobs []scheduler.Job `group:"scheduler.jobs,flatten"`
+}
+
+// NewCacheSweepJob 은 bolt cache 에서 만료된 값을 주기적으로 삭제합니다.
+// NOTE : BoltCache 는 조회할 때만 만료된 값을 삭제하므로, 다시 조회되지 않는 delivery id 등이 파일에 계속 쌓입니다.
+func NewCacheSweepJob(conf *config.Config, db *storage.BoltDB, logger logger.Logger) SweepJobResult {
+	if conf.Storage.Driver != storage.DriverBolt {
+		return SweepJobResult{}
+	}
+	return SweepJobResult{Jobs: []scheduler.Job{{
+		Name:     "cache-sweep",
+		Schedule: scheduler.Every(cacheSweepInterval),
+		Run: func(_ context.Context) error {
+			bolt, err := db.Open()
+			if err != nil {
+				return err
+			}
+			for _, bucket := range cacheBuckets {
+				swept, err := cache.SweepBolt(bolt, bucket, time.Now())
+				if err != nil {
+					return err
+				}
+				if swept > 0 {
+					logger.Debugw("swept expired cache entries", "bucket", bucket, "count", swept)
+				}
+			}
+			return nil
+		},
+	}}}
+}
+
+func Module() fx.Option {
+	return fx.Module(
+		"storage",
+
+		fx.Provide(
+			NewBoltDB,
+			NewThreadStore,
+			NewJobQueue,
+			NewBatchStore,
+			NewDeliveryCache,
+			NewPullRequestCICache,
+			NewCommitStateCache,
+			NewMirroredMessageCache,
+			NewReminderStore,
+			NewCacheSweepJob,
+		),
+	)
+}
@@ -0,0 +1,13 @@
+package template
+
+import (
+	"github.com/channel-io/cht-app-github/internal/i18n"
+)
+
+// catalog 은 locale 별 기본 template 입니다. channeltalk.yml 의 templates 로 덮어쓸 수 있습니다.
+// English 는 모든 Name 을 가지고 있어야 하며 locale 에 없는 template 은 English 로 작성합니다.
+var catalog = map[i18n.Locale]map[Name]string{
+	i18n.English:  english,
+	i18n.Korean:   korean,
+	i18n.Japanese: japanese,
+}
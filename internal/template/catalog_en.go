@@ -0,0 +1,69 @@
+package template
+
+var english = map[Name]string{
+	IssueCommentCreated:      `:speech_balloon: {{ .Mentions }} {{ link .Comment.URL "issue" }} commented by {{ .Sender }}`,
+	IssueCommentPullRequest:  `:thinking_face::speech_balloon: {{ .Mentions }} {{ link .Comment.URL "pull request" }} commented by {{ .Sender }}`,
+	IssueOpened:              `:rotating_light: {{ link .Repository.URL .Repository.Name }} New issue opened! {{ link .Issue.URL .Issue.Title }} by {{ .Sender }}`,
+	IssueAssigned:            `:pray: {{ link .Issue.URL "issue" }} assigned to {{ .Mentions }}`,
+	IssueClosed:              `:x: {{ link .Issue.URL "issue" }} closed by {{ .Sender }}`,
+	PullRequestBody:          `{{ link .PullRequest.URL .PullRequest.Title }} ({{ .PullRequest.Head }} → {{ .PullRequest.Base }})`,
+	PullRequestOpened:        `:writing_hand: {{ link .Repository.URL .Repository.Name }} New pull request opened! by {{ .Sender }}`,
+	PullRequestSummary:       "{{ with .Stats }}:bar_chart: +{{ .Additions }} -{{ .Deletions }} in {{ .ChangedFiles }} file(s)\n{{ end }}{{ with .Labels }}:label: {{ escape (join . \", \") }}\n{{ end }}{{ with .Milestone }}:triangular_flag_on_post: {{ link .URL .Title }}\n{{ end }}{{ with .Reviewers }}:eyes: Reviewers: {{ . }}\n{{ end }}{{ with .Issues }}:link: Closes {{ range $i, $issue := . }}{{ if $i }}, {{ end }}{{ link $issue.URL $issue.Title }}{{ end }}\n{{ end }}",
+	PullRequestDraftOpened:   `:building_construction: {{ link .Repository.URL .Repository.Name }} Draft pull request created by {{ .Sender }}`,
+	PullRequestReady:         `:fire: {{ .Mentions }} {{ link .PullRequest.URL "pull request" }} ready for review!`,
+	PullRequestMerged:        `:white_check_mark: {{ link .Repository.URL .Repository.Name }} pull request merged! by {{ .Sender }}`,
+	PullRequestClosed:        `:boom: {{ link .Repository.URL .Repository.Name }} pull request closed... by {{ .Sender }}`,
+	PullRequestAssigned:      `:pray: {{ link .PullRequest.URL "pull request" }} assigned to {{ .Assignee }}`,
+	PullRequestSynchronized:  `:arrows_counterclockwise: {{ link .PullRequest.URL "pull request" }} has been updated by {{ .Sender }}`,
+	ReviewRequested:          `:pray: {{ .Reviewer }} {{ link .PullRequest.URL "pull request" }} review requested by {{ .Sender }}`,
+	ReviewRequestRemoved:     `:x: {{ .Reviewer }} {{ link .PullRequest.URL "pull request" }} review request removed by {{ .Sender }}`,
+	ReviewReminder:           `:bell: {{ .Reviewers }} {{ link .PullRequest.URL "pull request" }} has been waiting for your review for {{ .Waiting }}`,
+	ReviewEscalated:          `:rotating_light: {{ if .Leads }}{{ .Leads }} {{ end }}{{ link .PullRequest.URL "pull request" }} has been waiting for review by {{ .Reviewers }} for {{ .Waiting }}`,
+	PullRequestReviewApprove: `:100: {{ .Mentions }} {{ link .Review.URL "pull request" }} approved! by {{ .Sender }}`,
+	PullRequestReviewComment: `:thinking_face::speech_balloon: {{ .Mentions }} {{ link .Review.URL "pull request" }} commented by {{ .Sender }}`,
+	ReviewCodeComments:       `:mag: {{ .Mentions }} {{ link .Review.URL "pull request" }} {{ len .ReviewComments }} code comment(s) by {{ .Sender }}`,
+	ReviewCodeLocation:       `{{ link .URL .Path }} L{{ .StartLine }}{{ if ne .StartLine .Line }}-L{{ .Line }}{{ end }}{{ if .Outdated }} (outdated){{ end }}`,
+	CISummary:                `{{ resultEmoji .Result }} {{ if .Mentions }}{{ .Mentions }} {{ end }}CI for {{ link .URL (shortSHA .SHA) }}: {{ .Passed }} passed, {{ .Failed }} failed{{ if .Pending }}, {{ .Pending }} still running{{ end }}`,
+	CICheckResult:            `{{ if .URL }}{{ link .URL .Name }}{{ else }}{{ .Name }}{{ end }} {{ .Result }}`,
+	CIFixed:                  `:tada: {{ .Mentions }} CI for {{ link .URL (shortSHA .SHA) }} is fixed: {{ .Passed }} passed`,
+	WorkflowRunCompleted:     `{{ resultEmoji .Run.Conclusion }} {{ link .Run.URL (printf "%s #%d" .Run.Name .Run.Number) }} {{ .Run.Conclusion }}{{ if .Run.Duration }} in {{ .Run.Duration }}{{ end }} on {{ .Run.Branch }} ({{ shortSHA .Run.SHA }}) by {{ .Sender }}`,
+	WorkflowRunFailing:       `:warning: {{ len .FailedJobs }} job(s) failed in {{ link .Run.URL .Run.Name }} while it is still running`,
+	WorkflowJobFailed:        `{{ link .URL .Name }} {{ .Conclusion }}`,
+	DeploymentCreated:        `:rocket: Deploying {{ .Deployment.Ref }} ({{ shortSHA .Deployment.SHA }}) to {{ escape .Deployment.Environment }} by {{ .Sender }}`,
+	DeploymentStatus:         `{{ resultEmoji .Deployment.State }} {{ link .Deployment.URL (escape .Deployment.Environment) }} deployment of {{ .Deployment.Ref }} ({{ shortSHA .Deployment.SHA }}) {{ .Deployment.State }}{{ if .Deployment.Duration }} in {{ .Deployment.Duration }}{{ end }}`,
+	ReleaseReleased:          `:package: {{ link .Repository.URL .Repository.Name }}: {{ link .Release.URL .Release.TagName }} released by {{ .Sender }}`,
+	DiscussionCreated:        `:speaking_head_in_silhouette: {{ link .Repository.URL .Repository.Name }} New discussion in {{ escape .Discussion.Category }}! {{ link .Discussion.URL .Discussion.Title }} by {{ .Sender }}`,
+	DiscussionAnswered:       `:white_check_mark: {{ .Mentions }} {{ link .Discussion.AnswerURL "discussion" }} answered! marked by {{ .Sender }}`,
+	DiscussionClosed:         `:lock: {{ link .Discussion.URL "discussion" }} closed by {{ .Sender }}`,
+	DiscussionReopened:       `:unlock: {{ link .Discussion.URL "discussion" }} reopened by {{ .Sender }}`,
+	DiscussionComment:        `:speech_balloon: {{ .Mentions }} {{ link .Comment.URL "discussion" }} commented by {{ .Sender }}`,
+	TODORoot:                 ":four_leaf_clover: Hello {{ .Manager }}. Here's your TODO\r\n",
+	TODOReviewRequested:      `:eyes: {{ bold "Waiting for your review" }} ({{ .Count }})`,
+	TODOAssignedPullRequests: `:bust_in_silhouette: {{ bold "Pull requests assigned to you" }} ({{ .Count }})`,
+	TODOChangesRequested:     `:memo: {{ bold "Changes requested on your pull requests" }} ({{ .Count }})`,
+	TODOFailingChecks:        `:x: {{ bold "Your pull requests with failing checks" }} ({{ .Count }})`,
+	TODOApproved:             `:white_check_mark: {{ bold "Approved and ready to merge" }} ({{ .Count }})`,
+	TODOAssignedIssues:       `:pushpin: {{ bold "Issues assigned to you" }} ({{ .Count }})`,
+	TODOMentions:             `:speech_balloon: {{ bold "Open issues and pull requests mentioning you" }} ({{ .Count }})`,
+	TODOItem:                 `[{{ .Repository }}] {{ link .URL (escape .Title) }}{{ if .Draft }} (draft){{ end }} · {{ .Age }}`,
+	TODOEmpty:                `:tada: Nothing to do. Have a nice day!`,
+	TODOTeamQueueRoot:        ":busts_in_silhouette: Hello {{ .Manager }}. Here's your teams' review queue\r\n",
+	TODOTeamReviewRequested:  `:busts_in_silhouette: {{ bold "Waiting for review from" }} {{ .Team }} ({{ .Count }})`,
+	TODONoTeams:              `:information_source: You are not a member of any GitHub team.`,
+	CommandApproved:          `:100: {{ link .URL .Title }} approved by {{ .Manager }} (approved as the GitHub App, which does not count toward required reviews of branch protection)`,
+	CommandChangesRequested:  `:construction: {{ link .URL .Title }} changes requested by {{ .Manager }}`,
+	CommandCommented:         `:speech_balloon: {{ link .URL .Title }} commented by {{ .Manager }}`,
+	CommandAssigned:          `:pray: {{ link .URL .Title }} assigned to {{ .Assignees }} by {{ .Manager }}`,
+	CommandLabeled:           `:label: {{ .Labels }} added to {{ link .URL .Title }} by {{ .Manager }}`,
+	CommandUnlabeled:         `:label: {{ .Labels }} removed from {{ link .URL .Title }} by {{ .Manager }}`,
+	CommandClosed:            `:x: {{ link .URL .Title }} closed by {{ .Manager }}`,
+	CommandReopened:          `:unlock: {{ link .URL .Title }} reopened by {{ .Manager }}`,
+	CommandMerged:            `:white_check_mark: {{ link .URL .Title }} merged ({{ .Method }}) by {{ .Manager }}`,
+	CommandIssueCreated:      `:memo: {{ link .URL .Title }} created by {{ .Manager }}`,
+	CommandFailed:            `:warning: {{ .Command }} failed: {{ escape .Error }}`,
+	MessageTruncated:         `:scissors: The message is too long and was shortened.{{ if .URL }} See more on {{ link .URL "GitHub" }}{{ end }}`,
+	DigestRoot:               `:newspaper: Review digest for {{ .Scope }}{{ if or .Waiting .Stale .Failing }}: {{ .Waiting }} waiting for review, {{ .Stale }} stale, {{ .Failing }} failing checks{{ else }}: nothing to review :tada:{{ end }}`,
+	DigestWaiting:            `:eyes: {{ bold "Waiting for review" }}`,
+	DigestStale:              `:hourglass: {{ bold (printf "Not updated for %s" .StaleAfter) }}`,
+	DigestFailing:            `:x: {{ bold "Failing checks" }}`,
+}
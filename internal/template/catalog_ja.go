@@ -0,0 +1,69 @@
+package template
+
+var japanese = map[Name]string{
+	IssueCommentCreated:      `:speech_balloon: {{ .Mentions }} {{ .Sender }}さんが{{ link .Comment.URL "イシュー" }}にコメントしました`,
+	IssueCommentPullRequest:  `:thinking_face::speech_balloon: {{ .Mentions }} {{ .Sender }}さんが{{ link .Comment.URL "プルリクエスト" }}にコメントしました`,
+	IssueOpened:              `:rotating_light: {{ link .Repository.URL .Repository.Name }} 新しいイシューが作成されました！ {{ link .Issue.URL .Issue.Title }} by {{ .Sender }}`,
+	IssueAssigned:            `:pray: {{ link .Issue.URL "イシュー" }}が{{ .Mentions }}さんにアサインされました`,
+	IssueClosed:              `:x: {{ .Sender }}さんが{{ link .Issue.URL "イシュー" }}をクローズしました`,
+	PullRequestBody:          `{{ link .PullRequest.URL .PullRequest.Title }} ({{ .PullRequest.Head }} → {{ .PullRequest.Base }})`,
+	PullRequestOpened:        `:writing_hand: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}さんが新しいプルリクエストを作成しました！`,
+	PullRequestSummary:       "{{ with .Stats }}:bar_chart: {{ .ChangedFiles }}ファイル +{{ .Additions }} -{{ .Deletions }}\n{{ end }}{{ with .Labels }}:label: {{ escape (join . \", \") }}\n{{ end }}{{ with .Milestone }}:triangular_flag_on_post: {{ link .URL .Title }}\n{{ end }}{{ with .Reviewers }}:eyes: レビュアー: {{ . }}\n{{ end }}{{ with .Issues }}:link: クローズするissue: {{ range $i, $issue := . }}{{ if $i }}, {{ end }}{{ link $issue.URL $issue.Title }}{{ end }}\n{{ end }}",
+	PullRequestDraftOpened:   `:building_construction: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}さんがドラフトのプルリクエストを作成しました`,
+	PullRequestReady:         `:fire: {{ .Mentions }} {{ link .PullRequest.URL "プルリクエスト" }}のレビュー準備ができました！`,
+	PullRequestMerged:        `:white_check_mark: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}さんがプルリクエストをマージしました！`,
+	PullRequestClosed:        `:boom: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}さんがプルリクエストをクローズしました...`,
+	PullRequestAssigned:      `:pray: {{ link .PullRequest.URL "プルリクエスト" }}が{{ .Assignee }}さんにアサインされました`,
+	PullRequestSynchronized:  `:arrows_counterclockwise: {{ .Sender }}さんが{{ link .PullRequest.URL "プルリクエスト" }}を更新しました`,
+	ReviewRequested:          `:pray: {{ .Reviewer }} {{ .Sender }}さんが{{ link .PullRequest.URL "プルリクエスト" }}のレビューを依頼しました`,
+	ReviewRequestRemoved:     `:x: {{ .Reviewer }} {{ .Sender }}さんが{{ link .PullRequest.URL "プルリクエスト" }}のレビュー依頼を取り消しました`,
+	ReviewReminder:           `:bell: {{ .Reviewers }} {{ link .PullRequest.URL "プルリクエスト" }}が{{ .Waiting }}レビューを待っています`,
+	ReviewEscalated:          `:rotating_light: {{ if .Leads }}{{ .Leads }} {{ end }}{{ link .PullRequest.URL "プルリクエスト" }}が{{ .Reviewers }}さんのレビューを{{ .Waiting }}待っています`,
+	PullRequestReviewApprove: `:100: {{ .Mentions }} {{ .Sender }}さんが{{ link .Review.URL "プルリクエスト" }}を承認しました！`,
+	PullRequestReviewComment: `:thinking_face::speech_balloon: {{ .Mentions }} {{ .Sender }}さんが{{ link .Review.URL "プルリクエスト" }}にレビューしました`,
+	ReviewCodeComments:       `:mag: {{ .Mentions }} {{ .Sender }}さんが{{ link .Review.URL "プルリクエスト" }}にコードコメントを{{ len .ReviewComments }}件残しました`,
+	ReviewCodeLocation:       `{{ link .URL .Path }} {{ .StartLine }}{{ if ne .StartLine .Line }}-{{ .Line }}{{ end }}行目{{ if .Outdated }} (古いコード){{ end }}`,
+	CISummary:                `{{ resultEmoji .Result }} {{ if .Mentions }}{{ .Mentions }} {{ end }}{{ link .URL (shortSHA .SHA) }}のCI結果: 成功 {{ .Passed }}、失敗 {{ .Failed }}{{ if .Pending }}、実行中 {{ .Pending }}{{ end }}`,
+	CICheckResult:            `{{ if .URL }}{{ link .URL .Name }}{{ else }}{{ .Name }}{{ end }} {{ .Result }}`,
+	CIFixed:                  `:tada: {{ .Mentions }} {{ link .URL (shortSHA .SHA) }}のCIが復旧しました: 成功 {{ .Passed }}`,
+	WorkflowRunCompleted:     `{{ resultEmoji .Run.Conclusion }} {{ .Sender }}さんの{{ link .Run.URL (printf "%s #%d" .Run.Name .Run.Number) }}の結果: {{ .Run.Conclusion }} ({{ .Run.Branch }}, {{ shortSHA .Run.SHA }}{{ if .Run.Duration }}, {{ .Run.Duration }}{{ end }})`,
+	WorkflowRunFailing:       `:warning: {{ link .Run.URL .Run.Name }}の実行中に{{ len .FailedJobs }}件のjobが失敗しました`,
+	WorkflowJobFailed:        `{{ link .URL .Name }} {{ .Conclusion }}`,
+	DeploymentCreated:        `:rocket: {{ .Sender }}さんが{{ .Deployment.Ref }} ({{ shortSHA .Deployment.SHA }})を{{ escape .Deployment.Environment }}にデプロイします`,
+	DeploymentStatus:         `{{ resultEmoji .Deployment.State }} {{ link .Deployment.URL (escape .Deployment.Environment) }}のデプロイ結果: {{ .Deployment.State }} ({{ .Deployment.Ref }}, {{ shortSHA .Deployment.SHA }}{{ if .Deployment.Duration }}, {{ .Deployment.Duration }}{{ end }})`,
+	ReleaseReleased:          `:package: {{ link .Repository.URL .Repository.Name }}: {{ .Sender }}さんが{{ link .Release.URL .Release.TagName }}をリリースしました`,
+	DiscussionCreated:        `:speaking_head_in_silhouette: {{ link .Repository.URL .Repository.Name }} {{ escape .Discussion.Category }}に新しいディスカッションが作成されました！ {{ link .Discussion.URL .Discussion.Title }} by {{ .Sender }}`,
+	DiscussionAnswered:       `:white_check_mark: {{ .Mentions }} {{ .Sender }}さんが{{ link .Discussion.AnswerURL "ディスカッション" }}の回答を選択しました！`,
+	DiscussionClosed:         `:lock: {{ .Sender }}さんが{{ link .Discussion.URL "ディスカッション" }}をクローズしました`,
+	DiscussionReopened:       `:unlock: {{ .Sender }}さんが{{ link .Discussion.URL "ディスカッション" }}を再オープンしました`,
+	DiscussionComment:        `:speech_balloon: {{ .Mentions }} {{ .Sender }}さんが{{ link .Comment.URL "ディスカッション" }}にコメントしました`,
+	TODORoot:                 ":four_leaf_clover: {{ .Manager }}さん、こんにちは。今日のTODOです\r\n",
+	TODOReviewRequested:      `:eyes: {{ bold "レビュー待ちのプルリクエスト" }} ({{ .Count }})`,
+	TODOAssignedPullRequests: `:bust_in_silhouette: {{ bold "担当のプルリクエスト" }} ({{ .Count }})`,
+	TODOChangesRequested:     `:memo: {{ bold "変更をリクエストされたプルリクエスト" }} ({{ .Count }})`,
+	TODOFailingChecks:        `:x: {{ bold "チェックが失敗しているプルリクエスト" }} ({{ .Count }})`,
+	TODOApproved:             `:white_check_mark: {{ bold "承認済みでマージ待ちのプルリクエスト" }} ({{ .Count }})`,
+	TODOAssignedIssues:       `:pushpin: {{ bold "担当のイシュー" }} ({{ .Count }})`,
+	TODOMentions:             `:speech_balloon: {{ bold "あなたがメンションされたイシューとプルリクエスト" }} ({{ .Count }})`,
+	TODOItem:                 `[{{ .Repository }}] {{ link .URL (escape .Title) }}{{ if .Draft }} (ドラフト){{ end }} · {{ .Age }}`,
+	TODOEmpty:                `:tada: TODOはありません。良い一日を!`,
+	TODOTeamQueueRoot:        ":busts_in_silhouette: {{ .Manager }}さん、こんにちは。チームのレビュー待ちです\r\n",
+	TODOTeamReviewRequested:  `:busts_in_silhouette: {{ .Team }} {{ bold "チームのレビュー待ちのプルリクエスト" }} ({{ .Count }})`,
+	TODONoTeams:              `:information_source: 所属しているGitHubチームがありません。`,
+	CommandApproved:          `:100: {{ .Manager }}さんが{{ link .URL .Title }}をapproveしました (GitHub Appによるapproveはbranch protectionの必須reviewに含まれません)`,
+	CommandChangesRequested:  `:construction: {{ .Manager }}さんが{{ link .URL .Title }}に変更をリクエストしました`,
+	CommandCommented:         `:speech_balloon: {{ .Manager }}さんが{{ link .URL .Title }}にコメントしました`,
+	CommandAssigned:          `:pray: {{ .Manager }}さんが{{ link .URL .Title }}を{{ .Assignees }}さんにアサインしました`,
+	CommandLabeled:           `:label: {{ .Manager }}さんが{{ link .URL .Title }}に{{ .Labels }}ラベルを追加しました`,
+	CommandUnlabeled:         `:label: {{ .Manager }}さんが{{ link .URL .Title }}から{{ .Labels }}ラベルを削除しました`,
+	CommandClosed:            `:x: {{ .Manager }}さんが{{ link .URL .Title }}をクローズしました`,
+	CommandReopened:          `:unlock: {{ .Manager }}さんが{{ link .URL .Title }}を再オープンしました`,
+	CommandMerged:            `:white_check_mark: {{ .Manager }}さんが{{ link .URL .Title }}をマージしました ({{ .Method }})`,
+	CommandIssueCreated:      `:memo: {{ .Manager }}さんが{{ link .URL .Title }}を作成しました`,
+	CommandFailed:            `:warning: {{ .Command }}の実行に失敗しました: {{ escape .Error }}`,
+	MessageTruncated:         `:scissors: メッセージが長すぎるため一部を省略しました。{{ if .URL }}全文は{{ link .URL "GitHub" }}で確認できます{{ end }}`,
+	DigestRoot:               `:newspaper: {{ .Scope }}のレビューまとめ{{ if or .Waiting .Stale .Failing }}: レビュー待ち{{ .Waiting }}件、長期未更新{{ .Stale }}件、チェック失敗{{ .Failing }}件{{ else }}: レビューするpull requestはありません :tada:{{ end }}`,
+	DigestWaiting:            `:eyes: {{ bold "レビュー待ち" }}`,
+	DigestStale:              `:hourglass: {{ bold (printf "%s以上更新なし" .StaleAfter) }}`,
+	DigestFailing:            `:x: {{ bold "チェック失敗" }}`,
+}
@@ -0,0 +1,69 @@
+package template
+
+var korean = map[Name]string{
+	IssueCommentCreated:      `:speech_balloon: {{ .Mentions }} {{ .Sender }}님이 {{ link .Comment.URL "이슈" }}에 댓글을 남겼습니다`,
+	IssueCommentPullRequest:  `:thinking_face::speech_balloon: {{ .Mentions }} {{ .Sender }}님이 {{ link .Comment.URL "풀 리퀘스트" }}에 댓글을 남겼습니다`,
+	IssueOpened:              `:rotating_light: {{ link .Repository.URL .Repository.Name }} 새 이슈가 열렸습니다! {{ link .Issue.URL .Issue.Title }} by {{ .Sender }}`,
+	IssueAssigned:            `:pray: {{ link .Issue.URL "이슈" }}가 {{ .Mentions }}님에게 할당되었습니다`,
+	IssueClosed:              `:x: {{ .Sender }}님이 {{ link .Issue.URL "이슈" }}를 닫았습니다`,
+	PullRequestBody:          `{{ link .PullRequest.URL .PullRequest.Title }} ({{ .PullRequest.Head }} → {{ .PullRequest.Base }})`,
+	PullRequestOpened:        `:writing_hand: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}님이 새 풀 리퀘스트를 열었습니다!`,
+	PullRequestSummary:       "{{ with .Stats }}:bar_chart: {{ .ChangedFiles }}개 파일 +{{ .Additions }} -{{ .Deletions }}\n{{ end }}{{ with .Labels }}:label: {{ escape (join . \", \") }}\n{{ end }}{{ with .Milestone }}:triangular_flag_on_post: {{ link .URL .Title }}\n{{ end }}{{ with .Reviewers }}:eyes: 리뷰어: {{ . }}\n{{ end }}{{ with .Issues }}:link: 닫는 이슈: {{ range $i, $issue := . }}{{ if $i }}, {{ end }}{{ link $issue.URL $issue.Title }}{{ end }}\n{{ end }}",
+	PullRequestDraftOpened:   `:building_construction: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}님이 draft 풀 리퀘스트를 만들었습니다`,
+	PullRequestReady:         `:fire: {{ .Mentions }} {{ link .PullRequest.URL "풀 리퀘스트" }} 리뷰 준비가 되었습니다!`,
+	PullRequestMerged:        `:white_check_mark: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}님이 풀 리퀘스트를 머지했습니다!`,
+	PullRequestClosed:        `:boom: {{ link .Repository.URL .Repository.Name }} {{ .Sender }}님이 풀 리퀘스트를 닫았습니다...`,
+	PullRequestAssigned:      `:pray: {{ link .PullRequest.URL "풀 리퀘스트" }}가 {{ .Assignee }}님에게 할당되었습니다`,
+	PullRequestSynchronized:  `:arrows_counterclockwise: {{ .Sender }}님이 {{ link .PullRequest.URL "풀 리퀘스트" }}를 업데이트했습니다`,
+	ReviewRequested:          `:pray: {{ .Reviewer }} {{ .Sender }}님이 {{ link .PullRequest.URL "풀 리퀘스트" }} 리뷰를 요청했습니다`,
+	ReviewRequestRemoved:     `:x: {{ .Reviewer }} {{ .Sender }}님이 {{ link .PullRequest.URL "풀 리퀘스트" }} 리뷰 요청을 취소했습니다`,
+	ReviewReminder:           `:bell: {{ .Reviewers }} {{ link .PullRequest.URL "풀 리퀘스트" }}가 {{ .Waiting }} 동안 리뷰를 기다리고 있습니다`,
+	ReviewEscalated:          `:rotating_light: {{ if .Leads }}{{ .Leads }} {{ end }}{{ link .PullRequest.URL "풀 리퀘스트" }}가 {{ .Reviewers }}님의 리뷰를 {{ .Waiting }} 동안 기다리고 있습니다`,
+	PullRequestReviewApprove: `:100: {{ .Mentions }} {{ .Sender }}님이 {{ link .Review.URL "풀 리퀘스트" }}를 승인했습니다!`,
+	PullRequestReviewComment: `:thinking_face::speech_balloon: {{ .Mentions }} {{ .Sender }}님이 {{ link .Review.URL "풀 리퀘스트" }}에 리뷰를 남겼습니다`,
+	ReviewCodeComments:       `:mag: {{ .Mentions }} {{ .Sender }}님이 {{ link .Review.URL "풀 리퀘스트" }}에 코드 코멘트 {{ len .ReviewComments }}개를 남겼습니다`,
+	ReviewCodeLocation:       `{{ link .URL .Path }} {{ .StartLine }}{{ if ne .StartLine .Line }}-{{ .Line }}{{ end }}번째 줄{{ if .Outdated }} (이전 코드){{ end }}`,
+	CISummary:                `{{ resultEmoji .Result }} {{ if .Mentions }}{{ .Mentions }} {{ end }}{{ link .URL (shortSHA .SHA) }}의 CI 결과: 성공 {{ .Passed }}, 실패 {{ .Failed }}{{ if .Pending }}, 진행중 {{ .Pending }}{{ end }}`,
+	CICheckResult:            `{{ if .URL }}{{ link .URL .Name }}{{ else }}{{ .Name }}{{ end }} {{ .Result }}`,
+	CIFixed:                  `:tada: {{ .Mentions }} {{ link .URL (shortSHA .SHA) }}의 CI 가 다시 성공했습니다: 성공 {{ .Passed }}`,
+	WorkflowRunCompleted:     `{{ resultEmoji .Run.Conclusion }} {{ .Sender }}님의 {{ link .Run.URL (printf "%s #%d" .Run.Name .Run.Number) }} 결과: {{ .Run.Conclusion }} ({{ .Run.Branch }}, {{ shortSHA .Run.SHA }}{{ if .Run.Duration }}, {{ .Run.Duration }}{{ end }})`,
+	WorkflowRunFailing:       `:warning: {{ link .Run.URL .Run.Name }} 실행 중 {{ len .FailedJobs }}개의 job 이 실패했습니다`,
+	WorkflowJobFailed:        `{{ link .URL .Name }} {{ .Conclusion }}`,
+	DeploymentCreated:        `:rocket: {{ .Sender }}님이 {{ .Deployment.Ref }} ({{ shortSHA .Deployment.SHA }})를 {{ escape .Deployment.Environment }}에 배포합니다`,
+	DeploymentStatus:         `{{ resultEmoji .Deployment.State }} {{ link .Deployment.URL (escape .Deployment.Environment) }} 배포 결과: {{ .Deployment.State }} ({{ .Deployment.Ref }}, {{ shortSHA .Deployment.SHA }}{{ if .Deployment.Duration }}, {{ .Deployment.Duration }}{{ end }})`,
+	ReleaseReleased:          `:package: {{ link .Repository.URL .Repository.Name }}: {{ .Sender }}님이 {{ link .Release.URL .Release.TagName }}을 릴리즈했습니다`,
+	DiscussionCreated:        `:speaking_head_in_silhouette: {{ link .Repository.URL .Repository.Name }} {{ escape .Discussion.Category }}에 새 디스커션이 열렸습니다! {{ link .Discussion.URL .Discussion.Title }} by {{ .Sender }}`,
+	DiscussionAnswered:       `:white_check_mark: {{ .Mentions }} {{ .Sender }}님이 {{ link .Discussion.AnswerURL "디스커션" }}의 답변을 선택했습니다!`,
+	DiscussionClosed:         `:lock: {{ .Sender }}님이 {{ link .Discussion.URL "디스커션" }}을 닫았습니다`,
+	DiscussionReopened:       `:unlock: {{ .Sender }}님이 {{ link .Discussion.URL "디스커션" }}을 다시 열었습니다`,
+	DiscussionComment:        `:speech_balloon: {{ .Mentions }} {{ .Sender }}님이 {{ link .Comment.URL "디스커션" }}에 댓글을 남겼습니다`,
+	TODORoot:                 ":four_leaf_clover: 안녕하세요 {{ .Manager }}님. 오늘의 TODO 입니다\r\n",
+	TODOReviewRequested:      `:eyes: {{ bold "리뷰를 기다리는 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOAssignedPullRequests: `:bust_in_silhouette: {{ bold "나에게 할당된 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOChangesRequested:     `:memo: {{ bold "변경 요청을 받은 내 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOFailingChecks:        `:x: {{ bold "체크가 실패한 내 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOApproved:             `:white_check_mark: {{ bold "승인되어 머지를 기다리는 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOAssignedIssues:       `:pushpin: {{ bold "나에게 할당된 이슈" }} ({{ .Count }})`,
+	TODOMentions:             `:speech_balloon: {{ bold "나를 멘션한 열린 이슈와 풀 리퀘스트" }} ({{ .Count }})`,
+	TODOItem:                 `[{{ .Repository }}] {{ link .URL (escape .Title) }}{{ if .Draft }} (초안){{ end }} · {{ .Age }}`,
+	TODOEmpty:                `:tada: 할 일이 없습니다. 좋은 하루 보내세요!`,
+	TODOTeamQueueRoot:        ":busts_in_silhouette: 안녕하세요 {{ .Manager }}님. 팀의 리뷰 대기열입니다\r\n",
+	TODOTeamReviewRequested:  `:busts_in_silhouette: {{ .Team }} {{ bold "팀의 리뷰를 기다리는 풀 리퀘스트" }} ({{ .Count }})`,
+	TODONoTeams:              `:information_source: 속한 GitHub 팀이 없습니다.`,
+	CommandApproved:          `:100: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) approve 했습니다 (GitHub App 의 approve 는 branch protection 의 필수 review 로 집계되지 않습니다)`,
+	CommandChangesRequested:  `:construction: {{ .Manager }}님이 {{ link .URL .Title }} 에 변경을 요청했습니다`,
+	CommandCommented:         `:speech_balloon: {{ .Manager }}님이 {{ link .URL .Title }} 에 코멘트를 남겼습니다`,
+	CommandAssigned:          `:pray: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) {{ .Assignees }}에게 할당했습니다`,
+	CommandLabeled:           `:label: {{ .Manager }}님이 {{ link .URL .Title }} 에 {{ .Labels }} label 을 추가했습니다`,
+	CommandUnlabeled:         `:label: {{ .Manager }}님이 {{ link .URL .Title }} 에서 {{ .Labels }} label 을 제거했습니다`,
+	CommandClosed:            `:x: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) 닫았습니다`,
+	CommandReopened:          `:unlock: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) 다시 열었습니다`,
+	CommandMerged:            `:white_check_mark: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) merge 했습니다 ({{ .Method }})`,
+	CommandIssueCreated:      `:memo: {{ .Manager }}님이 {{ link .URL .Title }} 을(를) 만들었습니다`,
+	CommandFailed:            `:warning: {{ .Command }} 실행에 실패했습니다: {{ escape .Error }}`,
+	MessageTruncated:         `:scissors: 메시지가 너무 길어 일부를 생략했습니다.{{ if .URL }} 전체 내용은 {{ link .URL "GitHub" }}에서 확인하세요{{ end }}`,
+	DigestRoot:               `:newspaper: {{ .Scope }} 리뷰 요약{{ if or .Waiting .Stale .Failing }}: 리뷰 대기 {{ .Waiting }}개, 오래된 PR {{ .Stale }}개, 체크 실패 {{ .Failing }}개{{ else }}: 리뷰할 pull request 가 없습니다 :tada:{{ end }}`,
+	DigestWaiting:            `:eyes: {{ bold "리뷰 대기" }}`,
+	DigestStale:              `:hourglass: {{ bold (printf "%s 동안 업데이트 없음" .StaleAfter) }}`,
+	DigestFailing:            `:x: {{ bold "체크 실패" }}`,
+}
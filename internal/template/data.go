@@ -0,0 +1,253 @@
+package template
+
+import (
+	"fmt"
+	"time"
+)
+
+// 각 template 에 전달되는 값입니다.
+// Sender, Mentions 등 manager 를 나타내는 값은 이미 mention 혹은 manager 이름으로 변환된 문자열입니다.
+
+type Repository struct {
+	Name string
+	URL  string
+}
+
+type Issue struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+type PullRequest struct {
+	Number int
+	Title  string
+	URL    string
+	Head   string
+	Base   string
+	Draft  bool
+}
+
+type Comment struct {
+	URL  string
+	Body string
+}
+
+type Review struct {
+	URL   string
+	State string
+	Body  string
+}
+
+// ReviewComment 는 pull request 의 code line 에 작성된 comment 입니다.
+// StartLine 과 Line 이 같으면 한 줄에 작성된 comment 입니다.
+type ReviewComment struct {
+	URL       string
+	Path      string
+	StartLine int
+	Line      int
+	Outdated  bool
+	Body      string
+}
+
+type Release struct {
+	TagName string
+	URL     string
+}
+
+// WorkflowRun 은 github actions 의 workflow 실행입니다. Duration 은 "1m30s" 형식입니다.
+type WorkflowRun struct {
+	Name       string
+	Number     int
+	URL        string
+	Event      string
+	Branch     string
+	SHA        string
+	Conclusion string
+	Duration   string
+}
+
+type WorkflowJob struct {
+	Name       string
+	URL        string
+	Conclusion string
+}
+
+// Deployment 의 State 는 deployment 가 생성된 경우 비어있고, 이후 deployment status 의 state 입니다.
+type Deployment struct {
+	Environment string
+	Ref         string
+	SHA         string
+	URL         string
+	State       string
+	Description string
+	Duration    string
+}
+
+type Discussion struct {
+	Number    int
+	Title     string
+	URL       string
+	Category  string
+	AnswerURL string
+}
+
+type IssueData struct {
+	Repository Repository
+	Issue      Issue
+	Comment    Comment
+	Sender     string
+	Mentions   string
+}
+
+type PullRequestData struct {
+	Repository  Repository
+	PullRequest PullRequest
+	Comment     Comment
+	Review      Review
+	// ReviewComments 는 한 번의 review 에서 작성된 code comment 목록입니다.
+	ReviewComments []ReviewComment
+	Sender         string
+	Mentions       string
+	Reviewer       string
+	Assignee       string
+}
+
+// ReviewReminderData 는 review 를 요청한 뒤 review 가 없는 pull request 를 다시 알리는 message 입니다. Waiting 은 "3d" 형식입니다.
+type ReviewReminderData struct {
+	Repository  Repository
+	PullRequest PullRequest
+	Reviewers   string
+	Waiting     string
+	// Leads 는 escalation 에서 멘션하는 사용자입니다. 비어있으면 group 에 broadcast 합니다.
+	Leads string
+}
+
+// PullRequestSummaryData 는 pull request 를 열었을 때 작성하는 요약입니다. 작성하지 않도록 설정한 항목은 비어있습니다.
+type PullRequestSummaryData struct {
+	Repository  Repository
+	PullRequest PullRequest
+	Stats       *PullRequestStats
+	Labels      []string
+	Milestone   *Milestone
+	// Reviewers 는 review 를 요청한 manager 와 team 을 " " 로 연결한 값입니다.
+	Reviewers string
+	// Issues 는 pull request 가 merge 되면 닫히는 issue 입니다. Title 은 #123 혹은 org/repo#123 입니다.
+	Issues []Issue
+}
+
+type PullRequestStats struct {
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+}
+
+type Milestone struct {
+	Title string
+	URL   string
+}
+
+// CICheck 는 commit 에 대한 check run 혹은 commit status 입니다.
+type CICheck struct {
+	Name   string
+	URL    string
+	Result string
+}
+
+// CISummaryData 는 commit 의 모든 check 결과를 요약한 값입니다. URL 은 commit 의 url 입니다.
+type CISummaryData struct {
+	Repository   Repository
+	SHA          string
+	URL          string
+	Result       string
+	Passed       int
+	Failed       int
+	Pending      int
+	FailedChecks []CICheck
+	// Mentions 는 열려있는 pull request 에 보낼 때 멘션할 작성자입니다. merge 된 pull request 에 보낼 때는 비어있습니다.
+	Mentions string
+}
+
+type ReleaseData struct {
+	Repository Repository
+	Release    Release
+	Sender     string
+}
+
+type WorkflowData struct {
+	Repository Repository
+	Run        WorkflowRun
+	// FailedJobs 는 실패한 job 목록입니다.
+	FailedJobs []WorkflowJob
+	Sender     string
+}
+
+type DeploymentData struct {
+	Repository Repository
+	Deployment Deployment
+	Sender     string
+}
+
+type DiscussionData struct {
+	Repository Repository
+	Discussion Discussion
+	Comment    Comment
+	Sender     string
+	Mentions   string
+}
+
+// TODOData 의 Count 는 TODO 목록 제목에 작성하는 항목 수입니다. Team 은 review 를 요청받은 github team 의 멘션입니다.
+type TODOData struct {
+	Manager string
+	Team    string
+	Count   int
+}
+
+// TODOItemData 는 TODO 목록의 issue(pull request)입니다. Repository 는 "org/repo", Age 는 "3d" 형식입니다.
+type TODOItemData struct {
+	Repository string
+	Title      string
+	URL        string
+	Draft      bool
+	Age        string
+}
+
+// CommandData 는 팀챗에서 github issue(pull request)에 실행한 command 의 결과입니다.
+// Assignees, Labels 는 ", " 로 연결한 값입니다.
+type CommandData struct {
+	Command   string
+	Manager   string
+	Title     string
+	URL       string
+	Assignees string
+	Labels    string
+	Method    string
+	Error     string
+}
+
+// MessageData 는 channel 에 보내는 message 의 정보입니다. URL 은 github 에서 전체 내용을 볼 수 있는 url 이고, 없으면 비어있습니다.
+type MessageData struct {
+	URL string
+}
+
+// DigestData 는 group 에 보내는 pull request 요약입니다. Scope 는 요약한 organization 혹은 repository 이름입니다.
+// Waiting, Stale, Failing 은 각각 해당하는 pull request 의 수이고, StaleAfter 는 "3d" 형식입니다.
+type DigestData struct {
+	Scope      string
+	Waiting    int
+	Stale      int
+	Failing    int
+	StaleAfter string
+}
+
+// FormatAge 는 경과 시간을 "45m", "5h", "3d" 형식으로 작성합니다. 이틀 미만은 시간 단위로 작성합니다.
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,15 @@
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "45m", FormatAge(45*time.Minute))
+	assert.Equal(t, "5h", FormatAge(5*time.Hour))
+	assert.Equal(t, "47h", FormatAge(47*time.Hour))
+	assert.Equal(t, "3d", FormatAge(72*time.Hour))
+}
@@ -0,0 +1,140 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/channel-io/cht-app-github/internal/config"
+	"github.com/channel-io/cht-app-github/internal/github"
+	"github.com/channel-io/cht-app-github/internal/i18n"
+	"github.com/channel-io/cht-app-github/internal/logger"
+	"github.com/channel-io/cht-app-github/internal/repoconfig"
+	"github.com/channel-io/cht-app-github/internal/routing"
+	"github.com/channel-io/cht-app-github/pkg/cache"
+)
+
+const channelIDTTL = 10 * time.Minute
+
+type Engine struct {
+	router         *routing.Router
+	repoConfigSvc  *repoconfig.Service
+	logger         logger.Logger
+	defaultLocale  string
+	channelLocales map[string]string
+	defaults       map[i18n.Locale]map[Name]*texttemplate.Template
+	// overrides 는 channeltalk.yml 의 template 원문을 key 로 parse 결과를 보관합니다.
+	overrides sync.Map
+	// channelIDs 는 repository 가 연결된 channel 입니다. 찾지 못한 경우 빈 문자열을 보관합니다.
+	channelIDs cache.LocalCache[string]
+}
+
+func NewEngine(conf *config.Config, router *routing.Router, repoConfigSvc *repoconfig.Service, logger logger.Logger) *Engine {
+	return &Engine{
+		router:         router,
+		repoConfigSvc:  repoConfigSvc,
+		logger:         logger,
+		defaultLocale:  conf.I18n.DefaultLocale,
+		channelLocales: conf.I18n.Channels,
+		defaults:       parseCatalog(),
+		channelIDs:     cache.NewLocalCache[string](),
+	}
+}
+
+func parseCatalog() map[i18n.Locale]map[Name]*texttemplate.Template {
+	parsed := make(map[i18n.Locale]map[Name]*texttemplate.Template, len(catalog))
+	for locale, templates := range catalog {
+		parsed[locale] = make(map[Name]*texttemplate.Template, len(templates))
+		for name, text := range templates {
+			parsed[locale][name] = texttemplate.Must(newTemplate(name).Parse(text))
+		}
+	}
+	return parsed
+}
+
+// Render 는 repository 에 적용된 template 으로 data 를 작성합니다.
+// organization 혹은 repository 의 template 이 잘못된 경우 기본 template 을 사용합니다.
+//
+// 기본 template 의 locale 은 다음 순서로 찾습니다.
+// channeltalk.yml 의 locale, repository 가 연결된 channel 의 locale, 기본 locale, English
+func (e *Engine) Render(ctx context.Context, installCtx github.InstallationContext, repository string, name Name, data any) (string, error) {
+	conf, err := e.repoConfigSvc.Find(ctx, installCtx, repository)
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := conf.Templates[string(name)]; ok {
+		rendered, err := e.renderOverride(name, text, data)
+		if err == nil {
+			return rendered, nil
+		}
+		e.logger.Warnw("invalid template, fallback to default",
+			"org", installCtx.OrgLogin, "repository", repository, "template", name, "error", err)
+	}
+
+	return e.RenderLocale(i18n.Chain(conf.Locale, e.channelLocales[e.findChannelID(ctx, installCtx, repository)], e.defaultLocale), name, data)
+}
+
+// findChannelID 는 channel 의 locale 을 찾기 위해 repository 가 연결된 channel 을 찾습니다.
+// NOTE : channel 의 locale 이 없어도 기본 locale 로 작성할 수 있으므로, channel 을 찾지 못한 경우에도 message 작성을 실패시키지 않습니다.
+func (e *Engine) findChannelID(ctx context.Context, installCtx github.InstallationContext, repository string) string {
+	if len(e.channelLocales) == 0 {
+		return ""
+	}
+
+	key := fmt.Sprintf("%s:%s", installCtx.OrgLogin, repository)
+	if cached, _ := e.channelIDs.Get(ctx, key); cached != nil {
+		return *cached
+	}
+	channelID, err := e.router.FindChannelID(ctx, installCtx, repository, "")
+	if err != nil {
+		e.logger.Warnw("failed to find channel of repository, fallback to default locale",
+			"org", installCtx.OrgLogin, "repository", repository, "error", err)
+	}
+	_ = e.channelIDs.Set(ctx, key, channelID, channelIDTTL)
+	return channelID
+}
+
+// RenderForChannel 은 repository 와 관계없는 message 를 channel 의 locale 로 작성합니다.
+func (e *Engine) RenderForChannel(channelID string, name Name, data any) (string, error) {
+	return e.RenderLocale(i18n.Chain(e.channelLocales[channelID], e.defaultLocale), name, data)
+}
+
+// RenderLocale 은 locales 중 처음으로 template 이 있는 locale 의 기본 template 으로 data 를 작성합니다.
+func (e *Engine) RenderLocale(locales []i18n.Locale, name Name, data any) (string, error) {
+	for _, locale := range locales {
+		if tmpl, ok := e.defaults[locale][name]; ok {
+			return execute(tmpl, data)
+		}
+	}
+	return "", errors.Errorf("template %s not found", name)
+}
+
+func (e *Engine) renderOverride(name Name, text string, data any) (string, error) {
+	cached, ok := e.overrides.Load(text)
+	if !ok {
+		tmpl, err := newTemplate(name).Parse(text)
+		if err != nil {
+			return "", err
+		}
+		cached, _ = e.overrides.LoadOrStore(text, tmpl)
+	}
+	return execute(cached.(*texttemplate.Template), data)
+}
+
+func newTemplate(name Name) *texttemplate.Template {
+	return texttemplate.New(string(name)).Funcs(funcs).Option("missingkey=error")
+}
+
+func execute(tmpl *texttemplate.Template, data any) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
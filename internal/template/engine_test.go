@@ -0,0 +1,461 @@
+package template
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+	"github.com/channel-io/cht-app-github/internal/i18n"
+)
+
+// English template 은 fmt format 으로 작성하던 이전 message 와 같아야 합니다.
+func TestEngine_RenderDefault(t *testing.T) {
+	repo := Repository{Name: "cht-app-github", URL: "https://github.com/channel-io/cht-app-github"}
+	pr := PullRequest{
+		Number: 1,
+		Title:  "Add <feature> & fix",
+		URL:    "https://github.com/channel-io/cht-app-github/pull/1",
+		Head:   "channel-io:feature",
+		Base:   "channel-io:main",
+	}
+	issue := Issue{Number: 2, Title: "Bug", URL: "https://github.com/channel-io/cht-app-github/issues/2"}
+	comment := Comment{URL: "https://github.com/channel-io/cht-app-github/issues/2#issuecomment-1"}
+	review := Review{URL: "https://github.com/channel-io/cht-app-github/pull/1#pullrequestreview-1", State: "approved"}
+	discussion := Discussion{
+		Number:    3,
+		Title:     "How to configure routes?",
+		URL:       "https://github.com/channel-io/cht-app-github/discussions/3",
+		Category:  "Q&A",
+		AnswerURL: "https://github.com/channel-io/cht-app-github/discussions/3#discussioncomment-1",
+	}
+	run := WorkflowRun{
+		Name:       "CI",
+		Number:     42,
+		URL:        "https://github.com/channel-io/cht-app-github/actions/runs/1",
+		Branch:     "main",
+		SHA:        "0123456789abcdef",
+		Conclusion: "failure",
+		Duration:   "3m5s",
+	}
+	deployment := Deployment{
+		Environment: "production",
+		Ref:         "main",
+		SHA:         "0123456789abcdef",
+		URL:         "https://github.com/channel-io/cht-app-github/deployments/production",
+		State:       "success",
+		Duration:    "1m0s",
+	}
+	mention := model.Mention(model.MentionTypeManager, "1", "Dylan")
+	repoLink := model.InlineLink(repo.URL, repo.Name)
+	prLink := model.InlineLink(pr.URL, pr.Title)
+	command := CommandData{Manager: "Lento", Title: pr.Title, URL: pr.URL}
+
+	tests := []struct {
+		name     Name
+		data     any
+		expected string
+	}{
+		{
+			name:     IssueCommentCreated,
+			data:     IssueData{Comment: comment, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":speech_balloon: %s %s commented by %s", mention, model.InlineLink(comment.URL, "issue"), "Lento"),
+		},
+		{
+			name:     IssueCommentPullRequest,
+			data:     IssueData{Comment: comment, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":thinking_face::speech_balloon: %s %s commented by %s", mention, model.InlineLink(comment.URL, "pull request"), "Lento"),
+		},
+		{
+			name:     IssueOpened,
+			data:     IssueData{Repository: repo, Issue: issue, Sender: mention},
+			expected: fmt.Sprintf(":rotating_light: %s New issue opened! %s by %s", repoLink, model.InlineLink(issue.URL, issue.Title), mention),
+		},
+		{
+			name:     IssueAssigned,
+			data:     IssueData{Issue: issue, Mentions: mention},
+			expected: fmt.Sprintf(":pray: %s assigned to %s", model.InlineLink(issue.URL, "issue"), mention),
+		},
+		{
+			name:     IssueClosed,
+			data:     IssueData{Issue: issue, Sender: "Dylan"},
+			expected: fmt.Sprintf(":x: %s closed by %s", model.InlineLink(issue.URL, "issue"), "Dylan"),
+		},
+		{
+			name:     PullRequestBody,
+			data:     PullRequestData{PullRequest: pr},
+			expected: fmt.Sprintf("%s (%s → %s)", model.InlineLink(pr.URL, pr.Title), pr.Head, pr.Base),
+		},
+		{
+			name:     PullRequestOpened,
+			data:     PullRequestData{Repository: repo, Sender: mention},
+			expected: fmt.Sprintf(":writing_hand: %s New pull request opened! by %s", repoLink, mention),
+		},
+		{
+			name: PullRequestSummary,
+			data: PullRequestSummaryData{
+				Stats:     &PullRequestStats{Additions: 10, Deletions: 2, ChangedFiles: 3},
+				Labels:    []string{"bug", "<ui>"},
+				Milestone: &Milestone{Title: "v1.0", URL: "https://github.com/channel-io/cht-app-github/milestone/1"},
+				Reviewers: mention,
+				Issues:    []Issue{issue, {Title: "channel-io/other#5", URL: "https://github.com/channel-io/other/issues/5"}},
+			},
+			expected: fmt.Sprintf(":bar_chart: +10 -2 in 3 file(s)\n:label: bug, &lt;ui&gt;\n:triangular_flag_on_post: %s\n:eyes: Reviewers: %s\n:link: Closes %s, %s\n",
+				model.InlineLink("https://github.com/channel-io/cht-app-github/milestone/1", "v1.0"),
+				mention,
+				model.InlineLink(issue.URL, issue.Title),
+				model.InlineLink("https://github.com/channel-io/other/issues/5", "channel-io/other#5")),
+		},
+		{
+			name:     PullRequestDraftOpened,
+			data:     PullRequestData{Repository: repo, Sender: mention},
+			expected: fmt.Sprintf(":building_construction: %s Draft pull request created by %s", repoLink, mention),
+		},
+		{
+			name:     PullRequestReady,
+			data:     PullRequestData{PullRequest: pr, Mentions: mention},
+			expected: fmt.Sprintf(":fire: %s %s ready for review!", mention, model.InlineLink(pr.URL, "pull request")),
+		},
+		{
+			name:     PullRequestMerged,
+			data:     PullRequestData{Repository: repo, Sender: mention},
+			expected: fmt.Sprintf(":white_check_mark: %s pull request merged! by %s", repoLink, mention),
+		},
+		{
+			name:     PullRequestClosed,
+			data:     PullRequestData{Repository: repo, Sender: mention},
+			expected: fmt.Sprintf(":boom: %s pull request closed... by %s", repoLink, mention),
+		},
+		{
+			name:     PullRequestAssigned,
+			data:     PullRequestData{PullRequest: pr, Assignee: "Dylan"},
+			expected: fmt.Sprintf(":pray: %s assigned to %s", model.InlineLink(pr.URL, "pull request"), "Dylan"),
+		},
+		{
+			name:     PullRequestSynchronized,
+			data:     PullRequestData{PullRequest: pr, Sender: "Dylan"},
+			expected: fmt.Sprintf(":arrows_counterclockwise: %s has been updated by %s", model.InlineLink(pr.URL, "pull request"), "Dylan"),
+		},
+		{
+			name:     ReviewRequested,
+			data:     PullRequestData{PullRequest: pr, Reviewer: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":pray: %s %s review requested by %s", mention, model.InlineLink(pr.URL, "pull request"), "Lento"),
+		},
+		{
+			name:     ReviewRequestRemoved,
+			data:     PullRequestData{PullRequest: pr, Reviewer: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":x: %s %s review request removed by %s", mention, model.InlineLink(pr.URL, "pull request"), "Lento"),
+		},
+		{
+			name:     ReviewReminder,
+			data:     ReviewReminderData{PullRequest: pr, Reviewers: mention, Waiting: "1d"},
+			expected: fmt.Sprintf(":bell: %s %s has been waiting for your review for 1d", mention, model.InlineLink(pr.URL, "pull request")),
+		},
+		{
+			name:     ReviewEscalated,
+			data:     ReviewReminderData{PullRequest: pr, Reviewers: "Lento", Waiting: "5d", Leads: mention},
+			expected: fmt.Sprintf(":rotating_light: %s %s has been waiting for review by Lento for 5d", mention, model.InlineLink(pr.URL, "pull request")),
+		},
+		{
+			name:     PullRequestReviewApprove,
+			data:     PullRequestData{Review: review, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":100: %s %s approved! by %s", mention, model.InlineLink(review.URL, "pull request"), "Lento"),
+		},
+		{
+			name:     PullRequestReviewComment,
+			data:     PullRequestData{Review: review, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":thinking_face::speech_balloon: %s %s commented by %s", mention, model.InlineLink(review.URL, "pull request"), "Lento"),
+		},
+		{
+			name:     ReviewCodeComments,
+			data:     PullRequestData{Review: review, ReviewComments: []ReviewComment{{}, {}}, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":mag: %s %s 2 code comment(s) by Lento", mention, model.InlineLink(review.URL, "pull request")),
+		},
+		{
+			name:     ReviewCodeLocation,
+			data:     ReviewComment{URL: "https://github.com/channel-io/cht-app-github/pull/1#discussion_r1", Path: "internal/a.go", StartLine: 10, Line: 12, Outdated: true},
+			expected: fmt.Sprintf("%s L10-L12 (outdated)", model.InlineLink("https://github.com/channel-io/cht-app-github/pull/1#discussion_r1", "internal/a.go")),
+		},
+		{
+			name:     CISummary,
+			data:     CISummaryData{SHA: "0123456789abcdef", URL: "https://github.com/channel-io/cht-app-github/commit/0123456789abcdef", Result: "failure", Passed: 18, Failed: 1, Pending: 1},
+			expected: fmt.Sprintf(":x: CI for %s: 18 passed, 1 failed, 1 still running", model.InlineLink("https://github.com/channel-io/cht-app-github/commit/0123456789abcdef", "0123456789")),
+		},
+		{
+			name:     CICheckResult,
+			data:     CICheck{Name: "ci/build", URL: "https://ci.example.com/1", Result: "failure"},
+			expected: fmt.Sprintf("%s failure", model.InlineLink("https://ci.example.com/1", "ci/build")),
+		},
+		{
+			name:     CIFixed,
+			data:     CISummaryData{SHA: "0123456789abcdef", URL: "https://github.com/channel-io/cht-app-github/commit/0123456789abcdef", Result: "success", Passed: 20, Mentions: mention},
+			expected: fmt.Sprintf(":tada: %s CI for %s is fixed: 20 passed", mention, model.InlineLink("https://github.com/channel-io/cht-app-github/commit/0123456789abcdef", "0123456789")),
+		},
+		{
+			name:     DiscussionCreated,
+			data:     DiscussionData{Repository: repo, Discussion: discussion, Sender: mention},
+			expected: fmt.Sprintf(":speaking_head_in_silhouette: %s New discussion in Q&amp;A! %s by %s", repoLink, model.InlineLink(discussion.URL, discussion.Title), mention),
+		},
+		{
+			name:     DiscussionAnswered,
+			data:     DiscussionData{Discussion: discussion, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":white_check_mark: %s %s answered! marked by Lento", mention, model.InlineLink(discussion.AnswerURL, "discussion")),
+		},
+		{
+			name:     DiscussionClosed,
+			data:     DiscussionData{Discussion: discussion, Sender: "Lento"},
+			expected: fmt.Sprintf(":lock: %s closed by Lento", model.InlineLink(discussion.URL, "discussion")),
+		},
+		{
+			name:     DiscussionReopened,
+			data:     DiscussionData{Discussion: discussion, Sender: "Lento"},
+			expected: fmt.Sprintf(":unlock: %s reopened by Lento", model.InlineLink(discussion.URL, "discussion")),
+		},
+		{
+			name:     DiscussionComment,
+			data:     DiscussionData{Comment: comment, Mentions: mention, Sender: "Lento"},
+			expected: fmt.Sprintf(":speech_balloon: %s %s commented by Lento", mention, model.InlineLink(comment.URL, "discussion")),
+		},
+		{
+			name:     TODORoot,
+			data:     TODOData{Manager: mention},
+			expected: fmt.Sprintf(":four_leaf_clover: Hello %s. Here's your TODO\r\n", mention),
+		},
+		{
+			name:     TODOReviewRequested,
+			data:     TODOData{Count: 2},
+			expected: ":eyes: " + model.Bold("Waiting for your review") + " (2)",
+		},
+		{
+			name:     TODOAssignedPullRequests,
+			data:     TODOData{Count: 1},
+			expected: ":bust_in_silhouette: " + model.Bold("Pull requests assigned to you") + " (1)",
+		},
+		{
+			name:     TODOChangesRequested,
+			data:     TODOData{Count: 1},
+			expected: ":memo: " + model.Bold("Changes requested on your pull requests") + " (1)",
+		},
+		{
+			name:     TODOFailingChecks,
+			data:     TODOData{Count: 1},
+			expected: ":x: " + model.Bold("Your pull requests with failing checks") + " (1)",
+		},
+		{
+			name:     TODOApproved,
+			data:     TODOData{Count: 1},
+			expected: ":white_check_mark: " + model.Bold("Approved and ready to merge") + " (1)",
+		},
+		{
+			name:     TODOAssignedIssues,
+			data:     TODOData{Count: 3},
+			expected: ":pushpin: " + model.Bold("Issues assigned to you") + " (3)",
+		},
+		{
+			name:     TODOMentions,
+			data:     TODOData{Count: 1},
+			expected: ":speech_balloon: " + model.Bold("Open issues and pull requests mentioning you") + " (1)",
+		},
+		{
+			name:     TODOItem,
+			data:     TODOItemData{Repository: "channel-io/ch-api", Title: "Fix <b>", URL: pr.URL, Draft: true, Age: "3d"},
+			expected: fmt.Sprintf("[channel-io/ch-api] %s (draft) · 3d", model.InlineLink(pr.URL, "Fix &lt;b&gt;")),
+		},
+		{
+			name:     TODOEmpty,
+			data:     TODOData{},
+			expected: ":tada: Nothing to do. Have a nice day!",
+		},
+		{
+			name:     TODOTeamQueueRoot,
+			data:     TODOData{Manager: mention},
+			expected: fmt.Sprintf(":busts_in_silhouette: Hello %s. Here's your teams' review queue\r\n", mention),
+		},
+		{
+			name:     TODOTeamReviewRequested,
+			data:     TODOData{Team: model.Mention(model.MentionTypeTeam, "10", "Backend"), Count: 2},
+			expected: ":busts_in_silhouette: " + model.Bold("Waiting for review from") + " " + model.Mention(model.MentionTypeTeam, "10", "Backend") + " (2)",
+		},
+		{
+			name:     TODONoTeams,
+			data:     TODOData{},
+			expected: ":information_source: You are not a member of any GitHub team.",
+		},
+		{
+			name:     CommandApproved,
+			data:     command,
+			expected: fmt.Sprintf(":100: %s approved by Lento (approved as the GitHub App, which does not count toward required reviews of branch protection)", prLink),
+		},
+		{
+			name:     CommandChangesRequested,
+			data:     command,
+			expected: fmt.Sprintf(":construction: %s changes requested by Lento", prLink),
+		},
+		{
+			name:     CommandCommented,
+			data:     command,
+			expected: fmt.Sprintf(":speech_balloon: %s commented by Lento", prLink),
+		},
+		{
+			name:     CommandAssigned,
+			data:     CommandData{Manager: "Lento", Title: pr.Title, URL: pr.URL, Assignees: "dylan, lento"},
+			expected: fmt.Sprintf(":pray: %s assigned to dylan, lento by Lento", prLink),
+		},
+		{
+			name:     CommandLabeled,
+			data:     CommandData{Manager: "Lento", Title: pr.Title, URL: pr.URL, Labels: "bug"},
+			expected: fmt.Sprintf(":label: bug added to %s by Lento", prLink),
+		},
+		{
+			name:     CommandUnlabeled,
+			data:     CommandData{Manager: "Lento", Title: pr.Title, URL: pr.URL, Labels: "bug"},
+			expected: fmt.Sprintf(":label: bug removed from %s by Lento", prLink),
+		},
+		{
+			name:     CommandClosed,
+			data:     command,
+			expected: fmt.Sprintf(":x: %s closed by Lento", prLink),
+		},
+		{
+			name:     CommandReopened,
+			data:     command,
+			expected: fmt.Sprintf(":unlock: %s reopened by Lento", prLink),
+		},
+		{
+			name:     CommandMerged,
+			data:     CommandData{Manager: "Lento", Title: pr.Title, URL: pr.URL, Method: "squash"},
+			expected: fmt.Sprintf(":white_check_mark: %s merged (squash) by Lento", prLink),
+		},
+		{
+			name:     CommandIssueCreated,
+			data:     CommandData{Manager: "Lento", Title: issue.Title, URL: issue.URL},
+			expected: fmt.Sprintf(":memo: %s created by Lento", model.InlineLink(issue.URL, issue.Title)),
+		},
+		{
+			name:     CommandFailed,
+			data:     CommandData{Command: "githubMerge", Error: "Pull Request is not mergeable"},
+			expected: ":warning: githubMerge failed: Pull Request is not mergeable",
+		},
+		{
+			name:     DigestRoot,
+			data:     DigestData{Scope: "channel-io", Waiting: 3, Stale: 1, Failing: 2},
+			expected: ":newspaper: Review digest for channel-io: 3 waiting for review, 1 stale, 2 failing checks",
+		},
+		{
+			name:     DigestWaiting,
+			data:     DigestData{},
+			expected: ":eyes: " + model.Bold("Waiting for review"),
+		},
+		{
+			name:     DigestStale,
+			data:     DigestData{StaleAfter: "3d"},
+			expected: ":hourglass: " + model.Bold("Not updated for 3d"),
+		},
+		{
+			name:     DigestFailing,
+			data:     DigestData{},
+			expected: ":x: " + model.Bold("Failing checks"),
+		},
+		{
+			name:     MessageTruncated,
+			data:     MessageData{URL: pr.URL},
+			expected: fmt.Sprintf(":scissors: The message is too long and was shortened. See more on %s", model.InlineLink(pr.URL, "GitHub")),
+		},
+		{
+			name:     WorkflowRunCompleted,
+			data:     WorkflowData{Run: run, Sender: "Lento"},
+			expected: fmt.Sprintf(":x: %s failure in 3m5s on main (0123456789) by Lento", model.InlineLink(run.URL, "CI #42")),
+		},
+		{
+			name:     WorkflowRunFailing,
+			data:     WorkflowData{Run: run, FailedJobs: []WorkflowJob{{Name: "test"}}},
+			expected: fmt.Sprintf(":warning: 1 job(s) failed in %s while it is still running", model.InlineLink(run.URL, "CI")),
+		},
+		{
+			name:     WorkflowJobFailed,
+			data:     WorkflowJob{Name: "test", URL: "https://github.com/channel-io/cht-app-github/actions/runs/1/job/2", Conclusion: "failure"},
+			expected: fmt.Sprintf("%s failure", model.InlineLink("https://github.com/channel-io/cht-app-github/actions/runs/1/job/2", "test")),
+		},
+		{
+			name:     DeploymentCreated,
+			data:     DeploymentData{Deployment: deployment, Sender: "Lento"},
+			expected: ":rocket: Deploying main (0123456789) to production by Lento",
+		},
+		{
+			name:     DeploymentStatus,
+			data:     DeploymentData{Deployment: deployment},
+			expected: fmt.Sprintf(":white_check_mark: %s deployment of main (0123456789) success in 1m0s", model.InlineLink(deployment.URL, "production")),
+		},
+		{
+			name: ReleaseReleased,
+			data: ReleaseData{Repository: repo, Release: Release{TagName: "v1.0.0", URL: "https://github.com/channel-io/cht-app-github/releases/v1.0.0"}, Sender: "Dylan"},
+			expected: fmt.Sprintf(":package: %s: %s released by %s", repoLink,
+				model.InlineLink("https://github.com/channel-io/cht-app-github/releases/v1.0.0", "v1.0.0"), "Dylan"),
+		},
+	}
+
+	engine := newTestEngine()
+	assert.Len(t, tests, len(english))
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			rendered, err := engine.RenderLocale(i18n.Chain(), tt.name, tt.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rendered)
+
+			for _, locale := range i18n.Supported {
+				rendered, err := engine.RenderLocale([]i18n.Locale{locale}, tt.name, tt.data)
+				assert.NoError(t, err, locale)
+				assert.NotEmpty(t, rendered, locale)
+			}
+		})
+	}
+}
+
+func TestCatalog_AllLocalesHaveEveryName(t *testing.T) {
+	for _, locale := range i18n.Supported {
+		templates, ok := catalog[locale]
+		if !assert.True(t, ok, locale) {
+			continue
+		}
+		assert.Len(t, templates, len(english), locale)
+		for name := range english {
+			assert.Contains(t, templates, name, locale)
+		}
+	}
+}
+
+func TestEngine_RenderLocale_Fallback(t *testing.T) {
+	engine := newTestEngine()
+	data := TODOData{Manager: "Dylan"}
+
+	rendered, err := engine.RenderLocale(i18n.Chain("ja-JP"), TODORoot, data)
+	assert.NoError(t, err)
+	assert.Equal(t, ":four_leaf_clover: Dylanさん、こんにちは。今日のTODOです\r\n", rendered)
+
+	rendered, err = engine.RenderLocale(i18n.Chain("fr", "ko"), TODORoot, data)
+	assert.NoError(t, err)
+	assert.Equal(t, ":four_leaf_clover: 안녕하세요 Dylan님. 오늘의 TODO 입니다\r\n", rendered)
+
+	rendered, err = engine.RenderLocale(i18n.Chain("fr"), TODORoot, data)
+	assert.NoError(t, err)
+	assert.Equal(t, ":four_leaf_clover: Hello Dylan. Here's your TODO\r\n", rendered)
+}
+
+func newTestEngine() *Engine {
+	return &Engine{defaults: parseCatalog()}
+}
+
+func TestEngine_renderOverride(t *testing.T) {
+	engine := newTestEngine()
+
+	rendered, err := engine.renderOverride(PullRequestOpened, `{{ emoji "rocket" }} {{ bold .PullRequest.Title }} by {{ .Sender }}`, PullRequestData{
+		PullRequest: PullRequest{Title: "Add feature"},
+		Sender:      "Dylan",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ":rocket: <b>Add feature</b> by Dylan", rendered)
+
+	_, err = engine.renderOverride(PullRequestOpened, `{{ .Unknown }}`, PullRequestData{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package template
+
+import (
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+// funcs 는 template 에서 model/antlr.go 의 pattern 을 작성하기 위한 함수입니다.
+//
+//	{{ link .PullRequest.URL "pull request" }}
+//	{{ bold .Repository.Name }} {{ emoji "rocket" }}
+//	{{ resultEmoji .Run.Conclusion }}
+var funcs = texttemplate.FuncMap{
+	"link":   model.InlineLink,
+	"bold":   model.Bold,
+	"italic": model.Italic,
+	"emoji":  model.Emoji,
+	"escape": model.EscapedString,
+	"join":   strings.Join,
+	"mention": func(mentionType, id, name string) string {
+		return model.Mention(model.MentionType(mentionType), id, name)
+	},
+	"resultEmoji": resultEmoji,
+	"shortSHA": func(sha string) string {
+		if len(sha) > 10 {
+			return sha[:10]
+		}
+		return sha
+	},
+}
+
+// resultEmoji 는 ci 결과(check run conclusion, deployment state 등)를 나타내는 emoji 입니다.
+func resultEmoji(result string) string {
+	switch result {
+	case "success":
+		return model.Emoji("white_check_mark")
+	case "failure", "error", "timed_out", "startup_failure", "action_required":
+		return model.Emoji("x")
+	case "cancelled":
+		return model.Emoji("no_entry_sign")
+	case "skipped", "neutral", "stale", "inactive":
+		return model.Emoji("fast_forward")
+	case "", "pending", "queued", "in_progress", "waiting":
+		return model.Emoji("hourglass_flowing_sand")
+	default:
+		return model.Emoji("arrows_counterclockwise")
+	}
+}
@@ -0,0 +1,72 @@
+package template
+
+// Name 는 message template 의 이름입니다. channeltalk.yml 의 templates 에서 key 로 사용합니다.
+type Name string
+
+const (
+	IssueCommentCreated      Name = "issue_comment.created"
+	IssueCommentPullRequest  Name = "issue_comment.pull_request"
+	IssueOpened              Name = "issues.opened"
+	IssueAssigned            Name = "issues.assigned"
+	IssueClosed              Name = "issues.closed"
+	PullRequestBody          Name = "pull_request.body"
+	PullRequestOpened        Name = "pull_request.opened"
+	PullRequestSummary       Name = "pull_request.summary"
+	PullRequestDraftOpened   Name = "pull_request.draft_opened"
+	PullRequestReady         Name = "pull_request.ready_for_review"
+	PullRequestMerged        Name = "pull_request.merged"
+	PullRequestClosed        Name = "pull_request.closed"
+	PullRequestAssigned      Name = "pull_request.assigned"
+	PullRequestSynchronized  Name = "pull_request.synchronize"
+	ReviewRequested          Name = "pull_request.review_requested"
+	ReviewRequestRemoved     Name = "pull_request.review_request_removed"
+	ReviewReminder           Name = "pull_request.review_reminder"
+	ReviewEscalated          Name = "pull_request.review_escalated"
+	PullRequestReviewApprove Name = "pull_request_review.approved"
+	PullRequestReviewComment Name = "pull_request_review.commented"
+	ReviewCodeComments       Name = "pull_request_review_comment.created"
+	ReviewCodeLocation       Name = "pull_request_review_comment.location"
+	CISummary                Name = "ci.summary"
+	CICheckResult            Name = "ci.check"
+	CIFixed                  Name = "ci.fixed"
+	WorkflowRunCompleted     Name = "workflow_run.completed"
+	WorkflowRunFailing       Name = "workflow_run.failing"
+	WorkflowJobFailed        Name = "workflow_job.failed"
+	DeploymentCreated        Name = "deployment.created"
+	DeploymentStatus         Name = "deployment_status.created"
+	ReleaseReleased          Name = "release.released"
+	DiscussionCreated        Name = "discussion.created"
+	DiscussionAnswered       Name = "discussion.answered"
+	DiscussionClosed         Name = "discussion.closed"
+	DiscussionReopened       Name = "discussion.reopened"
+	DiscussionComment        Name = "discussion_comment.created"
+	TODORoot                 Name = "todo.root"
+	TODOReviewRequested      Name = "todo.review_requested"
+	TODOAssignedPullRequests Name = "todo.assigned_pull_requests"
+	TODOChangesRequested     Name = "todo.changes_requested"
+	TODOFailingChecks        Name = "todo.failing_checks"
+	TODOApproved             Name = "todo.approved"
+	TODOAssignedIssues       Name = "todo.assigned_issues"
+	TODOMentions             Name = "todo.mentions"
+	TODOItem                 Name = "todo.item"
+	TODOEmpty                Name = "todo.empty"
+	TODOTeamQueueRoot        Name = "todo.team_queue_root"
+	TODOTeamReviewRequested  Name = "todo.team_review_requested"
+	TODONoTeams              Name = "todo.no_teams"
+	CommandApproved          Name = "command.approved"
+	CommandChangesRequested  Name = "command.changes_requested"
+	CommandCommented         Name = "command.commented"
+	CommandAssigned          Name = "command.assigned"
+	CommandLabeled           Name = "command.labeled"
+	CommandUnlabeled         Name = "command.unlabeled"
+	CommandClosed            Name = "command.closed"
+	CommandReopened          Name = "command.reopened"
+	CommandMerged            Name = "command.merged"
+	CommandIssueCreated      Name = "command.issue_created"
+	CommandFailed            Name = "command.failed"
+	MessageTruncated         Name = "message.truncated"
+	DigestRoot               Name = "digest.root"
+	DigestWaiting            Name = "digest.waiting"
+	DigestStale              Name = "digest.stale"
+	DigestFailing            Name = "digest.failing"
+)
@@ -0,0 +1,134 @@
+package thread
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	threadBucket = []byte("threads")
+	// keyBucket 은 thread 로 issue 를 찾기 위한 역방향 매핑입니다.
+	keyBucket = []byte("thread_keys")
+)
+
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+func NewBoltStore(db *bbolt.DB) (*BoltStore, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(threadBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(keyBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create thread bucket")
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Find(_ context.Context, key Key) (*Thread, error) {
+	var thread *Thread
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(threadBucket).Get([]byte(key.String()))
+		if value == nil {
+			return nil
+		}
+		thread = new(Thread)
+		return json.Unmarshal(value, thread)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if thread != nil && thread.Pending() {
+		return nil, nil
+	}
+	return thread, nil
+}
+
+// FindKey 는 역방향 매핑이 도입되기 전에 저장된 thread 는 찾지 못합니다.
+func (s *BoltStore) FindKey(_ context.Context, thread Thread) (*Key, error) {
+	var key *Key
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(keyBucket).Get([]byte(thread.String()))
+		if value == nil {
+			return nil
+		}
+		key = new(Key)
+		return json.Unmarshal(value, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *BoltStore) Save(_ context.Context, key Key, thread Thread) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return put(tx, key, thread)
+	})
+}
+
+func (s *BoltStore) SaveIfAbsent(_ context.Context, key Key, thread Thread) (bool, error) {
+	saved := false
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		if value := tx.Bucket(threadBucket).Get([]byte(key.String())); value != nil {
+			var existing Thread
+			if err := json.Unmarshal(value, &existing); err != nil {
+				return err
+			}
+			if existing.occupies(time.Now()) {
+				return nil
+			}
+		}
+		saved = true
+		return put(tx, key, thread)
+	})
+	if err != nil {
+		return false, err
+	}
+	return saved, nil
+}
+
+func (s *BoltStore) Delete(_ context.Context, key Key) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(threadBucket)
+		value := bucket.Get([]byte(key.String()))
+		if value == nil {
+			return nil
+		}
+		var thread Thread
+		if err := json.Unmarshal(value, &thread); err != nil {
+			return err
+		}
+		if err := tx.Bucket(keyBucket).Delete([]byte(thread.String())); err != nil {
+			return err
+		}
+		return bucket.Delete([]byte(key.String()))
+	})
+}
+
+// put 은 thread 와 역방향 매핑을 저장합니다. Pending thread 는 root message 가 없으므로 역방향 매핑을 저장하지 않습니다.
+func put(tx *bbolt.Tx, key Key, thread Thread) error {
+	value, err := json.Marshal(thread)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(threadBucket).Put([]byte(key.String()), value); err != nil {
+		return err
+	}
+	if thread.Pending() {
+		return nil
+	}
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(keyBucket).Put([]byte(thread.String()), encodedKey)
+}
@@ -0,0 +1,160 @@
+package thread
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+func TestBoltStore_FindAndSave(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "thread.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewBoltStore(db)
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	key := NewKey("channel-io", "cht-app-github", 1)
+
+	// not found
+	actual, err := s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	expected := Thread{
+		ChannelID:     "1",
+		GroupID:       "23",
+		RootMessageID: "456",
+	}
+	assert.NoError(t, s.Save(ctx, key, expected))
+
+	actual, err = s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, actual)
+
+	foundKey, err := s.FindKey(ctx, expected)
+	assert.NoError(t, err)
+	assert.Equal(t, &key, foundKey)
+
+	// other issue number is not affected
+	actual, err = s.Find(ctx, NewKey("channel-io", "cht-app-github", 2))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "thread.db")
+	ctx := context.TODO()
+	key := NewKey("channel-io", "cht-app-github", 1)
+	expected := Thread{
+		ChannelID:     "1",
+		GroupID:       "23",
+		RootMessageID: "456",
+	}
+
+	db, err := bbolt.Open(path, 0o600, nil)
+	assert.NoError(t, err)
+	s, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Save(ctx, key, expected))
+	assert.NoError(t, db.Close())
+
+	// mapping survives restart
+	db, err = bbolt.Open(path, 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	s, err = NewBoltStore(db)
+	assert.NoError(t, err)
+
+	actual, err := s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, actual)
+}
+
+func TestBoltStore_SaveIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "thread.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	s, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	testSaveIfAbsent(t, s)
+}
+
+func TestMemoryStore_SaveIfAbsent(t *testing.T) {
+	t.Parallel()
+	testSaveIfAbsent(t, NewMemoryStore())
+}
+
+func testSaveIfAbsent(t *testing.T, s Store) {
+	ctx := context.TODO()
+	key := NewKey("channel-io", "cht-app-github", 1)
+	group := model.Group{ChannelID: "1", ID: "23"}
+
+	// 먼저 선점한 event 만 저장합니다.
+	claimed, err := s.SaveIfAbsent(ctx, key, NewPendingThread(group, time.Now()))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = s.SaveIfAbsent(ctx, key, NewPendingThread(group, time.Now()))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	// Pending thread 는 찾지 않습니다.
+	actual, err := s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	// 선점을 풀면 다시 선점할 수 있습니다.
+	assert.NoError(t, s.Delete(ctx, key))
+	claimed, err = s.SaveIfAbsent(ctx, key, NewPendingThread(group, time.Now()))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	expected := Thread{ChannelID: "1", GroupID: "23", RootMessageID: "456"}
+	assert.NoError(t, s.Save(ctx, key, expected))
+	claimed, err = s.SaveIfAbsent(ctx, key, NewPendingThread(group, time.Now()))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	actual, err = s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, actual)
+
+	// root message 를 작성하다 중단된 선점은 만료되면 다시 선점할 수 있습니다.
+	stale := NewKey("channel-io", "cht-app-github", 2)
+	claimed, err = s.SaveIfAbsent(ctx, stale, NewPendingThread(group, time.Now().Add(-ClaimTimeout)))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = s.SaveIfAbsent(ctx, stale, NewPendingThread(group, time.Now()))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+// url 이나 사용자 입력으로 만든 key 도 webhook 의 key 와 같은 thread 를 찾습니다.
+func TestNewKey_MixedCase(t *testing.T) {
+	key := NewKey("Channel-IO", "CHT-App-GitHub", 1)
+	assert.Equal(t, NewKey("channel-io", "cht-app-github", 1), key)
+	assert.Equal(t, "channel-io/cht-app-github#1", key.String())
+
+	ctx := context.TODO()
+	s := NewMemoryStore()
+	expected := Thread{ChannelID: "1", GroupID: "23", RootMessageID: "456"}
+	assert.NoError(t, s.Save(ctx, NewKey("channel-io", "cht-app-github", 1), expected))
+
+	actual, err := s.Find(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, actual)
+}
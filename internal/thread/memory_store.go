@@ -0,0 +1,80 @@
+package thread
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore 는 프로세스 메모리에만 매핑을 저장합니다. 로컬 개발 및 테스트 용도로 사용합니다.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	threads map[Key]Thread
+	keys    map[Thread]Key
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		threads: make(map[Key]Thread),
+		keys:    make(map[Thread]Key),
+	}
+}
+
+func (s *MemoryStore) Find(_ context.Context, key Key) (*Thread, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	thread, ok := s.threads[key]
+	if !ok || thread.Pending() {
+		return nil, nil
+	}
+	return &thread, nil
+}
+
+func (s *MemoryStore) FindKey(_ context.Context, thread Thread) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[thread]
+	if !ok {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, key Key, thread Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.save(key, thread)
+	return nil
+}
+
+func (s *MemoryStore) SaveIfAbsent(_ context.Context, key Key, thread Thread) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if saved, ok := s.threads[key]; ok && saved.occupies(time.Now()) {
+		return false, nil
+	}
+	s.save(key, thread)
+	return true, nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if thread, ok := s.threads[key]; ok {
+		delete(s.keys, thread)
+		delete(s.threads, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) save(key Key, thread Thread) {
+	s.threads[key] = thread
+	if !thread.Pending() {
+		s.keys[thread] = key
+	}
+}
@@ -0,0 +1,88 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/channel-io/cht-app-github/internal/channel/model"
+)
+
+// Key 는 팀챗 thread 와 연결되는 github issue(pull request)를 식별합니다.
+type Key struct {
+	Org        string `json:"org"`
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+}
+
+// NewKey 는 org 와 repository 를 소문자로 맞춥니다.
+// NOTE : github 은 대소문자를 구분하지 않으므로 url 이나 사용자 입력으로 만든 key 도 webhook 으로 만든 key 와 같아야 합니다.
+func NewKey(org, repository string, number int) Key {
+	return Key{
+		Org:        strings.ToLower(org),
+		Repository: strings.ToLower(repository),
+		Number:     number,
+	}
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%s#%d", k.Org, k.Repository, k.Number)
+}
+
+// ClaimTimeout 이 지난 Pending thread 는 root message 를 작성하다 중단된 것으로 보고 다시 선점할 수 있습니다.
+const ClaimTimeout = 5 * time.Minute
+
+// Thread 는 issue 에 대해 작성된 팀챗 root message 의 위치입니다.
+type Thread struct {
+	ChannelID     string `json:"channelId"`
+	GroupID       string `json:"groupId"`
+	RootMessageID string `json:"rootMessageId"`
+	// ClaimedAt 은 root message 를 작성하기 전에 thread 를 선점한 시각입니다.
+	ClaimedAt *time.Time `json:"claimedAt,omitempty"`
+}
+
+// NewPendingThread 는 root message 를 작성하기 전에 key 를 선점하는 thread 입니다.
+func NewPendingThread(group model.Group, now time.Time) Thread {
+	return Thread{
+		ChannelID: group.ChannelID,
+		GroupID:   group.ID,
+		ClaimedAt: &now,
+	}
+}
+
+// Pending 은 root message 를 아직 작성하지 않은 thread 입니다.
+func (t Thread) Pending() bool {
+	return t.RootMessageID == ""
+}
+
+// occupies 는 SaveIfAbsent 가 덮어쓰지 않는 thread 입니다. 만료된 Pending thread 는 덮어씁니다.
+func (t Thread) occupies(now time.Time) bool {
+	return !t.Pending() || t.ClaimedAt == nil || now.Sub(*t.ClaimedAt) < ClaimTimeout
+}
+
+func (t Thread) String() string {
+	return fmt.Sprintf("%s/%s/%s", t.ChannelID, t.GroupID, t.RootMessageID)
+}
+
+func (t Thread) Group() model.Group {
+	return model.Group{
+		ChannelID: t.ChannelID,
+		ID:        t.GroupID,
+	}
+}
+
+// Store 는 issue 와 팀챗 thread 의 매핑을 영속적으로 저장합니다.
+// Redis, Postgres 등 외부 저장소를 사용하려면 이 인터페이스를 구현합니다.
+type Store interface {
+	// Find 는 Pending thread 는 찾지 않습니다.
+	Find(ctx context.Context, key Key) (*Thread, error)
+	// FindKey 는 thread 에 연결된 issue 를 찾습니다. 팀챗 thread 에서 github 으로 동작을 보낼 때 사용합니다.
+	FindKey(ctx context.Context, thread Thread) (*Key, error)
+	Save(ctx context.Context, key Key, thread Thread) error
+	// SaveIfAbsent 는 key 에 thread 가 없을 때만 저장하고, 저장했으면 true 를 반환합니다. 확인과 저장은 atomic 합니다.
+	// root message 를 작성하기 전에 Pending thread 로 key 를 선점해서 같은 issue 에 root message 를 두 번 작성하지 않도록 합니다.
+	SaveIfAbsent(ctx context.Context, key Key, thread Thread) (bool, error)
+	// Delete 는 root message 를 작성하지 못한 Pending thread 의 선점을 풉니다.
+	Delete(ctx context.Context, key Key) error
+}
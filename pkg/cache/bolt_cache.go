@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// NewBoltCache 는 bucket 에 값을 영속적으로 저장하는 Cache 를 생성합니다.
+// 만료된 값은 조회 시점에 삭제되고, 조회되지 않는 값은 SweepBolt 로 정리합니다.
+func NewBoltCache[T any](db *bbolt.DB, bucket string) (BoltCache[T], error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		return BoltCache[T]{}, errors.Wrapf(err, "failed to create %s bucket", bucket)
+	}
+	return BoltCache[T]{
+		db:     db,
+		bucket: []byte(bucket),
+	}, nil
+}
+
+type BoltCache[T any] struct {
+	db     *bbolt.DB
+	bucket []byte
+}
+
+type boltCacheEntry[T any] struct {
+	Value     T         `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (c BoltCache[T]) Get(_ context.Context, key string) (*T, error) {
+	var entry *boltCacheEntry[T]
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(c.bucket).Get([]byte(key))
+		if value == nil {
+			return nil
+		}
+		entry = new(boltCacheEntry[T])
+		return json.Unmarshal(value, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		err := c.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(c.bucket).Delete([]byte(key))
+		})
+		return nil, err
+	}
+	return &entry.Value, nil
+}
+
+// Set 은 expiry 가 0 이하인 경우 만료되지 않는 값으로 저장합니다.
+func (c BoltCache[T]) Set(_ context.Context, key string, value T, expiry time.Duration) error {
+	encoded, err := encodeBoltCacheEntry(value, expiry)
+	if err != nil {
+		return err
+	}
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(c.bucket).Put([]byte(key), encoded)
+	})
+}
+
+// SetIfAbsent 는 하나의 write transaction 안에서 값을 확인하고 저장하므로 동시에 호출해도 하나만 저장됩니다.
+func (c BoltCache[T]) SetIfAbsent(_ context.Context, key string, value T, expiry time.Duration) (bool, error) {
+	encoded, err := encodeBoltCacheEntry(value, expiry)
+	if err != nil {
+		return false, err
+	}
+
+	stored := false
+	err = c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(c.bucket)
+		if existing := bucket.Get([]byte(key)); existing != nil {
+			expired, err := isExpired(existing, time.Now())
+			if err != nil {
+				return err
+			}
+			if !expired {
+				return nil
+			}
+		}
+		stored = true
+		return bucket.Put([]byte(key), encoded)
+	})
+	return stored, err
+}
+
+func (c BoltCache[T]) Delete(_ context.Context, key string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(c.bucket).Delete([]byte(key))
+	})
+}
+
+// SweepBolt 는 bucket 에서 만료된 값을 삭제하고 삭제한 개수를 반환합니다.
+// 값의 type 과 관계없이 BoltCache 의 bucket 이라면 정리할 수 있습니다.
+func SweepBolt(db *bbolt.DB, bucket string, now time.Time) (int, error) {
+	swept := 0
+	err := db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		// NOTE : cursor 로 순회하는 중에 삭제하면 다음 key 를 건너뛸 수 있으므로 key 를 모은 뒤 삭제합니다.
+		var expiredKeys [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			expired, err := isExpired(v, now)
+			if err != nil {
+				return errors.Wrapf(err, "failed to decode %s/%s", bucket, k)
+			}
+			if expired {
+				expiredKeys = append(expiredKeys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range expiredKeys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		swept = len(expiredKeys)
+		return nil
+	})
+	return swept, err
+}
+
+func encodeBoltCacheEntry[T any](value T, expiry time.Duration) ([]byte, error) {
+	entry := boltCacheEntry[T]{Value: value}
+	if expiry > 0 {
+		entry.ExpiresAt = time.Now().Add(expiry)
+	}
+	return json.Marshal(entry)
+}
+
+// isExpired 는 값을 decode 하지 않고 만료 시각만 확인합니다.
+func isExpired(encoded []byte, now time.Time) (bool, error) {
+	var entry struct {
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(encoded, &entry); err != nil {
+		return false, err
+	}
+	return !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt), nil
+}
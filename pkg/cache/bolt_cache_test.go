@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestBoltCache_GetAndSet(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "cache.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	c, err := NewBoltCache[string](db, "test")
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	actual, err := c.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	assert.NoError(t, c.Set(ctx, "key", "value", time.Minute))
+	actual, err = c.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", *actual)
+
+	// no expiry
+	assert.NoError(t, c.Set(ctx, "forever", "value", -1))
+	actual, err = c.Get(ctx, "forever")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", *actual)
+}
+
+func TestBoltCache_Expired(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "cache.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	c, err := NewBoltCache[int](db, "test")
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	assert.NoError(t, c.Set(ctx, "key", 1, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	actual, err := c.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestBoltCache_SetIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "cache.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	c, err := NewBoltCache[string](db, "test")
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	stored, err := c.SetIfAbsent(ctx, "key", "first", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, stored)
+
+	stored, err = c.SetIfAbsent(ctx, "key", "second", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, stored)
+	actual, err := c.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", *actual)
+
+	// expired value is treated as absent
+	assert.NoError(t, c.Set(ctx, "expired", "old", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	stored, err = c.SetIfAbsent(ctx, "expired", "new", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, stored)
+
+	assert.NoError(t, c.Delete(ctx, "key"))
+	stored, err = c.SetIfAbsent(ctx, "key", "third", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, stored)
+}
+
+func TestSweepBolt(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "cache.db"), 0o600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	c, err := NewBoltCache[int](db, "test")
+	assert.NoError(t, err)
+
+	ctx := context.TODO()
+	assert.NoError(t, c.Set(ctx, "expired-1", 1, time.Minute))
+	assert.NoError(t, c.Set(ctx, "expired-2", 2, time.Minute))
+	assert.NoError(t, c.Set(ctx, "alive", 3, time.Hour))
+	assert.NoError(t, c.Set(ctx, "forever", 4, -1))
+
+	swept, err := SweepBolt(db, "test", time.Now().Add(10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, swept)
+
+	for key, expected := range map[string]bool{"expired-1": false, "expired-2": false, "alive": true, "forever": true} {
+		var exists bool
+		assert.NoError(t, db.View(func(tx *bbolt.Tx) error {
+			exists = tx.Bucket([]byte("test")).Get([]byte(key)) != nil
+			return nil
+		}))
+		assert.Equal(t, expected, exists, key)
+	}
+
+	// unknown bucket
+	swept, err = SweepBolt(db, "unknown", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, swept)
+}
@@ -9,3 +9,11 @@ type Cache[T any] interface {
 	Get(ctx context.Context, key string) (*T, error)
 	Set(ctx context.Context, key string, value T, expiry time.Duration) error
 }
+
+// AtomicCache 는 여러 worker 가 같은 key 를 동시에 선점하지 않아야 할 때 사용합니다.
+type AtomicCache[T any] interface {
+	Cache[T]
+	// SetIfAbsent 는 key 가 없거나 만료된 경우에만 값을 저장하고, 저장했는지 여부를 반환합니다.
+	SetIfAbsent(ctx context.Context, key string, value T, expiry time.Duration) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
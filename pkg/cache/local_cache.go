@@ -36,3 +36,13 @@ func (c LocalCache[T]) Set(_ context.Context, key string, value T, expiry time.D
 	c.Cache.Set(key, value, expiry)
 	return nil
 }
+
+func (c LocalCache[T]) SetIfAbsent(_ context.Context, key string, value T, expiry time.Duration) (bool, error) {
+	// NOTE : go-cache 의 Add 는 만료되지 않은 값이 있으면 error 를 반환합니다.
+	return c.Cache.Add(key, value, expiry) == nil, nil
+}
+
+func (c LocalCache[T]) Delete(_ context.Context, key string) error {
+	c.Cache.Delete(key)
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/channel-io/cht-app-github/internal/githubfx"
 	"github.com/channel-io/cht-app-github/internal/httpfx"
 	"github.com/channel-io/cht-app-github/internal/loggerfx"
+	"github.com/channel-io/cht-app-github/internal/schedulerfx"
+	"github.com/channel-io/cht-app-github/internal/storagefx"
 )
 
 func integratedTestModule() fx.Option {
@@ -20,7 +22,9 @@ func integratedTestModule() fx.Option {
 		httpfx.Option,
 		loggerfx.Option,
 		eventfx.Option,
+		schedulerfx.Option,
 		githubfx.Module(),
 		functionfx.Module(),
+		storagefx.Module(),
 	)
 }